@@ -8,6 +8,7 @@ import (
 	"github.com/assu-2000/StreamRPC/internal/pb"
 	"github.com/assu-2000/StreamRPC/internal/room"
 	"github.com/assu-2000/StreamRPC/internal/server"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/redis/go-redis/v9"
 	"google.golang.org/grpc"
 	"log"
@@ -20,15 +21,9 @@ import (
 func main() {
 	pgConfig := config.LoadPostgresConfig()
 	jwtConfig := config.LoadJWTConfig()
+	roomConfig := config.LoadRoomConfig()
 	jwtService := auth.NewJWTService(jwtConfig)
 
-	redisClient := initRedis()
-
-	// RoomService
-	roomRepo := room.NewRedisRepository(redisClient)
-	roomService := room.NewRoomService(roomRepo)
-	roomHandler := room.NewGRPCHandler(roomService)
-
 	//
 	pgPool, err := database.NewPostgresConnection((*database.PostgresConfig)(pgConfig))
 	if err != nil {
@@ -36,11 +31,20 @@ func main() {
 	}
 	defer pgPool.Close()
 
+	// RoomService
+	roomService := room.NewRoomService(initRoomRepository(roomConfig, pgPool), initProfileDirectory(roomConfig, pgPool), roomConfig)
+	roomHandler := room.NewGRPCHandler(roomService)
+	messageHandler := room.NewMessageGRPCHandler(roomService)
+	spaceHandler := room.NewSpaceGRPCHandler(roomService)
+
 	authRepo := auth.NewUserPostgresRepository(pgPool)
 	tokenRepo := auth.NewPostgresTokenRepository(pgPool)
 	tokenService := auth.NewTokenService(tokenRepo, jwtService, jwtConfig.AccessDuration, jwtConfig.RefreshDuration)
 
 	authService := auth.NewAuthService(authRepo, jwtService, tokenService)
+	for _, method := range room.GuestMethods {
+		authService.SetMethodAuth(method, auth.AuthOptional)
+	}
 
 	lis, err := net.Listen("tcp", ":50051")
 	if err != nil {
@@ -55,6 +59,14 @@ func main() {
 		AuthService: authService,
 	})
 	pb.RegisterRoomGrpcServiceServer(s, roomHandler)
+	pb.RegisterMessageGrpcServiceServer(s, messageHandler)
+	pb.RegisterSpaceGrpcServiceServer(s, spaceHandler)
+
+	backgroundCtx, stopBackground := context.WithCancel(context.Background())
+	defer stopBackground()
+	go roomService.RunPresenceHeartbeat(backgroundCtx)
+	go roomService.RunArchivePurger(backgroundCtx)
+	go roomService.RunReconciler(backgroundCtx)
 
 	go func() {
 		log.Println("Server starting on port 50051...")
@@ -72,6 +84,32 @@ func main() {
 	log.Println("Server stopped")
 }
 
+// initRoomRepository picks the storage of rooms configured by ROOM_STORE
+func initRoomRepository(cfg config.RoomConfig, pgPool *pgxpool.Pool) room.RoomRepository {
+	switch cfg.Store {
+	case config.MemoryStore:
+		log.Println("Rooms are kept in memory, this node cannot share them with other nodes")
+		return room.NewMemoryRepository()
+	case config.PostgresStore:
+		return room.NewCachedRepository(room.NewPostgresRepository(pgPool), room.NewRedisRepository(initRedis()), cfg.CacheTTL)
+	}
+
+	redisRepo := room.NewRedisRepository(initRedis())
+	if err := redisRepo.RebuildRoomIndexes(context.Background()); err != nil {
+		log.Fatalf("Failed to rebuild room indexes: %v", err)
+	}
+	return redisRepo
+}
+
+// initProfileDirectory leaves the rooms of the memory store away from Postgres, their members are
+// listed without profile
+func initProfileDirectory(cfg config.RoomConfig, pgPool *pgxpool.Pool) room.ProfileDirectory {
+	if cfg.Store == config.MemoryStore {
+		return nil
+	}
+	return room.NewPostgresProfileRepository(pgPool)
+}
+
 func initRedis() *redis.Client {
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:            os.Getenv("REDIS_URL"),
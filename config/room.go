@@ -0,0 +1,143 @@
+package config
+
+import (
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SlowConsumerPolicy decides what happens to a stream that cannot keep up with its room
+type SlowConsumerPolicy string
+
+const (
+	// DropOldest discards the oldest queued event to make room for the new one
+	DropOldest SlowConsumerPolicy = "drop-oldest"
+	// Disconnect ends the stream as soon as its buffer is full
+	Disconnect SlowConsumerPolicy = "disconnect"
+	// Spill writes up to EventSpillSize more events to a temporary file, delivered in order once the
+	// buffer drains, before disconnecting the stream
+	Spill SlowConsumerPolicy = "spill"
+)
+
+// RoomStoreKind selects the storage behind rooms and memberships
+type RoomStoreKind string
+
+const (
+	RedisStore    RoomStoreKind = "redis"
+	PostgresStore RoomStoreKind = "postgres"
+	// MemoryStore keeps everything in the process, for a single node in development
+	MemoryStore RoomStoreKind = "memory"
+)
+
+type RoomConfig struct {
+	// NodeID identifies this server instance in the presence store
+	NodeID string
+	// PresenceTTL is how long a session stays online without a heartbeat
+	PresenceTTL time.Duration
+	// EventBufferSize is the number of events queued per stream before SlowConsumerPolicy applies
+	EventBufferSize int
+	// EventSpillSize is the number of events a stream can spill to disk under the Spill policy
+	EventSpillSize     int
+	SlowConsumerPolicy SlowConsumerPolicy
+	// ArchiveGracePeriod is how long an archived room is kept before it is deleted for good
+	ArchiveGracePeriod   time.Duration
+	ArchivePurgeInterval time.Duration
+	// Store is where rooms and memberships are persisted: Redis alone, Postgres with Redis as cache
+	// or the memory of the process
+	Store RoomStoreKind
+	// CacheTTL is how long a room stays in the Redis cache when Store is Postgres
+	CacheTTL time.Duration
+	// MessageHistorySize is the number of recent messages kept per room
+	MessageHistorySize int
+	// AdminUserIDs are the users allowed to run server-wide operations such as reconciling rooms
+	AdminUserIDs []string
+	// ReconcileInterval is how often stale room state is looked for and repaired
+	ReconcileInterval time.Duration
+}
+
+func LoadRoomConfig() RoomConfig {
+	err := godotenv.Load(".env")
+	if err != nil {
+		log.Fatal("Error loading .env file")
+	}
+
+	nodeID := os.Getenv("NODE_ID")
+	if nodeID == "" {
+		hostname, _ := os.Hostname()
+		nodeID = hostname + "-" + uuid.New().String()[:8]
+	}
+
+	policy := SlowConsumerPolicy(os.Getenv("SLOW_CONSUMER_POLICY"))
+	switch policy {
+	case "":
+		policy = DropOldest
+	case DropOldest, Disconnect, Spill:
+	default:
+		log.Fatalf("Invalid SLOW_CONSUMER_POLICY: %s", policy)
+	}
+
+	store := RoomStoreKind(os.Getenv("ROOM_STORE"))
+	switch store {
+	case "":
+		store = RedisStore
+	case RedisStore, PostgresStore, MemoryStore:
+	default:
+		log.Fatalf("Invalid ROOM_STORE: %s", store)
+	}
+
+	return RoomConfig{
+		NodeID:               nodeID,
+		PresenceTTL:          durationFromEnv("PRESENCE_TTL", 30*time.Second),
+		EventBufferSize:      intFromEnv("EVENT_BUFFER_SIZE", 64),
+		EventSpillSize:       intFromEnv("EVENT_SPILL_SIZE", 1024),
+		SlowConsumerPolicy:   policy,
+		ArchiveGracePeriod:   durationFromEnv("ARCHIVE_GRACE_PERIOD", 30*24*time.Hour),
+		ArchivePurgeInterval: durationFromEnv("ARCHIVE_PURGE_INTERVAL", time.Minute),
+		Store:                store,
+		CacheTTL:             durationFromEnv("ROOM_CACHE_TTL", 10*time.Minute),
+		MessageHistorySize:   intFromEnv("MESSAGE_HISTORY_SIZE", 1000),
+		AdminUserIDs:         listFromEnv("ROOM_ADMIN_USER_IDS"),
+		ReconcileInterval:    durationFromEnv("ROOM_RECONCILE_INTERVAL", time.Hour),
+	}
+}
+
+func intFromEnv(key string, fallback int) int {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	n, err := strconv.Atoi(value)
+	if err != nil || n <= 0 {
+		log.Fatalf("Invalid %s: %s", key, value)
+	}
+	return n
+}
+
+func durationFromEnv(key string, fallback time.Duration) time.Duration {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", key, err)
+	}
+	return d
+}
+
+// listFromEnv splits a comma-separated variable, ignoring blank entries
+func listFromEnv(key string) []string {
+	var values []string
+	for _, value := range strings.Split(os.Getenv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -2,7 +2,6 @@ package auth
 
 import (
 	"context"
-	"fmt"
 	"strings"
 
 	"google.golang.org/grpc"
@@ -16,6 +15,24 @@ const (
 	bearerPrefix        = "Bearer "
 )
 
+// MethodAuth is how the interceptors treat the token sent with a call to a given method
+type MethodAuth int
+
+const (
+	// AuthRequired rejects calls without a valid token, methods not configured otherwise get it
+	AuthRequired MethodAuth = iota
+	// AuthOptional also serves anonymous calls, a token sent must still be valid
+	AuthOptional
+	// AuthSkipped ignores the token altogether
+	AuthSkipped
+)
+
+// SetMethodAuth sets how calls to the method, given by its full gRPC name, are authenticated.
+// It is meant to be called before the server starts serving.
+func (s *AuthService) SetMethodAuth(fullMethod string, mode MethodAuth) {
+	s.methodAuth[fullMethod] = mode
+}
+
 func (s *AuthService) UnaryInterceptor() grpc.UnaryServerInterceptor {
 	return func(
 		ctx context.Context,
@@ -23,29 +40,10 @@ func (s *AuthService) UnaryInterceptor() grpc.UnaryServerInterceptor {
 		info *grpc.UnaryServerInfo,
 		handler grpc.UnaryHandler,
 	) (interface{}, error) {
-		// Skip auth for login and register
-		if info.FullMethod == "/chat.AuthGrpcService/Login" ||
-			info.FullMethod == "/chat.AuthGrpcService/Register" {
-			return handler(ctx, req)
-		}
-
-		md, ok := metadata.FromIncomingContext(ctx)
-		if !ok {
-			return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
-		}
-
-		token, err := extractToken(md)
+		newCtx, err := s.authenticate(ctx, info.FullMethod)
 		if err != nil {
 			return nil, err
 		}
-
-		claims, err := s.jwtService.ValidateToken(token)
-		if err != nil {
-			return nil, status.Error(codes.Unauthenticated, "invalid token")
-		}
-		// Adding claims to the contexte (user_id)
-		newCtx := context.WithValue(ctx, "user_id", claims.UserID)
-		fmt.Println("Interceptor user id:", claims.UserID)
 		return handler(newCtx, req)
 	}
 }
@@ -57,31 +55,44 @@ func (s *AuthService) StreamInterceptor() grpc.StreamServerInterceptor {
 		info *grpc.StreamServerInfo,
 		handler grpc.StreamHandler,
 	) error {
-		md, ok := metadata.FromIncomingContext(ss.Context())
-		if !ok {
-			return status.Error(codes.Unauthenticated, "metadata is not provided")
-		}
-
-		token, err := extractToken(md)
+		newCtx, err := s.authenticate(ss.Context(), info.FullMethod)
 		if err != nil {
 			return err
 		}
 
-		claims, err := s.jwtService.ValidateToken(token)
-		if err != nil {
-			return status.Error(codes.Unauthenticated, "invalid token")
-		}
-
-		// Inject user_id in stream context
-		newCtx := context.WithValue(ss.Context(), "user_id", claims.UserID)
-
 		// Wrap stream to override its context with the new one
 		wrapped := &wrappedStream{ServerStream: ss, ctx: newCtx}
+		return handler(srv, wrapped)
+	}
+}
 
-		fmt.Println("Stream Interceptor user id:", claims.UserID)
+// authenticate returns ctx with the user_id of the caller added,
+// or ctx as is for the anonymous calls the method accepts
+func (s *AuthService) authenticate(ctx context.Context, fullMethod string) (context.Context, error) {
+	mode := s.methodAuth[fullMethod]
+	if mode == AuthSkipped {
+		return ctx, nil
+	}
 
-		return handler(srv, wrapped)
+	md, ok := metadata.FromIncomingContext(ctx)
+	if mode == AuthOptional && (!ok || len(md.Get(authorizationHeader)) == 0) {
+		return ctx, nil
+	}
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "metadata is not provided")
+	}
+
+	token, err := extractToken(md)
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := s.jwtService.ValidateToken(token)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid token")
 	}
+	// Adding claims to the contexte (user_id)
+	return context.WithValue(ctx, "user_id", claims.UserID), nil
 }
 
 func extractToken(md metadata.MD) (string, error) {
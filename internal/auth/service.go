@@ -17,12 +17,18 @@ type AuthService struct {
 	repo         UserRepo
 	jwtService   *JWTService
 	tokenService *TokenService
+	// methodAuth holds the methods that do not require a token, keyed by their full gRPC name
+	methodAuth map[string]MethodAuth
 }
 
 func NewAuthService(repo UserRepo, jwt *JWTService, token *TokenService) *AuthService {
 	return &AuthService{repo: repo,
 		jwtService:   jwt,
 		tokenService: token,
+		methodAuth: map[string]MethodAuth{
+			"/chat.AuthGrpcService/Login":    AuthSkipped,
+			"/chat.AuthGrpcService/Register": AuthSkipped,
+		},
 	}
 }
 
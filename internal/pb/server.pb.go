@@ -9,7 +9,9 @@ package pb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
 	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	fieldmaskpb "google.golang.org/protobuf/types/known/fieldmaskpb"
 	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
 	reflect "reflect"
 	sync "sync"
@@ -23,6 +25,283 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type JoinRequestState int32
+
+const (
+	JoinRequestState_JOIN_REQUEST_PENDING  JoinRequestState = 0
+	JoinRequestState_JOIN_REQUEST_APPROVED JoinRequestState = 1
+	JoinRequestState_JOIN_REQUEST_REJECTED JoinRequestState = 2
+)
+
+// Enum value maps for JoinRequestState.
+var (
+	JoinRequestState_name = map[int32]string{
+		0: "JOIN_REQUEST_PENDING",
+		1: "JOIN_REQUEST_APPROVED",
+		2: "JOIN_REQUEST_REJECTED",
+	}
+	JoinRequestState_value = map[string]int32{
+		"JOIN_REQUEST_PENDING":  0,
+		"JOIN_REQUEST_APPROVED": 1,
+		"JOIN_REQUEST_REJECTED": 2,
+	}
+)
+
+func (x JoinRequestState) Enum() *JoinRequestState {
+	p := new(JoinRequestState)
+	*p = x
+	return p
+}
+
+func (x JoinRequestState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (JoinRequestState) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_pb_server_proto_enumTypes[0].Descriptor()
+}
+
+func (JoinRequestState) Type() protoreflect.EnumType {
+	return &file_internal_pb_server_proto_enumTypes[0]
+}
+
+func (x JoinRequestState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use JoinRequestState.Descriptor instead.
+func (JoinRequestState) EnumDescriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{0}
+}
+
+type InconsistencyKind int32
+
+const (
+	// a member set for a room that no longer exists
+	InconsistencyKind_INCONSISTENCY_ORPHANED_MEMBERS InconsistencyKind = 0
+	// a listed room whose data is gone
+	InconsistencyKind_INCONSISTENCY_MISSING_ROOM       InconsistencyKind = 1
+	InconsistencyKind_INCONSISTENCY_MEMBER_COUNT_DRIFT InconsistencyKind = 2
+	// members with no live session, only reported
+	InconsistencyKind_INCONSISTENCY_MEMBERS_WITHOUT_SESSION InconsistencyKind = 3
+	// Redis keys of a room the database no longer has
+	InconsistencyKind_INCONSISTENCY_ORPHANED_ROOM_KEYS InconsistencyKind = 4
+)
+
+// Enum value maps for InconsistencyKind.
+var (
+	InconsistencyKind_name = map[int32]string{
+		0: "INCONSISTENCY_ORPHANED_MEMBERS",
+		1: "INCONSISTENCY_MISSING_ROOM",
+		2: "INCONSISTENCY_MEMBER_COUNT_DRIFT",
+		3: "INCONSISTENCY_MEMBERS_WITHOUT_SESSION",
+		4: "INCONSISTENCY_ORPHANED_ROOM_KEYS",
+	}
+	InconsistencyKind_value = map[string]int32{
+		"INCONSISTENCY_ORPHANED_MEMBERS":        0,
+		"INCONSISTENCY_MISSING_ROOM":            1,
+		"INCONSISTENCY_MEMBER_COUNT_DRIFT":      2,
+		"INCONSISTENCY_MEMBERS_WITHOUT_SESSION": 3,
+		"INCONSISTENCY_ORPHANED_ROOM_KEYS":      4,
+	}
+)
+
+func (x InconsistencyKind) Enum() *InconsistencyKind {
+	p := new(InconsistencyKind)
+	*p = x
+	return p
+}
+
+func (x InconsistencyKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (InconsistencyKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_pb_server_proto_enumTypes[1].Descriptor()
+}
+
+func (InconsistencyKind) Type() protoreflect.EnumType {
+	return &file_internal_pb_server_proto_enumTypes[1]
+}
+
+func (x InconsistencyKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use InconsistencyKind.Descriptor instead.
+func (InconsistencyKind) EnumDescriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{1}
+}
+
+type MemberRole int32
+
+const (
+	MemberRole_ROLE_MEMBER    MemberRole = 0
+	MemberRole_ROLE_MODERATOR MemberRole = 1
+	MemberRole_ROLE_ADMIN     MemberRole = 2
+	MemberRole_ROLE_OWNER     MemberRole = 3
+)
+
+// Enum value maps for MemberRole.
+var (
+	MemberRole_name = map[int32]string{
+		0: "ROLE_MEMBER",
+		1: "ROLE_MODERATOR",
+		2: "ROLE_ADMIN",
+		3: "ROLE_OWNER",
+	}
+	MemberRole_value = map[string]int32{
+		"ROLE_MEMBER":    0,
+		"ROLE_MODERATOR": 1,
+		"ROLE_ADMIN":     2,
+		"ROLE_OWNER":     3,
+	}
+)
+
+func (x MemberRole) Enum() *MemberRole {
+	p := new(MemberRole)
+	*p = x
+	return p
+}
+
+func (x MemberRole) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MemberRole) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_pb_server_proto_enumTypes[2].Descriptor()
+}
+
+func (MemberRole) Type() protoreflect.EnumType {
+	return &file_internal_pb_server_proto_enumTypes[2]
+}
+
+func (x MemberRole) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MemberRole.Descriptor instead.
+func (MemberRole) EnumDescriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{2}
+}
+
+type MemberStatus int32
+
+const (
+	// no live session on any server
+	MemberStatus_MEMBER_STATUS_AWAY   MemberStatus = 0
+	MemberStatus_MEMBER_STATUS_ONLINE MemberStatus = 1
+)
+
+// Enum value maps for MemberStatus.
+var (
+	MemberStatus_name = map[int32]string{
+		0: "MEMBER_STATUS_AWAY",
+		1: "MEMBER_STATUS_ONLINE",
+	}
+	MemberStatus_value = map[string]int32{
+		"MEMBER_STATUS_AWAY":   0,
+		"MEMBER_STATUS_ONLINE": 1,
+	}
+)
+
+func (x MemberStatus) Enum() *MemberStatus {
+	p := new(MemberStatus)
+	*p = x
+	return p
+}
+
+func (x MemberStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MemberStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_pb_server_proto_enumTypes[3].Descriptor()
+}
+
+func (MemberStatus) Type() protoreflect.EnumType {
+	return &file_internal_pb_server_proto_enumTypes[3]
+}
+
+func (x MemberStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MemberStatus.Descriptor instead.
+func (MemberStatus) EnumDescriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{3}
+}
+
+type RoomEventType int32
+
+const (
+	RoomEventType_ROOM_EVENT_USER_JOINED           RoomEventType = 0
+	RoomEventType_ROOM_EVENT_USER_LEFT             RoomEventType = 1
+	RoomEventType_ROOM_EVENT_ROOM_DELETED          RoomEventType = 2
+	RoomEventType_ROOM_EVENT_MESSAGE               RoomEventType = 3
+	RoomEventType_ROOM_EVENT_ROOM_UPDATED          RoomEventType = 4
+	RoomEventType_ROOM_EVENT_WAITLIST_PROMOTED     RoomEventType = 5
+	RoomEventType_ROOM_EVENT_OWNERSHIP_TRANSFERRED RoomEventType = 6
+	RoomEventType_ROOM_EVENT_JOIN_REQUESTED        RoomEventType = 7
+	RoomEventType_ROOM_EVENT_JOIN_REQUEST_RESOLVED RoomEventType = 8
+	RoomEventType_ROOM_EVENT_METADATA_CHANGED      RoomEventType = 9
+)
+
+// Enum value maps for RoomEventType.
+var (
+	RoomEventType_name = map[int32]string{
+		0: "ROOM_EVENT_USER_JOINED",
+		1: "ROOM_EVENT_USER_LEFT",
+		2: "ROOM_EVENT_ROOM_DELETED",
+		3: "ROOM_EVENT_MESSAGE",
+		4: "ROOM_EVENT_ROOM_UPDATED",
+		5: "ROOM_EVENT_WAITLIST_PROMOTED",
+		6: "ROOM_EVENT_OWNERSHIP_TRANSFERRED",
+		7: "ROOM_EVENT_JOIN_REQUESTED",
+		8: "ROOM_EVENT_JOIN_REQUEST_RESOLVED",
+		9: "ROOM_EVENT_METADATA_CHANGED",
+	}
+	RoomEventType_value = map[string]int32{
+		"ROOM_EVENT_USER_JOINED":           0,
+		"ROOM_EVENT_USER_LEFT":             1,
+		"ROOM_EVENT_ROOM_DELETED":          2,
+		"ROOM_EVENT_MESSAGE":               3,
+		"ROOM_EVENT_ROOM_UPDATED":          4,
+		"ROOM_EVENT_WAITLIST_PROMOTED":     5,
+		"ROOM_EVENT_OWNERSHIP_TRANSFERRED": 6,
+		"ROOM_EVENT_JOIN_REQUESTED":        7,
+		"ROOM_EVENT_JOIN_REQUEST_RESOLVED": 8,
+		"ROOM_EVENT_METADATA_CHANGED":      9,
+	}
+)
+
+func (x RoomEventType) Enum() *RoomEventType {
+	p := new(RoomEventType)
+	*p = x
+	return p
+}
+
+func (x RoomEventType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (RoomEventType) Descriptor() protoreflect.EnumDescriptor {
+	return file_internal_pb_server_proto_enumTypes[4].Descriptor()
+}
+
+func (RoomEventType) Type() protoreflect.EnumType {
+	return &file_internal_pb_server_proto_enumTypes[4]
+}
+
+func (x RoomEventType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use RoomEventType.Descriptor instead.
+func (RoomEventType) EnumDescriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{4}
+}
+
 type LoginRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Username      string                 `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
@@ -572,9 +851,14 @@ func (x *ServerMessage) GetSender() string {
 }
 
 type CreateRoomRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
-	IsPrivate     bool                   `protobuf:"varint,2,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IsPrivate bool                   `protobuf:"varint,2,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	// 0 leaves the room uncapped
+	MaxMembers uint32 `protobuf:"varint,3,opt,name=max_members,json=maxMembers,proto3" json:"max_members,omitempty"`
+	// template_id sets the room up from the template, name then fills its name pattern and
+	// is_private is ignored. The room is only created once everything in the template applied.
+	TemplateId    string `protobuf:"bytes,4,opt,name=template_id,json=templateId,proto3" json:"template_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -623,27 +907,55 @@ func (x *CreateRoomRequest) GetIsPrivate() bool {
 	return false
 }
 
-type JoinRoomRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+func (x *CreateRoomRequest) GetMaxMembers() uint32 {
+	if x != nil {
+		return x.MaxMembers
+	}
+	return 0
 }
 
-func (x *JoinRoomRequest) Reset() {
-	*x = JoinRoomRequest{}
+func (x *CreateRoomRequest) GetTemplateId() string {
+	if x != nil {
+		return x.TemplateId
+	}
+	return ""
+}
+
+type RoomTemplate struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name  string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	// {name} is replaced by the name of the CreateRoomRequest and {date} by the UTC date,
+	// defaults to "{name}"
+	NamePattern string `protobuf:"bytes,3,opt,name=name_pattern,json=namePattern,proto3" json:"name_pattern,omitempty"`
+	IsPrivate   bool   `protobuf:"varint,4,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	// the users made members of each room keyed by user ID, with a role from ROLE_MEMBER to ROLE_ADMIN
+	DefaultRoles map[string]MemberRole `protobuf:"bytes,5,rep,name=default_roles,json=defaultRoles,proto3" json:"default_roles,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"varint,2,opt,name=value,enum=chat.MemberRole"`
+	// posted and pinned in each room on behalf of its creator, none when empty
+	WelcomeMessage string `protobuf:"bytes,6,opt,name=welcome_message,json=welcomeMessage,proto3" json:"welcome_message,omitempty"`
+	// messages older than the retention are dropped, unset or zero keeps them
+	MessageRetention *durationpb.Duration   `protobuf:"bytes,7,opt,name=message_retention,json=messageRetention,proto3" json:"message_retention,omitempty"`
+	Metadata         []*TemplateMetadata    `protobuf:"bytes,8,rep,name=metadata,proto3" json:"metadata,omitempty"`
+	CreatedBy        string                 `protobuf:"bytes,9,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt        *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *RoomTemplate) Reset() {
+	*x = RoomTemplate{}
 	mi := &file_internal_pb_server_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *JoinRoomRequest) String() string {
+func (x *RoomTemplate) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*JoinRoomRequest) ProtoMessage() {}
+func (*RoomTemplate) ProtoMessage() {}
 
-func (x *JoinRoomRequest) ProtoReflect() protoreflect.Message {
+func (x *RoomTemplate) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_pb_server_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -655,40 +967,103 @@ func (x *JoinRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use JoinRoomRequest.ProtoReflect.Descriptor instead.
-func (*JoinRoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use RoomTemplate.ProtoReflect.Descriptor instead.
+func (*RoomTemplate) Descriptor() ([]byte, []int) {
 	return file_internal_pb_server_proto_rawDescGZIP(), []int{12}
 }
 
-func (x *JoinRoomRequest) GetRoomId() string {
+func (x *RoomTemplate) GetId() string {
 	if x != nil {
-		return x.RoomId
+		return x.Id
 	}
 	return ""
 }
 
-type LeaveRoomRequest struct {
+func (x *RoomTemplate) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RoomTemplate) GetNamePattern() string {
+	if x != nil {
+		return x.NamePattern
+	}
+	return ""
+}
+
+func (x *RoomTemplate) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *RoomTemplate) GetDefaultRoles() map[string]MemberRole {
+	if x != nil {
+		return x.DefaultRoles
+	}
+	return nil
+}
+
+func (x *RoomTemplate) GetWelcomeMessage() string {
+	if x != nil {
+		return x.WelcomeMessage
+	}
+	return ""
+}
+
+func (x *RoomTemplate) GetMessageRetention() *durationpb.Duration {
+	if x != nil {
+		return x.MessageRetention
+	}
+	return nil
+}
+
+func (x *RoomTemplate) GetMetadata() []*TemplateMetadata {
+	if x != nil {
+		return x.Metadata
+	}
+	return nil
+}
+
+func (x *RoomTemplate) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *RoomTemplate) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type TemplateMetadata struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
-	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Values        map[string]string      `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LeaveRoomRequest) Reset() {
-	*x = LeaveRoomRequest{}
+func (x *TemplateMetadata) Reset() {
+	*x = TemplateMetadata{}
 	mi := &file_internal_pb_server_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LeaveRoomRequest) String() string {
+func (x *TemplateMetadata) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LeaveRoomRequest) ProtoMessage() {}
+func (*TemplateMetadata) ProtoMessage() {}
 
-func (x *LeaveRoomRequest) ProtoReflect() protoreflect.Message {
+func (x *TemplateMetadata) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_pb_server_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -700,46 +1075,47 @@ func (x *LeaveRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LeaveRoomRequest.ProtoReflect.Descriptor instead.
-func (*LeaveRoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use TemplateMetadata.ProtoReflect.Descriptor instead.
+func (*TemplateMetadata) Descriptor() ([]byte, []int) {
 	return file_internal_pb_server_proto_rawDescGZIP(), []int{13}
 }
 
-func (x *LeaveRoomRequest) GetRoomId() string {
+func (x *TemplateMetadata) GetNamespace() string {
 	if x != nil {
-		return x.RoomId
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *LeaveRoomRequest) GetUserId() string {
+func (x *TemplateMetadata) GetValues() map[string]string {
 	if x != nil {
-		return x.UserId
+		return x.Values
 	}
-	return ""
+	return nil
 }
 
-type GetRoomRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+type CreateRoomTemplateRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// id, created_by and created_at are set by the server
+	Template      *RoomTemplate `protobuf:"bytes,1,opt,name=template,proto3" json:"template,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *GetRoomRequest) Reset() {
-	*x = GetRoomRequest{}
+func (x *CreateRoomTemplateRequest) Reset() {
+	*x = CreateRoomTemplateRequest{}
 	mi := &file_internal_pb_server_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *GetRoomRequest) String() string {
+func (x *CreateRoomTemplateRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*GetRoomRequest) ProtoMessage() {}
+func (*CreateRoomTemplateRequest) ProtoMessage() {}
 
-func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
+func (x *CreateRoomTemplateRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_pb_server_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -751,39 +1127,38 @@ func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use GetRoomRequest.ProtoReflect.Descriptor instead.
-func (*GetRoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use CreateRoomTemplateRequest.ProtoReflect.Descriptor instead.
+func (*CreateRoomTemplateRequest) Descriptor() ([]byte, []int) {
 	return file_internal_pb_server_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *GetRoomRequest) GetRoomId() string {
+func (x *CreateRoomTemplateRequest) GetTemplate() *RoomTemplate {
 	if x != nil {
-		return x.RoomId
+		return x.Template
 	}
-	return ""
+	return nil
 }
 
-type DeleteRoomRequest struct {
+type ListRoomTemplatesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *DeleteRoomRequest) Reset() {
-	*x = DeleteRoomRequest{}
+func (x *ListRoomTemplatesRequest) Reset() {
+	*x = ListRoomTemplatesRequest{}
 	mi := &file_internal_pb_server_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *DeleteRoomRequest) String() string {
+func (x *ListRoomTemplatesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*DeleteRoomRequest) ProtoMessage() {}
+func (*ListRoomTemplatesRequest) ProtoMessage() {}
 
-func (x *DeleteRoomRequest) ProtoReflect() protoreflect.Message {
+func (x *ListRoomTemplatesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_pb_server_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -795,44 +1170,33 @@ func (x *DeleteRoomRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use DeleteRoomRequest.ProtoReflect.Descriptor instead.
-func (*DeleteRoomRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListRoomTemplatesRequest.ProtoReflect.Descriptor instead.
+func (*ListRoomTemplatesRequest) Descriptor() ([]byte, []int) {
 	return file_internal_pb_server_proto_rawDescGZIP(), []int{15}
 }
 
-func (x *DeleteRoomRequest) GetRoomId() string {
-	if x != nil {
-		return x.RoomId
-	}
-	return ""
-}
-
-type Room struct {
+// templates sorted by name
+type ListRoomTemplatesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
-	MemberCount   uint32                 `protobuf:"varint,3,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
-	IsPrivate     bool                   `protobuf:"varint,4,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
-	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
-	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Templates     []*RoomTemplate        `protobuf:"bytes,1,rep,name=templates,proto3" json:"templates,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Room) Reset() {
-	*x = Room{}
+func (x *ListRoomTemplatesResponse) Reset() {
+	*x = ListRoomTemplatesResponse{}
 	mi := &file_internal_pb_server_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *Room) String() string {
+func (x *ListRoomTemplatesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*Room) ProtoMessage() {}
+func (*ListRoomTemplatesResponse) ProtoMessage() {}
 
-func (x *Room) ProtoReflect() protoreflect.Message {
+func (x *ListRoomTemplatesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_internal_pb_server_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -844,75 +1208,4640 @@ func (x *Room) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use Room.ProtoReflect.Descriptor instead.
-func (*Room) Descriptor() ([]byte, []int) {
+// Deprecated: Use ListRoomTemplatesResponse.ProtoReflect.Descriptor instead.
+func (*ListRoomTemplatesResponse) Descriptor() ([]byte, []int) {
 	return file_internal_pb_server_proto_rawDescGZIP(), []int{16}
 }
 
-func (x *Room) GetId() string {
+func (x *ListRoomTemplatesResponse) GetTemplates() []*RoomTemplate {
 	if x != nil {
-		return x.Id
+		return x.Templates
 	}
-	return ""
+	return nil
 }
 
-func (x *Room) GetName() string {
-	if x != nil {
-		return x.Name
-	}
-	return ""
+type JoinRoomRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// queue in the waitlist instead of failing when the room is full
+	WaitIfFull    bool `protobuf:"varint,2,opt,name=wait_if_full,json=waitIfFull,proto3" json:"wait_if_full,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *Room) GetMemberCount() uint32 {
-	if x != nil {
+func (x *JoinRoomRequest) Reset() {
+	*x = JoinRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRoomRequest) ProtoMessage() {}
+
+func (x *JoinRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRoomRequest.ProtoReflect.Descriptor instead.
+func (*JoinRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *JoinRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *JoinRoomRequest) GetWaitIfFull() bool {
+	if x != nil {
+		return x.WaitIfFull
+	}
+	return false
+}
+
+type LeaveRoomRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// the member to remove, left empty the caller leaves. Only admins and the owner can remove
+	// a member, who has to rank below them
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LeaveRoomRequest) Reset() {
+	*x = LeaveRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LeaveRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LeaveRoomRequest) ProtoMessage() {}
+
+func (x *LeaveRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LeaveRoomRequest.ProtoReflect.Descriptor instead.
+func (*LeaveRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *LeaveRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *LeaveRoomRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type GetRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomRequest) Reset() {
+	*x = GetRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomRequest) ProtoMessage() {}
+
+func (x *GetRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomRequest.ProtoReflect.Descriptor instead.
+func (*GetRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *GetRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type DeleteRoomRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// reason is passed on to the joined members in RoomDeleted
+	Reason        string `protobuf:"bytes,2,opt,name=reason,proto3" json:"reason,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoomRequest) Reset() {
+	*x = DeleteRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoomRequest) ProtoMessage() {}
+
+func (x *DeleteRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoomRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *DeleteRoomRequest) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+type ArchiveRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ArchiveRoomRequest) Reset() {
+	*x = ArchiveRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ArchiveRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ArchiveRoomRequest) ProtoMessage() {}
+
+func (x *ArchiveRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ArchiveRoomRequest.ProtoReflect.Descriptor instead.
+func (*ArchiveRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *ArchiveRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type UnarchiveRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnarchiveRoomRequest) Reset() {
+	*x = UnarchiveRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnarchiveRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnarchiveRoomRequest) ProtoMessage() {}
+
+func (x *UnarchiveRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnarchiveRoomRequest.ProtoReflect.Descriptor instead.
+func (*UnarchiveRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *UnarchiveRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type InviteLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	MaxUses       uint32                 `protobuf:"varint,6,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	Uses          uint32                 `protobuf:"varint,7,opt,name=uses,proto3" json:"uses,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *InviteLink) Reset() {
+	*x = InviteLink{}
+	mi := &file_internal_pb_server_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *InviteLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*InviteLink) ProtoMessage() {}
+
+func (x *InviteLink) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use InviteLink.ProtoReflect.Descriptor instead.
+func (*InviteLink) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *InviteLink) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *InviteLink) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *InviteLink) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *InviteLink) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *InviteLink) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *InviteLink) GetMaxUses() uint32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *InviteLink) GetUses() uint32 {
+	if x != nil {
+		return x.Uses
+	}
+	return 0
+}
+
+type CreateInviteLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	MaxUses       uint32                 `protobuf:"varint,2,opt,name=max_uses,json=maxUses,proto3" json:"max_uses,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateInviteLinkRequest) Reset() {
+	*x = CreateInviteLinkRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateInviteLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateInviteLinkRequest) ProtoMessage() {}
+
+func (x *CreateInviteLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateInviteLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateInviteLinkRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *CreateInviteLinkRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *CreateInviteLinkRequest) GetMaxUses() uint32 {
+	if x != nil {
+		return x.MaxUses
+	}
+	return 0
+}
+
+func (x *CreateInviteLinkRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type RevokeInviteLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeInviteLinkRequest) Reset() {
+	*x = RevokeInviteLinkRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeInviteLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeInviteLinkRequest) ProtoMessage() {}
+
+func (x *RevokeInviteLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeInviteLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeInviteLinkRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *RevokeInviteLinkRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type ListInviteLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInviteLinksRequest) Reset() {
+	*x = ListInviteLinksRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInviteLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInviteLinksRequest) ProtoMessage() {}
+
+func (x *ListInviteLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInviteLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListInviteLinksRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ListInviteLinksRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type ListInviteLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*InviteLink          `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListInviteLinksResponse) Reset() {
+	*x = ListInviteLinksResponse{}
+	mi := &file_internal_pb_server_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListInviteLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListInviteLinksResponse) ProtoMessage() {}
+
+func (x *ListInviteLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListInviteLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListInviteLinksResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *ListInviteLinksResponse) GetLinks() []*InviteLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type JoinByInviteCodeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Code          string                 `protobuf:"bytes,1,opt,name=code,proto3" json:"code,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinByInviteCodeRequest) Reset() {
+	*x = JoinByInviteCodeRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinByInviteCodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinByInviteCodeRequest) ProtoMessage() {}
+
+func (x *JoinByInviteCodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinByInviteCodeRequest.ProtoReflect.Descriptor instead.
+func (*JoinByInviteCodeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *JoinByInviteCodeRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+type RequestToJoinRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// shown to the reviewers, up to 500 characters
+	Message       string `protobuf:"bytes,2,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RequestToJoinRequest) Reset() {
+	*x = RequestToJoinRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RequestToJoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RequestToJoinRequest) ProtoMessage() {}
+
+func (x *RequestToJoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RequestToJoinRequest.ProtoReflect.Descriptor instead.
+func (*RequestToJoinRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RequestToJoinRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RequestToJoinRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+type JoinRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Message       string                 `protobuf:"bytes,3,opt,name=message,proto3" json:"message,omitempty"`
+	RequestedAt   *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=requested_at,json=requestedAt,proto3" json:"requested_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRequest) Reset() {
+	*x = JoinRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequest) ProtoMessage() {}
+
+func (x *JoinRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *JoinRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetMessage() string {
+	if x != nil {
+		return x.Message
+	}
+	return ""
+}
+
+func (x *JoinRequest) GetRequestedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RequestedAt
+	}
+	return nil
+}
+
+// JoinRequestUpdate follows a join request: the pending request first, then the decision
+// which ends the stream. The stream ends with NOT_FOUND if the room is deleted meanwhile.
+type JoinRequestUpdate struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Request *JoinRequest           `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	State   JoinRequestState       `protobuf:"varint,2,opt,name=state,proto3,enum=chat.JoinRequestState" json:"state,omitempty"`
+	// set once the request is approved or rejected
+	ReviewedBy    string `protobuf:"bytes,3,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRequestUpdate) Reset() {
+	*x = JoinRequestUpdate{}
+	mi := &file_internal_pb_server_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRequestUpdate) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequestUpdate) ProtoMessage() {}
+
+func (x *JoinRequestUpdate) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequestUpdate.ProtoReflect.Descriptor instead.
+func (*JoinRequestUpdate) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *JoinRequestUpdate) GetRequest() *JoinRequest {
+	if x != nil {
+		return x.Request
+	}
+	return nil
+}
+
+func (x *JoinRequestUpdate) GetState() JoinRequestState {
+	if x != nil {
+		return x.State
+	}
+	return JoinRequestState_JOIN_REQUEST_PENDING
+}
+
+func (x *JoinRequestUpdate) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+type ListJoinRequestsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJoinRequestsRequest) Reset() {
+	*x = ListJoinRequestsRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJoinRequestsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinRequestsRequest) ProtoMessage() {}
+
+func (x *ListJoinRequestsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinRequestsRequest.ProtoReflect.Descriptor instead.
+func (*ListJoinRequestsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ListJoinRequestsRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+// pending requests, oldest first
+type ListJoinRequestsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Requests      []*JoinRequest         `protobuf:"bytes,1,rep,name=requests,proto3" json:"requests,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListJoinRequestsResponse) Reset() {
+	*x = ListJoinRequestsResponse{}
+	mi := &file_internal_pb_server_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListJoinRequestsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListJoinRequestsResponse) ProtoMessage() {}
+
+func (x *ListJoinRequestsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListJoinRequestsResponse.ProtoReflect.Descriptor instead.
+func (*ListJoinRequestsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *ListJoinRequestsResponse) GetRequests() []*JoinRequest {
+	if x != nil {
+		return x.Requests
+	}
+	return nil
+}
+
+// Room metadata is grouped in namespaces, one per integration, made of 1 to 64 of a-z, 0-9,
+// '.', '_' and '-'. Keys are up to 128 bytes, values up to 4096 bytes and a room holds at most
+// 256 keys. Without a policy a namespace is readable by members and writable by admins.
+type SetRoomMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	Value         string                 `protobuf:"bytes,4,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRoomMetadataRequest) Reset() {
+	*x = SetRoomMetadataRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRoomMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoomMetadataRequest) ProtoMessage() {}
+
+func (x *SetRoomMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoomMetadataRequest.ProtoReflect.Descriptor instead.
+func (*SetRoomMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetRoomMetadataRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *SetRoomMetadataRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SetRoomMetadataRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetRoomMetadataRequest) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+type GetRoomMetadataRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	RoomId    string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Namespace string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	// every key of the namespace when empty
+	Keys          []string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetRoomMetadataRequest) Reset() {
+	*x = GetRoomMetadataRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetRoomMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetRoomMetadataRequest) ProtoMessage() {}
+
+func (x *GetRoomMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetRoomMetadataRequest.ProtoReflect.Descriptor instead.
+func (*GetRoomMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetRoomMetadataRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *GetRoomMetadataRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *GetRoomMetadataRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type RoomMetadata struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Values        map[string]string      `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomMetadata) Reset() {
+	*x = RoomMetadata{}
+	mi := &file_internal_pb_server_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomMetadata) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomMetadata) ProtoMessage() {}
+
+func (x *RoomMetadata) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomMetadata.ProtoReflect.Descriptor instead.
+func (*RoomMetadata) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RoomMetadata) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RoomMetadata) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *RoomMetadata) GetValues() map[string]string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+type DeleteRoomMetadataRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key           string                 `protobuf:"bytes,3,opt,name=key,proto3" json:"key,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteRoomMetadataRequest) Reset() {
+	*x = DeleteRoomMetadataRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteRoomMetadataRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteRoomMetadataRequest) ProtoMessage() {}
+
+func (x *DeleteRoomMetadataRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteRoomMetadataRequest.ProtoReflect.Descriptor instead.
+func (*DeleteRoomMetadataRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *DeleteRoomMetadataRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *DeleteRoomMetadataRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *DeleteRoomMetadataRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+// only the owner can set a policy
+type SetRoomMetadataPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Namespace     string                 `protobuf:"bytes,2,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	ReadRole      MemberRole             `protobuf:"varint,3,opt,name=read_role,json=readRole,proto3,enum=chat.MemberRole" json:"read_role,omitempty"`
+	WriteRole     MemberRole             `protobuf:"varint,4,opt,name=write_role,json=writeRole,proto3,enum=chat.MemberRole" json:"write_role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetRoomMetadataPolicyRequest) Reset() {
+	*x = SetRoomMetadataPolicyRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetRoomMetadataPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetRoomMetadataPolicyRequest) ProtoMessage() {}
+
+func (x *SetRoomMetadataPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetRoomMetadataPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetRoomMetadataPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *SetRoomMetadataPolicyRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *SetRoomMetadataPolicyRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *SetRoomMetadataPolicyRequest) GetReadRole() MemberRole {
+	if x != nil {
+		return x.ReadRole
+	}
+	return MemberRole_ROLE_MEMBER
+}
+
+func (x *SetRoomMetadataPolicyRequest) GetWriteRole() MemberRole {
+	if x != nil {
+		return x.WriteRole
+	}
+	return MemberRole_ROLE_MEMBER
+}
+
+type ReconcileRoomsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// dry_run reports the inconsistencies without repairing them
+	DryRun        bool `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReconcileRoomsRequest) Reset() {
+	*x = ReconcileRoomsRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileRoomsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileRoomsRequest) ProtoMessage() {}
+
+func (x *ReconcileRoomsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileRoomsRequest.ProtoReflect.Descriptor instead.
+func (*ReconcileRoomsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ReconcileRoomsRequest) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+type RoomInconsistency struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Kind          InconsistencyKind      `protobuf:"varint,1,opt,name=kind,proto3,enum=chat.InconsistencyKind" json:"kind,omitempty"`
+	RoomId        string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserIds       []string               `protobuf:"bytes,3,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Repaired      bool                   `protobuf:"varint,4,opt,name=repaired,proto3" json:"repaired,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomInconsistency) Reset() {
+	*x = RoomInconsistency{}
+	mi := &file_internal_pb_server_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomInconsistency) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomInconsistency) ProtoMessage() {}
+
+func (x *RoomInconsistency) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomInconsistency.ProtoReflect.Descriptor instead.
+func (*RoomInconsistency) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RoomInconsistency) GetKind() InconsistencyKind {
+	if x != nil {
+		return x.Kind
+	}
+	return InconsistencyKind_INCONSISTENCY_ORPHANED_MEMBERS
+}
+
+func (x *RoomInconsistency) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RoomInconsistency) GetUserIds() []string {
+	if x != nil {
+		return x.UserIds
+	}
+	return nil
+}
+
+func (x *RoomInconsistency) GetRepaired() bool {
+	if x != nil {
+		return x.Repaired
+	}
+	return false
+}
+
+type ReconcileRoomsResponse struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DryRun          bool                   `protobuf:"varint,1,opt,name=dry_run,json=dryRun,proto3" json:"dry_run,omitempty"`
+	RoomsChecked    uint32                 `protobuf:"varint,2,opt,name=rooms_checked,json=roomsChecked,proto3" json:"rooms_checked,omitempty"`
+	Inconsistencies []*RoomInconsistency   `protobuf:"bytes,3,rep,name=inconsistencies,proto3" json:"inconsistencies,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *ReconcileRoomsResponse) Reset() {
+	*x = ReconcileRoomsResponse{}
+	mi := &file_internal_pb_server_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReconcileRoomsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReconcileRoomsResponse) ProtoMessage() {}
+
+func (x *ReconcileRoomsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReconcileRoomsResponse.ProtoReflect.Descriptor instead.
+func (*ReconcileRoomsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ReconcileRoomsResponse) GetDryRun() bool {
+	if x != nil {
+		return x.DryRun
+	}
+	return false
+}
+
+func (x *ReconcileRoomsResponse) GetRoomsChecked() uint32 {
+	if x != nil {
+		return x.RoomsChecked
+	}
+	return 0
+}
+
+func (x *ReconcileRoomsResponse) GetInconsistencies() []*RoomInconsistency {
+	if x != nil {
+		return x.Inconsistencies
+	}
+	return nil
+}
+
+type JoinRequestDecisionRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// the requester
+	UserId        string `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinRequestDecisionRequest) Reset() {
+	*x = JoinRequestDecisionRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinRequestDecisionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinRequestDecisionRequest) ProtoMessage() {}
+
+func (x *JoinRequestDecisionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinRequestDecisionRequest.ProtoReflect.Descriptor instead.
+func (*JoinRequestDecisionRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *JoinRequestDecisionRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *JoinRequestDecisionRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type UpdateRoomRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// room.id identifies the room, the other fields carry the new values
+	Room *Room `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	// supported paths: name, topic, description, avatar_url, is_private, max_members,
+	// slow_mode_interval, announcement_only
+	UpdateMask    *fieldmaskpb.FieldMask `protobuf:"bytes,2,opt,name=update_mask,json=updateMask,proto3" json:"update_mask,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateRoomRequest) Reset() {
+	*x = UpdateRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateRoomRequest) ProtoMessage() {}
+
+func (x *UpdateRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateRoomRequest.ProtoReflect.Descriptor instead.
+func (*UpdateRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *UpdateRoomRequest) GetRoom() *Room {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+func (x *UpdateRoomRequest) GetUpdateMask() *fieldmaskpb.FieldMask {
+	if x != nil {
+		return x.UpdateMask
+	}
+	return nil
+}
+
+type Room struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name         string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	MemberCount  uint32                 `protobuf:"varint,3,opt,name=member_count,json=memberCount,proto3" json:"member_count,omitempty"`
+	IsPrivate    bool                   `protobuf:"varint,4,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	CreatedBy    string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt    *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	Topic        string                 `protobuf:"bytes,7,opt,name=topic,proto3" json:"topic,omitempty"`
+	Description  string                 `protobuf:"bytes,8,opt,name=description,proto3" json:"description,omitempty"`
+	AvatarUrl    string                 `protobuf:"bytes,9,opt,name=avatar_url,json=avatarUrl,proto3" json:"avatar_url,omitempty"`
+	LastActivity *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
+	// set while the room is archived, it is deleted for good at purge_at
+	ArchivedAt *timestamppb.Timestamp `protobuf:"bytes,11,opt,name=archived_at,json=archivedAt,proto3" json:"archived_at,omitempty"`
+	ArchivedBy string                 `protobuf:"bytes,12,opt,name=archived_by,json=archivedBy,proto3" json:"archived_by,omitempty"`
+	PurgeAt    *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=purge_at,json=purgeAt,proto3" json:"purge_at,omitempty"`
+	MaxMembers uint32                 `protobuf:"varint,14,opt,name=max_members,json=maxMembers,proto3" json:"max_members,omitempty"`
+	// set once the room is placed in a space
+	SpaceId  string `protobuf:"bytes,15,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	Category string `protobuf:"bytes,16,opt,name=category,proto3" json:"category,omitempty"`
+	// members below moderator can post once per interval, unset or zero disables slow mode
+	SlowModeInterval *durationpb.Duration `protobuf:"bytes,17,opt,name=slow_mode_interval,json=slowModeInterval,proto3" json:"slow_mode_interval,omitempty"`
+	// only owners and admins can post
+	AnnouncementOnly bool `protobuf:"varint,18,opt,name=announcement_only,json=announcementOnly,proto3" json:"announcement_only,omitempty"`
+	// messages older than the retention are dropped from the history, unset when they are kept
+	MessageRetention *durationpb.Duration `protobuf:"bytes,19,opt,name=message_retention,json=messageRetention,proto3" json:"message_retention,omitempty"`
+	PinnedMessage    *ChatMessage         `protobuf:"bytes,20,opt,name=pinned_message,json=pinnedMessage,proto3" json:"pinned_message,omitempty"`
+	unknownFields    protoimpl.UnknownFields
+	sizeCache        protoimpl.SizeCache
+}
+
+func (x *Room) Reset() {
+	*x = Room{}
+	mi := &file_internal_pb_server_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Room) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Room) ProtoMessage() {}
+
+func (x *Room) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Room.ProtoReflect.Descriptor instead.
+func (*Room) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *Room) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Room) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Room) GetMemberCount() uint32 {
+	if x != nil {
 		return x.MemberCount
 	}
-	return 0
+	return 0
+}
+
+func (x *Room) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *Room) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Room) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Room) GetTopic() string {
+	if x != nil {
+		return x.Topic
+	}
+	return ""
+}
+
+func (x *Room) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *Room) GetAvatarUrl() string {
+	if x != nil {
+		return x.AvatarUrl
+	}
+	return ""
+}
+
+func (x *Room) GetLastActivity() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LastActivity
+	}
+	return nil
+}
+
+func (x *Room) GetArchivedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ArchivedAt
+	}
+	return nil
+}
+
+func (x *Room) GetArchivedBy() string {
+	if x != nil {
+		return x.ArchivedBy
+	}
+	return ""
+}
+
+func (x *Room) GetPurgeAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.PurgeAt
+	}
+	return nil
+}
+
+func (x *Room) GetMaxMembers() uint32 {
+	if x != nil {
+		return x.MaxMembers
+	}
+	return 0
+}
+
+func (x *Room) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *Room) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *Room) GetSlowModeInterval() *durationpb.Duration {
+	if x != nil {
+		return x.SlowModeInterval
+	}
+	return nil
+}
+
+func (x *Room) GetAnnouncementOnly() bool {
+	if x != nil {
+		return x.AnnouncementOnly
+	}
+	return false
+}
+
+func (x *Room) GetMessageRetention() *durationpb.Duration {
+	if x != nil {
+		return x.MessageRetention
+	}
+	return nil
+}
+
+func (x *Room) GetPinnedMessage() *ChatMessage {
+	if x != nil {
+		return x.PinnedMessage
+	}
+	return nil
+}
+
+type MuteMemberRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	// the mute never expires when unset
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MuteMemberRequest) Reset() {
+	*x = MuteMemberRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MuteMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MuteMemberRequest) ProtoMessage() {}
+
+func (x *MuteMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MuteMemberRequest.ProtoReflect.Descriptor instead.
+func (*MuteMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *MuteMemberRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *MuteMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MuteMemberRequest) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+type UnmuteMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnmuteMemberRequest) Reset() {
+	*x = UnmuteMemberRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnmuteMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnmuteMemberRequest) ProtoMessage() {}
+
+func (x *UnmuteMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnmuteMemberRequest.ProtoReflect.Descriptor instead.
+func (*UnmuteMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *UnmuteMemberRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *UnmuteMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type SetMemberRoleRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role          MemberRole             `protobuf:"varint,3,opt,name=role,proto3,enum=chat.MemberRole" json:"role,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMemberRoleRequest) Reset() {
+	*x = SetMemberRoleRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMemberRoleRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMemberRoleRequest) ProtoMessage() {}
+
+func (x *SetMemberRoleRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMemberRoleRequest.ProtoReflect.Descriptor instead.
+func (*SetMemberRoleRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *SetMemberRoleRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *SetMemberRoleRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *SetMemberRoleRequest) GetRole() MemberRole {
+	if x != nil {
+		return x.Role
+	}
+	return MemberRole_ROLE_MEMBER
+}
+
+// TransferOwnershipRequest hands the room to new_owner_id, who must be a member
+type TransferOwnershipRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	NewOwnerId    string                 `protobuf:"bytes,2,opt,name=new_owner_id,json=newOwnerId,proto3" json:"new_owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TransferOwnershipRequest) Reset() {
+	*x = TransferOwnershipRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TransferOwnershipRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TransferOwnershipRequest) ProtoMessage() {}
+
+func (x *TransferOwnershipRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TransferOwnershipRequest.ProtoReflect.Descriptor instead.
+func (*TransferOwnershipRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *TransferOwnershipRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *TransferOwnershipRequest) GetNewOwnerId() string {
+	if x != nil {
+		return x.NewOwnerId
+	}
+	return ""
+}
+
+type ExportRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExportRoomRequest) Reset() {
+	*x = ExportRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExportRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExportRoomRequest) ProtoMessage() {}
+
+func (x *ExportRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExportRoomRequest.ProtoReflect.Descriptor instead.
+func (*ExportRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *ExportRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+// RoomArchiveChunk is a piece of a room archive, the archive is the concatenation of the chunks in order
+type RoomArchiveChunk struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Data          []byte                 `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomArchiveChunk) Reset() {
+	*x = RoomArchiveChunk{}
+	mi := &file_internal_pb_server_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomArchiveChunk) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomArchiveChunk) ProtoMessage() {}
+
+func (x *RoomArchiveChunk) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomArchiveChunk.ProtoReflect.Descriptor instead.
+func (*RoomArchiveChunk) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *RoomArchiveChunk) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// ImportRoomRequest carries the options in the first message of the stream and the archive in the next ones
+type ImportRoomRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Payload:
+	//
+	//	*ImportRoomRequest_Options
+	//	*ImportRoomRequest_Chunk
+	Payload       isImportRoomRequest_Payload `protobuf_oneof:"payload"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRoomRequest) Reset() {
+	*x = ImportRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRoomRequest) ProtoMessage() {}
+
+func (x *ImportRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRoomRequest.ProtoReflect.Descriptor instead.
+func (*ImportRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *ImportRoomRequest) GetPayload() isImportRoomRequest_Payload {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+func (x *ImportRoomRequest) GetOptions() *ImportRoomOptions {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportRoomRequest_Options); ok {
+			return x.Options
+		}
+	}
+	return nil
+}
+
+func (x *ImportRoomRequest) GetChunk() *RoomArchiveChunk {
+	if x != nil {
+		if x, ok := x.Payload.(*ImportRoomRequest_Chunk); ok {
+			return x.Chunk
+		}
+	}
+	return nil
+}
+
+type isImportRoomRequest_Payload interface {
+	isImportRoomRequest_Payload()
+}
+
+type ImportRoomRequest_Options struct {
+	Options *ImportRoomOptions `protobuf:"bytes,1,opt,name=options,proto3,oneof"`
+}
+
+type ImportRoomRequest_Chunk struct {
+	Chunk *RoomArchiveChunk `protobuf:"bytes,2,opt,name=chunk,proto3,oneof"`
+}
+
+func (*ImportRoomRequest_Options) isImportRoomRequest_Payload() {}
+
+func (*ImportRoomRequest_Chunk) isImportRoomRequest_Payload() {}
+
+type ImportRoomOptions struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// user_id_map replaces the user IDs of the archive, IDs missing from it are kept
+	UserIdMap     map[string]string `protobuf:"bytes,1,rep,name=user_id_map,json=userIdMap,proto3" json:"user_id_map,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportRoomOptions) Reset() {
+	*x = ImportRoomOptions{}
+	mi := &file_internal_pb_server_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportRoomOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportRoomOptions) ProtoMessage() {}
+
+func (x *ImportRoomOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportRoomOptions.ProtoReflect.Descriptor instead.
+func (*ImportRoomOptions) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *ImportRoomOptions) GetUserIdMap() map[string]string {
+	if x != nil {
+		return x.UserIdMap
+	}
+	return nil
+}
+
+type Space struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	IsPrivate     bool                   `protobuf:"varint,3,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	CreatedBy     string                 `protobuf:"bytes,4,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Space) Reset() {
+	*x = Space{}
+	mi := &file_internal_pb_server_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Space) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Space) ProtoMessage() {}
+
+func (x *Space) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Space.ProtoReflect.Descriptor instead.
+func (*Space) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *Space) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Space) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Space) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *Space) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *Space) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+type SpaceCategory struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Rooms         []*Room                `protobuf:"bytes,2,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SpaceCategory) Reset() {
+	*x = SpaceCategory{}
+	mi := &file_internal_pb_server_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SpaceCategory) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SpaceCategory) ProtoMessage() {}
+
+func (x *SpaceCategory) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SpaceCategory.ProtoReflect.Descriptor instead.
+func (*SpaceCategory) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *SpaceCategory) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SpaceCategory) GetRooms() []*Room {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+type CreateSpaceRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Name      string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	IsPrivate bool                   `protobuf:"varint,2,opt,name=is_private,json=isPrivate,proto3" json:"is_private,omitempty"`
+	// initial categories, in display order
+	Categories    []string `protobuf:"bytes,3,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateSpaceRequest) Reset() {
+	*x = CreateSpaceRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateSpaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateSpaceRequest) ProtoMessage() {}
+
+func (x *CreateSpaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateSpaceRequest.ProtoReflect.Descriptor instead.
+func (*CreateSpaceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *CreateSpaceRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *CreateSpaceRequest) GetIsPrivate() bool {
+	if x != nil {
+		return x.IsPrivate
+	}
+	return false
+}
+
+func (x *CreateSpaceRequest) GetCategories() []string {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type JoinSpaceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *JoinSpaceRequest) Reset() {
+	*x = JoinSpaceRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *JoinSpaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*JoinSpaceRequest) ProtoMessage() {}
+
+func (x *JoinSpaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use JoinSpaceRequest.ProtoReflect.Descriptor instead.
+func (*JoinSpaceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *JoinSpaceRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+type AddSpaceMemberRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	UserId        string                 `protobuf:"bytes,2,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddSpaceMemberRequest) Reset() {
+	*x = AddSpaceMemberRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddSpaceMemberRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddSpaceMemberRequest) ProtoMessage() {}
+
+func (x *AddSpaceMemberRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddSpaceMemberRequest.ProtoReflect.Descriptor instead.
+func (*AddSpaceMemberRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *AddSpaceMemberRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *AddSpaceMemberRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type AddRoomToSpaceRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	RoomId  string                 `protobuf:"bytes,2,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// created at the end of the space when it does not exist yet
+	Category      string `protobuf:"bytes,3,opt,name=category,proto3" json:"category,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AddRoomToSpaceRequest) Reset() {
+	*x = AddRoomToSpaceRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AddRoomToSpaceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AddRoomToSpaceRequest) ProtoMessage() {}
+
+func (x *AddRoomToSpaceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AddRoomToSpaceRequest.ProtoReflect.Descriptor instead.
+func (*AddRoomToSpaceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *AddRoomToSpaceRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+func (x *AddRoomToSpaceRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *AddRoomToSpaceRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+type MoveRoomRequest struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	RoomId   string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	Category string                 `protobuf:"bytes,2,opt,name=category,proto3" json:"category,omitempty"`
+	// index in the category, the room goes last when it is past the end
+	Position      uint32 `protobuf:"varint,3,opt,name=position,proto3" json:"position,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MoveRoomRequest) Reset() {
+	*x = MoveRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MoveRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MoveRoomRequest) ProtoMessage() {}
+
+func (x *MoveRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MoveRoomRequest.ProtoReflect.Descriptor instead.
+func (*MoveRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *MoveRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *MoveRoomRequest) GetCategory() string {
+	if x != nil {
+		return x.Category
+	}
+	return ""
+}
+
+func (x *MoveRoomRequest) GetPosition() uint32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+type ListSpaceRoomsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SpaceId       string                 `protobuf:"bytes,1,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSpaceRoomsRequest) Reset() {
+	*x = ListSpaceRoomsRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSpaceRoomsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpaceRoomsRequest) ProtoMessage() {}
+
+func (x *ListSpaceRoomsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpaceRoomsRequest.ProtoReflect.Descriptor instead.
+func (*ListSpaceRoomsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *ListSpaceRoomsRequest) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+type ListSpaceRoomsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Space         *Space                 `protobuf:"bytes,1,opt,name=space,proto3" json:"space,omitempty"`
+	Categories    []*SpaceCategory       `protobuf:"bytes,2,rep,name=categories,proto3" json:"categories,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSpaceRoomsResponse) Reset() {
+	*x = ListSpaceRoomsResponse{}
+	mi := &file_internal_pb_server_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSpaceRoomsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSpaceRoomsResponse) ProtoMessage() {}
+
+func (x *ListSpaceRoomsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSpaceRoomsResponse.ProtoReflect.Descriptor instead.
+func (*ListSpaceRoomsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ListSpaceRoomsResponse) GetSpace() *Space {
+	if x != nil {
+		return x.Space
+	}
+	return nil
+}
+
+func (x *ListSpaceRoomsResponse) GetCategories() []*SpaceCategory {
+	if x != nil {
+		return x.Categories
+	}
+	return nil
+}
+
+type ListRoomsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// defaults to 50, capped at 200
+	PageSize int32 `protobuf:"varint,1,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of a previous call made with the same filter and order_by
+	PageToken string      `protobuf:"bytes,2,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Filter    *RoomFilter `protobuf:"bytes,3,opt,name=filter,proto3" json:"filter,omitempty"`
+	// one of created_at, member_count, last_activity, optionally followed by asc or desc
+	// (asc when omitted), defaults to "created_at desc"
+	OrderBy       string `protobuf:"bytes,4,opt,name=order_by,json=orderBy,proto3" json:"order_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsRequest) Reset() {
+	*x = ListRoomsRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsRequest) ProtoMessage() {}
+
+func (x *ListRoomsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsRequest.ProtoReflect.Descriptor instead.
+func (*ListRoomsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ListRoomsRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRoomsRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRoomsRequest) GetFilter() *RoomFilter {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *ListRoomsRequest) GetOrderBy() string {
+	if x != nil {
+		return x.OrderBy
+	}
+	return ""
+}
+
+type RoomFilter struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// case-insensitive prefix of the room name
+	NamePrefix string `protobuf:"bytes,1,opt,name=name_prefix,json=namePrefix,proto3" json:"name_prefix,omitempty"`
+	IsPrivate  *bool  `protobuf:"varint,2,opt,name=is_private,json=isPrivate,proto3,oneof" json:"is_private,omitempty"`
+	CreatedBy  string `protobuf:"bytes,3,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`
+	// archived rooms are left out unless set to true
+	Archived      *bool  `protobuf:"varint,4,opt,name=archived,proto3,oneof" json:"archived,omitempty"`
+	SpaceId       string `protobuf:"bytes,5,opt,name=space_id,json=spaceId,proto3" json:"space_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomFilter) Reset() {
+	*x = RoomFilter{}
+	mi := &file_internal_pb_server_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomFilter) ProtoMessage() {}
+
+func (x *RoomFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomFilter.ProtoReflect.Descriptor instead.
+func (*RoomFilter) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *RoomFilter) GetNamePrefix() string {
+	if x != nil {
+		return x.NamePrefix
+	}
+	return ""
+}
+
+func (x *RoomFilter) GetIsPrivate() bool {
+	if x != nil && x.IsPrivate != nil {
+		return *x.IsPrivate
+	}
+	return false
+}
+
+func (x *RoomFilter) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *RoomFilter) GetArchived() bool {
+	if x != nil && x.Archived != nil {
+		return *x.Archived
+	}
+	return false
+}
+
+func (x *RoomFilter) GetSpaceId() string {
+	if x != nil {
+		return x.SpaceId
+	}
+	return ""
+}
+
+type ListRoomsResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rooms []*Room                `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	// empty when there are no more results, a page may hold fewer than
+	// page_size rooms while a token is still returned
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomsResponse) Reset() {
+	*x = ListRoomsResponse{}
+	mi := &file_internal_pb_server_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomsResponse) ProtoMessage() {}
+
+func (x *ListRoomsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomsResponse.ProtoReflect.Descriptor instead.
+func (*ListRoomsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListRoomsResponse) GetRooms() []*Room {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+func (x *ListRoomsResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type SearchRoomsRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// matched against the words of the room name and topic, case-insensitive, up to 100 characters
+	Query string `protobuf:"bytes,1,opt,name=query,proto3" json:"query,omitempty"`
+	// defaults to 20, capped at 100
+	Limit int32 `protobuf:"varint,2,opt,name=limit,proto3" json:"limit,omitempty"`
+	// next_cursor of a previous call made with the same query
+	Cursor        string `protobuf:"bytes,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRoomsRequest) Reset() {
+	*x = SearchRoomsRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRoomsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRoomsRequest) ProtoMessage() {}
+
+func (x *SearchRoomsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRoomsRequest.ProtoReflect.Descriptor instead.
+func (*SearchRoomsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *SearchRoomsRequest) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchRoomsRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *SearchRoomsRequest) GetCursor() string {
+	if x != nil {
+		return x.Cursor
+	}
+	return ""
+}
+
+type SearchRoomsResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Results []*RoomSearchResult    `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	// empty when there are no more results
+	NextCursor    string `protobuf:"bytes,2,opt,name=next_cursor,json=nextCursor,proto3" json:"next_cursor,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRoomsResponse) Reset() {
+	*x = SearchRoomsResponse{}
+	mi := &file_internal_pb_server_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRoomsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRoomsResponse) ProtoMessage() {}
+
+func (x *SearchRoomsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRoomsResponse.ProtoReflect.Descriptor instead.
+func (*SearchRoomsResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *SearchRoomsResponse) GetResults() []*RoomSearchResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *SearchRoomsResponse) GetNextCursor() string {
+	if x != nil {
+		return x.NextCursor
+	}
+	return ""
+}
+
+type RoomSearchResult struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Room  *Room                  `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	// relevance goes from 0 to 1, a match on the name ranks above one on the topic
+	Relevance     float64 `protobuf:"fixed64,2,opt,name=relevance,proto3" json:"relevance,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomSearchResult) Reset() {
+	*x = RoomSearchResult{}
+	mi := &file_internal_pb_server_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomSearchResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomSearchResult) ProtoMessage() {}
+
+func (x *RoomSearchResult) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomSearchResult.ProtoReflect.Descriptor instead.
+func (*RoomSearchResult) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *RoomSearchResult) GetRoom() *Room {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+func (x *RoomSearchResult) GetRelevance() float64 {
+	if x != nil {
+		return x.Relevance
+	}
+	return 0
+}
+
+type WatchRoomsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRoomsRequest) Reset() {
+	*x = WatchRoomsRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRoomsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRoomsRequest) ProtoMessage() {}
+
+func (x *WatchRoomsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRoomsRequest.ProtoReflect.Descriptor instead.
+func (*WatchRoomsRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{68}
+}
+
+// RoomDirectoryEvent is a change to the rooms the caller can see: public rooms and
+// the private rooms they created or are a member of. The stream starts with snapshot pages.
+type RoomDirectoryEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// unset for snapshot pages
+	RoomId string `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*RoomDirectoryEvent_Snapshot
+	//	*RoomDirectoryEvent_RoomCreated
+	//	*RoomDirectoryEvent_RoomUpdated
+	//	*RoomDirectoryEvent_RoomDeleted
+	//	*RoomDirectoryEvent_MemberCountChanged
+	//	*RoomDirectoryEvent_RoomHidden
+	Event         isRoomDirectoryEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomDirectoryEvent) Reset() {
+	*x = RoomDirectoryEvent{}
+	mi := &file_internal_pb_server_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomDirectoryEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomDirectoryEvent) ProtoMessage() {}
+
+func (x *RoomDirectoryEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomDirectoryEvent.ProtoReflect.Descriptor instead.
+func (*RoomDirectoryEvent) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *RoomDirectoryEvent) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RoomDirectoryEvent) GetEvent() isRoomDirectoryEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *RoomDirectoryEvent) GetSnapshot() *RoomDirectorySnapshot {
+	if x != nil {
+		if x, ok := x.Event.(*RoomDirectoryEvent_Snapshot); ok {
+			return x.Snapshot
+		}
+	}
+	return nil
+}
+
+func (x *RoomDirectoryEvent) GetRoomCreated() *Room {
+	if x != nil {
+		if x, ok := x.Event.(*RoomDirectoryEvent_RoomCreated); ok {
+			return x.RoomCreated
+		}
+	}
+	return nil
+}
+
+func (x *RoomDirectoryEvent) GetRoomUpdated() *Room {
+	if x != nil {
+		if x, ok := x.Event.(*RoomDirectoryEvent_RoomUpdated); ok {
+			return x.RoomUpdated
+		}
+	}
+	return nil
+}
+
+func (x *RoomDirectoryEvent) GetRoomDeleted() *RoomDeleted {
+	if x != nil {
+		if x, ok := x.Event.(*RoomDirectoryEvent_RoomDeleted); ok {
+			return x.RoomDeleted
+		}
+	}
+	return nil
+}
+
+func (x *RoomDirectoryEvent) GetMemberCountChanged() *MemberCountChanged {
+	if x != nil {
+		if x, ok := x.Event.(*RoomDirectoryEvent_MemberCountChanged); ok {
+			return x.MemberCountChanged
+		}
+	}
+	return nil
+}
+
+func (x *RoomDirectoryEvent) GetRoomHidden() *emptypb.Empty {
+	if x != nil {
+		if x, ok := x.Event.(*RoomDirectoryEvent_RoomHidden); ok {
+			return x.RoomHidden
+		}
+	}
+	return nil
+}
+
+type isRoomDirectoryEvent_Event interface {
+	isRoomDirectoryEvent_Event()
+}
+
+type RoomDirectoryEvent_Snapshot struct {
+	Snapshot *RoomDirectorySnapshot `protobuf:"bytes,2,opt,name=snapshot,proto3,oneof"`
+}
+
+type RoomDirectoryEvent_RoomCreated struct {
+	RoomCreated *Room `protobuf:"bytes,3,opt,name=room_created,json=roomCreated,proto3,oneof"`
+}
+
+type RoomDirectoryEvent_RoomUpdated struct {
+	RoomUpdated *Room `protobuf:"bytes,4,opt,name=room_updated,json=roomUpdated,proto3,oneof"`
+}
+
+type RoomDirectoryEvent_RoomDeleted struct {
+	RoomDeleted *RoomDeleted `protobuf:"bytes,5,opt,name=room_deleted,json=roomDeleted,proto3,oneof"`
+}
+
+type RoomDirectoryEvent_MemberCountChanged struct {
+	MemberCountChanged *MemberCountChanged `protobuf:"bytes,6,opt,name=member_count_changed,json=memberCountChanged,proto3,oneof"`
+}
+
+type RoomDirectoryEvent_RoomHidden struct {
+	// the room still exists but the caller can no longer see it, e.g. it turned private
+	RoomHidden *emptypb.Empty `protobuf:"bytes,7,opt,name=room_hidden,json=roomHidden,proto3,oneof"`
+}
+
+func (*RoomDirectoryEvent_Snapshot) isRoomDirectoryEvent_Event() {}
+
+func (*RoomDirectoryEvent_RoomCreated) isRoomDirectoryEvent_Event() {}
+
+func (*RoomDirectoryEvent_RoomUpdated) isRoomDirectoryEvent_Event() {}
+
+func (*RoomDirectoryEvent_RoomDeleted) isRoomDirectoryEvent_Event() {}
+
+func (*RoomDirectoryEvent_MemberCountChanged) isRoomDirectoryEvent_Event() {}
+
+func (*RoomDirectoryEvent_RoomHidden) isRoomDirectoryEvent_Event() {}
+
+// RoomDirectorySnapshot is a page of the rooms the caller sees when the stream starts,
+// archived rooms left out
+type RoomDirectorySnapshot struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Rooms []*Room                `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+	// set on the last page, changes follow
+	Complete      bool `protobuf:"varint,2,opt,name=complete,proto3" json:"complete,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomDirectorySnapshot) Reset() {
+	*x = RoomDirectorySnapshot{}
+	mi := &file_internal_pb_server_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomDirectorySnapshot) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomDirectorySnapshot) ProtoMessage() {}
+
+func (x *RoomDirectorySnapshot) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomDirectorySnapshot.ProtoReflect.Descriptor instead.
+func (*RoomDirectorySnapshot) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *RoomDirectorySnapshot) GetRooms() []*Room {
+	if x != nil {
+		return x.Rooms
+	}
+	return nil
+}
+
+func (x *RoomDirectorySnapshot) GetComplete() bool {
+	if x != nil {
+		return x.Complete
+	}
+	return false
+}
+
+type MemberCountChanged struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Delta         int32                  `protobuf:"varint,1,opt,name=delta,proto3" json:"delta,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberCountChanged) Reset() {
+	*x = MemberCountChanged{}
+	mi := &file_internal_pb_server_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberCountChanged) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberCountChanged) ProtoMessage() {}
+
+func (x *MemberCountChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberCountChanged.ProtoReflect.Descriptor instead.
+func (*MemberCountChanged) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *MemberCountChanged) GetDelta() int32 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type ListRoomMembersRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// defaults to 50, capped at 200
+	PageSize int32 `protobuf:"varint,2,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`
+	// next_page_token of a previous call made with the same filters
+	PageToken     string        `protobuf:"bytes,3,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"`
+	Role          *MemberRole   `protobuf:"varint,4,opt,name=role,proto3,enum=chat.MemberRole,oneof" json:"role,omitempty"`
+	Status        *MemberStatus `protobuf:"varint,5,opt,name=status,proto3,enum=chat.MemberStatus,oneof" json:"status,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomMembersRequest) Reset() {
+	*x = ListRoomMembersRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomMembersRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomMembersRequest) ProtoMessage() {}
+
+func (x *ListRoomMembersRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomMembersRequest.ProtoReflect.Descriptor instead.
+func (*ListRoomMembersRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListRoomMembersRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *ListRoomMembersRequest) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *ListRoomMembersRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ListRoomMembersRequest) GetRole() MemberRole {
+	if x != nil && x.Role != nil {
+		return *x.Role
+	}
+	return MemberRole_ROLE_MEMBER
+}
+
+func (x *ListRoomMembersRequest) GetStatus() MemberStatus {
+	if x != nil && x.Status != nil {
+		return *x.Status
+	}
+	return MemberStatus_MEMBER_STATUS_AWAY
+}
+
+// members are listed in join order, the longest-standing first
+type ListRoomMembersResponse struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Members []*MemberInfo          `protobuf:"bytes,1,rep,name=members,proto3" json:"members,omitempty"`
+	// empty when there are no more members
+	NextPageToken string `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListRoomMembersResponse) Reset() {
+	*x = ListRoomMembersResponse{}
+	mi := &file_internal_pb_server_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListRoomMembersResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListRoomMembersResponse) ProtoMessage() {}
+
+func (x *ListRoomMembersResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListRoomMembersResponse.ProtoReflect.Descriptor instead.
+func (*ListRoomMembersResponse) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListRoomMembersResponse) GetMembers() []*MemberInfo {
+	if x != nil {
+		return x.Members
+	}
+	return nil
+}
+
+func (x *ListRoomMembersResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+type MemberInfo struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	UserId string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Role   MemberRole             `protobuf:"varint,2,opt,name=role,proto3,enum=chat.MemberRole" json:"role,omitempty"`
+	Muted  bool                   `protobuf:"varint,3,opt,name=muted,proto3" json:"muted,omitempty"`
+	// unset for a mute without expiry
+	MutedUntil *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=muted_until,json=mutedUntil,proto3" json:"muted_until,omitempty"`
+	Status     MemberStatus           `protobuf:"varint,5,opt,name=status,proto3,enum=chat.MemberStatus" json:"status,omitempty"`
+	Username   string                 `protobuf:"bytes,6,opt,name=username,proto3" json:"username,omitempty"`
+	// the username when the user has not set one
+	DisplayName   string                 `protobuf:"bytes,7,opt,name=display_name,json=displayName,proto3" json:"display_name,omitempty"`
+	JoinedAt      *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=joined_at,json=joinedAt,proto3" json:"joined_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MemberInfo) Reset() {
+	*x = MemberInfo{}
+	mi := &file_internal_pb_server_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MemberInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MemberInfo) ProtoMessage() {}
+
+func (x *MemberInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MemberInfo.ProtoReflect.Descriptor instead.
+func (*MemberInfo) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *MemberInfo) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *MemberInfo) GetRole() MemberRole {
+	if x != nil {
+		return x.Role
+	}
+	return MemberRole_ROLE_MEMBER
+}
+
+func (x *MemberInfo) GetMuted() bool {
+	if x != nil {
+		return x.Muted
+	}
+	return false
+}
+
+func (x *MemberInfo) GetMutedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.MutedUntil
+	}
+	return nil
+}
+
+func (x *MemberInfo) GetStatus() MemberStatus {
+	if x != nil {
+		return x.Status
+	}
+	return MemberStatus_MEMBER_STATUS_AWAY
+}
+
+func (x *MemberInfo) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+func (x *MemberInfo) GetDisplayName() string {
+	if x != nil {
+		return x.DisplayName
+	}
+	return ""
+}
+
+func (x *MemberInfo) GetJoinedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.JoinedAt
+	}
+	return nil
+}
+
+type RoomPresence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Users         []*UserPresence        `protobuf:"bytes,1,rep,name=users,proto3" json:"users,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomPresence) Reset() {
+	*x = RoomPresence{}
+	mi := &file_internal_pb_server_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomPresence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomPresence) ProtoMessage() {}
+
+func (x *RoomPresence) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomPresence.ProtoReflect.Descriptor instead.
+func (*RoomPresence) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RoomPresence) GetUsers() []*UserPresence {
+	if x != nil {
+		return x.Users
+	}
+	return nil
+}
+
+type UserPresence struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Sessions      []*PresenceSession     `protobuf:"bytes,2,rep,name=sessions,proto3" json:"sessions,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserPresence) Reset() {
+	*x = UserPresence{}
+	mi := &file_internal_pb_server_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserPresence) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserPresence) ProtoMessage() {}
+
+func (x *UserPresence) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserPresence.ProtoReflect.Descriptor instead.
+func (*UserPresence) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{76}
+}
+
+func (x *UserPresence) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserPresence) GetSessions() []*PresenceSession {
+	if x != nil {
+		return x.Sessions
+	}
+	return nil
+}
+
+type PresenceSession struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	SessionId     string                 `protobuf:"bytes,1,opt,name=session_id,json=sessionId,proto3" json:"session_id,omitempty"`
+	NodeId        string                 `protobuf:"bytes,2,opt,name=node_id,json=nodeId,proto3" json:"node_id,omitempty"`
+	ExpiresAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`
+	RoomId        string                 `protobuf:"bytes,4,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PresenceSession) Reset() {
+	*x = PresenceSession{}
+	mi := &file_internal_pb_server_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PresenceSession) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PresenceSession) ProtoMessage() {}
+
+func (x *PresenceSession) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PresenceSession.ProtoReflect.Descriptor instead.
+func (*PresenceSession) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{77}
+}
+
+func (x *PresenceSession) GetSessionId() string {
+	if x != nil {
+		return x.SessionId
+	}
+	return ""
+}
+
+func (x *PresenceSession) GetNodeId() string {
+	if x != nil {
+		return x.NodeId
+	}
+	return ""
+}
+
+func (x *PresenceSession) GetExpiresAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return nil
+}
+
+func (x *PresenceSession) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+type GetUserPresenceRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUserPresenceRequest) Reset() {
+	*x = GetUserPresenceRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[78]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUserPresenceRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUserPresenceRequest) ProtoMessage() {}
+
+func (x *GetUserPresenceRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[78]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUserPresenceRequest.ProtoReflect.Descriptor instead.
+func (*GetUserPresenceRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{78}
+}
+
+func (x *GetUserPresenceRequest) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type RoomID struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomID) Reset() {
+	*x = RoomID{}
+	mi := &file_internal_pb_server_proto_msgTypes[79]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomID) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomID) ProtoMessage() {}
+
+func (x *RoomID) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[79]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomID.ProtoReflect.Descriptor instead.
+func (*RoomID) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{79}
+}
+
+func (x *RoomID) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RoomEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*RoomEvent_UserJoined
+	//	*RoomEvent_UserLeft
+	//	*RoomEvent_RoomDeleted
+	//	*RoomEvent_RoomUpdated
+	//	*RoomEvent_Waitlisted
+	//	*RoomEvent_WaitlistPromoted
+	//	*RoomEvent_OwnershipTransferred
+	//	*RoomEvent_JoinRequested
+	//	*RoomEvent_JoinRequestResolved
+	//	*RoomEvent_MetadataChanged
+	Event         isRoomEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomEvent) Reset() {
+	*x = RoomEvent{}
+	mi := &file_internal_pb_server_proto_msgTypes[80]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomEvent) ProtoMessage() {}
+
+func (x *RoomEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[80]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomEvent.ProtoReflect.Descriptor instead.
+func (*RoomEvent) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{80}
+}
+
+func (x *RoomEvent) GetEvent() isRoomEvent_Event {
+	if x != nil {
+		return x.Event
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetUserJoined() *UserJoined {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_UserJoined); ok {
+			return x.UserJoined
+		}
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetUserLeft() *UserLeft {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_UserLeft); ok {
+			return x.UserLeft
+		}
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetRoomDeleted() *RoomDeleted {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_RoomDeleted); ok {
+			return x.RoomDeleted
+		}
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetRoomUpdated() *RoomUpdated {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_RoomUpdated); ok {
+			return x.RoomUpdated
+		}
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetWaitlisted() *Waitlisted {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_Waitlisted); ok {
+			return x.Waitlisted
+		}
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetWaitlistPromoted() *WaitlistPromoted {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_WaitlistPromoted); ok {
+			return x.WaitlistPromoted
+		}
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetOwnershipTransferred() *OwnershipTransferred {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_OwnershipTransferred); ok {
+			return x.OwnershipTransferred
+		}
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetJoinRequested() *JoinRequest {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_JoinRequested); ok {
+			return x.JoinRequested
+		}
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetJoinRequestResolved() *JoinRequestResolved {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_JoinRequestResolved); ok {
+			return x.JoinRequestResolved
+		}
+	}
+	return nil
+}
+
+func (x *RoomEvent) GetMetadataChanged() *RoomMetadataChanged {
+	if x != nil {
+		if x, ok := x.Event.(*RoomEvent_MetadataChanged); ok {
+			return x.MetadataChanged
+		}
+	}
+	return nil
+}
+
+type isRoomEvent_Event interface {
+	isRoomEvent_Event()
+}
+
+type RoomEvent_UserJoined struct {
+	UserJoined *UserJoined `protobuf:"bytes,1,opt,name=user_joined,json=userJoined,proto3,oneof"`
+}
+
+type RoomEvent_UserLeft struct {
+	UserLeft *UserLeft `protobuf:"bytes,2,opt,name=user_left,json=userLeft,proto3,oneof"`
+}
+
+type RoomEvent_RoomDeleted struct {
+	RoomDeleted *RoomDeleted `protobuf:"bytes,3,opt,name=room_deleted,json=roomDeleted,proto3,oneof"`
+}
+
+type RoomEvent_RoomUpdated struct {
+	RoomUpdated *RoomUpdated `protobuf:"bytes,4,opt,name=room_updated,json=roomUpdated,proto3,oneof"`
+}
+
+type RoomEvent_Waitlisted struct {
+	Waitlisted *Waitlisted `protobuf:"bytes,5,opt,name=waitlisted,proto3,oneof"`
+}
+
+type RoomEvent_WaitlistPromoted struct {
+	WaitlistPromoted *WaitlistPromoted `protobuf:"bytes,6,opt,name=waitlist_promoted,json=waitlistPromoted,proto3,oneof"`
+}
+
+type RoomEvent_OwnershipTransferred struct {
+	OwnershipTransferred *OwnershipTransferred `protobuf:"bytes,7,opt,name=ownership_transferred,json=ownershipTransferred,proto3,oneof"`
+}
+
+type RoomEvent_JoinRequested struct {
+	// join request events only reach owners and admins
+	JoinRequested *JoinRequest `protobuf:"bytes,8,opt,name=join_requested,json=joinRequested,proto3,oneof"`
+}
+
+type RoomEvent_JoinRequestResolved struct {
+	JoinRequestResolved *JoinRequestResolved `protobuf:"bytes,9,opt,name=join_request_resolved,json=joinRequestResolved,proto3,oneof"`
+}
+
+type RoomEvent_MetadataChanged struct {
+	// only reaches members allowed to read the namespace
+	MetadataChanged *RoomMetadataChanged `protobuf:"bytes,10,opt,name=metadata_changed,json=metadataChanged,proto3,oneof"`
+}
+
+func (*RoomEvent_UserJoined) isRoomEvent_Event() {}
+
+func (*RoomEvent_UserLeft) isRoomEvent_Event() {}
+
+func (*RoomEvent_RoomDeleted) isRoomEvent_Event() {}
+
+func (*RoomEvent_RoomUpdated) isRoomEvent_Event() {}
+
+func (*RoomEvent_Waitlisted) isRoomEvent_Event() {}
+
+func (*RoomEvent_WaitlistPromoted) isRoomEvent_Event() {}
+
+func (*RoomEvent_OwnershipTransferred) isRoomEvent_Event() {}
+
+func (*RoomEvent_JoinRequested) isRoomEvent_Event() {}
+
+func (*RoomEvent_JoinRequestResolved) isRoomEvent_Event() {}
+
+func (*RoomEvent_MetadataChanged) isRoomEvent_Event() {}
+
+type UserJoined struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserJoined) Reset() {
+	*x = UserJoined{}
+	mi := &file_internal_pb_server_proto_msgTypes[81]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserJoined) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserJoined) ProtoMessage() {}
+
+func (x *UserJoined) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[81]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserJoined.ProtoReflect.Descriptor instead.
+func (*UserJoined) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{81}
+}
+
+func (x *UserJoined) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+func (x *UserJoined) GetUsername() string {
+	if x != nil {
+		return x.Username
+	}
+	return ""
+}
+
+type UserLeft struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserLeft) Reset() {
+	*x = UserLeft{}
+	mi := &file_internal_pb_server_proto_msgTypes[82]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserLeft) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserLeft) ProtoMessage() {}
+
+func (x *UserLeft) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[82]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserLeft.ProtoReflect.Descriptor instead.
+func (*UserLeft) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{82}
+}
+
+func (x *UserLeft) GetUserId() string {
+	if x != nil {
+		return x.UserId
+	}
+	return ""
+}
+
+type PreviewRoomRequest struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// message_limit defaults to 20 and is capped at 100
+	MessageLimit  uint32 `protobuf:"varint,2,opt,name=message_limit,json=messageLimit,proto3" json:"message_limit,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PreviewRoomRequest) Reset() {
+	*x = PreviewRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[83]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PreviewRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PreviewRoomRequest) ProtoMessage() {}
+
+func (x *PreviewRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[83]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PreviewRoomRequest.ProtoReflect.Descriptor instead.
+func (*PreviewRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{83}
+}
+
+func (x *PreviewRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *PreviewRoomRequest) GetMessageLimit() uint32 {
+	if x != nil {
+		return x.MessageLimit
+	}
+	return 0
+}
+
+type RoomPreview struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Room  *Room                  `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	// the last messages of the room, oldest first
+	Messages      []*ChatMessage `protobuf:"bytes,2,rep,name=messages,proto3" json:"messages,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomPreview) Reset() {
+	*x = RoomPreview{}
+	mi := &file_internal_pb_server_proto_msgTypes[84]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomPreview) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomPreview) ProtoMessage() {}
+
+func (x *RoomPreview) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[84]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomPreview.ProtoReflect.Descriptor instead.
+func (*RoomPreview) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{84}
+}
+
+func (x *RoomPreview) GetRoom() *Room {
+	if x != nil {
+		return x.Room
+	}
+	return nil
+}
+
+func (x *RoomPreview) GetMessages() []*ChatMessage {
+	if x != nil {
+		return x.Messages
+	}
+	return nil
+}
+
+type WatchPublicRoomRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchPublicRoomRequest) Reset() {
+	*x = WatchPublicRoomRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[85]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchPublicRoomRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchPublicRoomRequest) ProtoMessage() {}
+
+func (x *WatchPublicRoomRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[85]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchPublicRoomRequest.ProtoReflect.Descriptor instead.
+func (*WatchPublicRoomRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{85}
+}
+
+func (x *WatchPublicRoomRequest) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+// RoomPreviewEvent is the read-only view of a room, the stream ends after room_deleted
+type RoomPreviewEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*RoomPreviewEvent_Message
+	//	*RoomPreviewEvent_RoomUpdated
+	//	*RoomPreviewEvent_RoomDeleted
+	Event         isRoomPreviewEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomPreviewEvent) Reset() {
+	*x = RoomPreviewEvent{}
+	mi := &file_internal_pb_server_proto_msgTypes[86]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomPreviewEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomPreviewEvent) ProtoMessage() {}
+
+func (x *RoomPreviewEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[86]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-func (x *Room) GetIsPrivate() bool {
+// Deprecated: Use RoomPreviewEvent.ProtoReflect.Descriptor instead.
+func (*RoomPreviewEvent) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{86}
+}
+
+func (x *RoomPreviewEvent) GetEvent() isRoomPreviewEvent_Event {
 	if x != nil {
-		return x.IsPrivate
+		return x.Event
+	}
+	return nil
+}
+
+func (x *RoomPreviewEvent) GetMessage() *ChatMessage {
+	if x != nil {
+		if x, ok := x.Event.(*RoomPreviewEvent_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *RoomPreviewEvent) GetRoomUpdated() *RoomUpdated {
+	if x != nil {
+		if x, ok := x.Event.(*RoomPreviewEvent_RoomUpdated); ok {
+			return x.RoomUpdated
+		}
+	}
+	return nil
+}
+
+func (x *RoomPreviewEvent) GetRoomDeleted() *RoomDeleted {
+	if x != nil {
+		if x, ok := x.Event.(*RoomPreviewEvent_RoomDeleted); ok {
+			return x.RoomDeleted
+		}
+	}
+	return nil
+}
+
+type isRoomPreviewEvent_Event interface {
+	isRoomPreviewEvent_Event()
+}
+
+type RoomPreviewEvent_Message struct {
+	Message *ChatMessage `protobuf:"bytes,1,opt,name=message,proto3,oneof"`
+}
+
+type RoomPreviewEvent_RoomUpdated struct {
+	RoomUpdated *RoomUpdated `protobuf:"bytes,2,opt,name=room_updated,json=roomUpdated,proto3,oneof"`
+}
+
+type RoomPreviewEvent_RoomDeleted struct {
+	RoomDeleted *RoomDeleted `protobuf:"bytes,3,opt,name=room_deleted,json=roomDeleted,proto3,oneof"`
+}
+
+func (*RoomPreviewEvent_Message) isRoomPreviewEvent_Event() {}
+
+func (*RoomPreviewEvent_RoomUpdated) isRoomPreviewEvent_Event() {}
+
+func (*RoomPreviewEvent_RoomDeleted) isRoomPreviewEvent_Event() {}
+
+type SubscribeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// filters replace the filters previously set for the same rooms
+	Filters       []*RoomEventFilter `protobuf:"bytes,1,rep,name=filters,proto3" json:"filters,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	mi := &file_internal_pb_server_proto_msgTypes[87]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[87]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{87}
+}
+
+func (x *SubscribeRequest) GetFilters() []*RoomEventFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+type RoomEventFilter struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// types keeps only the listed events when not empty
+	Types []RoomEventType `protobuf:"varint,2,rep,packed,name=types,proto3,enum=chat.RoomEventType" json:"types,omitempty"`
+	// muted drops every event of the room, the room stays followed
+	Muted         bool `protobuf:"varint,3,opt,name=muted,proto3" json:"muted,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomEventFilter) Reset() {
+	*x = RoomEventFilter{}
+	mi := &file_internal_pb_server_proto_msgTypes[88]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomEventFilter) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomEventFilter) ProtoMessage() {}
+
+func (x *RoomEventFilter) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[88]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomEventFilter.ProtoReflect.Descriptor instead.
+func (*RoomEventFilter) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{88}
+}
+
+func (x *RoomEventFilter) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
+	}
+	return ""
+}
+
+func (x *RoomEventFilter) GetTypes() []RoomEventType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
+func (x *RoomEventFilter) GetMuted() bool {
+	if x != nil {
+		return x.Muted
 	}
 	return false
 }
 
-func (x *Room) GetCreatedBy() string {
+type SubscribeEvent struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	RoomId string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
+	// Types that are valid to be assigned to Event:
+	//
+	//	*SubscribeEvent_RoomAdded
+	//	*SubscribeEvent_RoomRemoved
+	//	*SubscribeEvent_RoomEvent
+	//	*SubscribeEvent_Message
+	//	*SubscribeEvent_JoinRequestResolved
+	Event         isSubscribeEvent_Event `protobuf_oneof:"event"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SubscribeEvent) Reset() {
+	*x = SubscribeEvent{}
+	mi := &file_internal_pb_server_proto_msgTypes[89]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SubscribeEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeEvent) ProtoMessage() {}
+
+func (x *SubscribeEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[89]
 	if x != nil {
-		return x.CreatedBy
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeEvent.ProtoReflect.Descriptor instead.
+func (*SubscribeEvent) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{89}
+}
+
+func (x *SubscribeEvent) GetRoomId() string {
+	if x != nil {
+		return x.RoomId
 	}
 	return ""
 }
 
-func (x *Room) GetCreatedAt() *timestamppb.Timestamp {
+func (x *SubscribeEvent) GetEvent() isSubscribeEvent_Event {
 	if x != nil {
-		return x.CreatedAt
+		return x.Event
 	}
 	return nil
 }
 
-type ListRoomsResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Rooms         []*Room                `protobuf:"bytes,1,rep,name=rooms,proto3" json:"rooms,omitempty"`
+func (x *SubscribeEvent) GetRoomAdded() *Room {
+	if x != nil {
+		if x, ok := x.Event.(*SubscribeEvent_RoomAdded); ok {
+			return x.RoomAdded
+		}
+	}
+	return nil
+}
+
+func (x *SubscribeEvent) GetRoomRemoved() *RoomRemoved {
+	if x != nil {
+		if x, ok := x.Event.(*SubscribeEvent_RoomRemoved); ok {
+			return x.RoomRemoved
+		}
+	}
+	return nil
+}
+
+func (x *SubscribeEvent) GetRoomEvent() *RoomEvent {
+	if x != nil {
+		if x, ok := x.Event.(*SubscribeEvent_RoomEvent); ok {
+			return x.RoomEvent
+		}
+	}
+	return nil
+}
+
+func (x *SubscribeEvent) GetMessage() *ChatMessage {
+	if x != nil {
+		if x, ok := x.Event.(*SubscribeEvent_Message); ok {
+			return x.Message
+		}
+	}
+	return nil
+}
+
+func (x *SubscribeEvent) GetJoinRequestResolved() *JoinRequestResolved {
+	if x != nil {
+		if x, ok := x.Event.(*SubscribeEvent_JoinRequestResolved); ok {
+			return x.JoinRequestResolved
+		}
+	}
+	return nil
+}
+
+type isSubscribeEvent_Event interface {
+	isSubscribeEvent_Event()
+}
+
+type SubscribeEvent_RoomAdded struct {
+	// the user is a member of the room, its events follow; every room of the user is announced first
+	RoomAdded *Room `protobuf:"bytes,2,opt,name=room_added,json=roomAdded,proto3,oneof"`
+}
+
+type SubscribeEvent_RoomRemoved struct {
+	// no event of the room follows
+	RoomRemoved *RoomRemoved `protobuf:"bytes,3,opt,name=room_removed,json=roomRemoved,proto3,oneof"`
+}
+
+type SubscribeEvent_RoomEvent struct {
+	RoomEvent *RoomEvent `protobuf:"bytes,4,opt,name=room_event,json=roomEvent,proto3,oneof"`
+}
+
+type SubscribeEvent_Message struct {
+	Message *ChatMessage `protobuf:"bytes,5,opt,name=message,proto3,oneof"`
+}
+
+type SubscribeEvent_JoinRequestResolved struct {
+	// the answer to a join request of the user
+	JoinRequestResolved *JoinRequestResolved `protobuf:"bytes,6,opt,name=join_request_resolved,json=joinRequestResolved,proto3,oneof"`
+}
+
+func (*SubscribeEvent_RoomAdded) isSubscribeEvent_Event() {}
+
+func (*SubscribeEvent_RoomRemoved) isSubscribeEvent_Event() {}
+
+func (*SubscribeEvent_RoomEvent) isSubscribeEvent_Event() {}
+
+func (*SubscribeEvent_Message) isSubscribeEvent_Event() {}
+
+func (*SubscribeEvent_JoinRequestResolved) isSubscribeEvent_Event() {}
+
+type RoomRemoved struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// deleted is set when the room was deleted, unset when the user left it
+	Deleted       *RoomDeleted `protobuf:"bytes,1,opt,name=deleted,proto3" json:"deleted,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ListRoomsResponse) Reset() {
-	*x = ListRoomsResponse{}
-	mi := &file_internal_pb_server_proto_msgTypes[17]
+func (x *RoomRemoved) Reset() {
+	*x = RoomRemoved{}
+	mi := &file_internal_pb_server_proto_msgTypes[90]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ListRoomsResponse) String() string {
+func (x *RoomRemoved) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ListRoomsResponse) ProtoMessage() {}
+func (*RoomRemoved) ProtoMessage() {}
 
-func (x *ListRoomsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[17]
+func (x *RoomRemoved) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[90]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -923,40 +5852,95 @@ func (x *ListRoomsResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ListRoomsResponse.ProtoReflect.Descriptor instead.
-func (*ListRoomsResponse) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{17}
+// Deprecated: Use RoomRemoved.ProtoReflect.Descriptor instead.
+func (*RoomRemoved) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{90}
 }
 
-func (x *ListRoomsResponse) GetRooms() []*Room {
+func (x *RoomRemoved) GetDeleted() *RoomDeleted {
 	if x != nil {
-		return x.Rooms
+		return x.Deleted
 	}
 	return nil
 }
 
-type RoomMembers struct {
+// RoomDeleted is the last event of a room, the stream then ends with NOT_FOUND
+type RoomDeleted struct {
+	state  protoimpl.MessageState `protogen:"open.v1"`
+	Reason string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	// deleted_by is empty when the room was purged at the end of its archive grace period
+	DeletedBy     string `protobuf:"bytes,2,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RoomDeleted) Reset() {
+	*x = RoomDeleted{}
+	mi := &file_internal_pb_server_proto_msgTypes[91]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RoomDeleted) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RoomDeleted) ProtoMessage() {}
+
+func (x *RoomDeleted) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[91]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RoomDeleted.ProtoReflect.Descriptor instead.
+func (*RoomDeleted) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{91}
+}
+
+func (x *RoomDeleted) GetReason() string {
+	if x != nil {
+		return x.Reason
+	}
+	return ""
+}
+
+func (x *RoomDeleted) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
+// Waitlisted is the first event of a join queued behind a full room
+type Waitlisted struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserIds       []string               `protobuf:"bytes,1,rep,name=user_ids,json=userIds,proto3" json:"user_ids,omitempty"`
+	Position      uint32                 `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomMembers) Reset() {
-	*x = RoomMembers{}
-	mi := &file_internal_pb_server_proto_msgTypes[18]
+func (x *Waitlisted) Reset() {
+	*x = Waitlisted{}
+	mi := &file_internal_pb_server_proto_msgTypes[92]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomMembers) String() string {
+func (x *Waitlisted) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomMembers) ProtoMessage() {}
+func (*Waitlisted) ProtoMessage() {}
 
-func (x *RoomMembers) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[18]
+func (x *Waitlisted) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[92]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -967,40 +5951,41 @@ func (x *RoomMembers) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomMembers.ProtoReflect.Descriptor instead.
-func (*RoomMembers) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{18}
+// Deprecated: Use Waitlisted.ProtoReflect.Descriptor instead.
+func (*Waitlisted) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{92}
 }
 
-func (x *RoomMembers) GetUserIds() []string {
+func (x *Waitlisted) GetPosition() uint32 {
 	if x != nil {
-		return x.UserIds
+		return x.Position
 	}
-	return nil
+	return 0
 }
 
-type RoomID struct {
+// WaitlistPromoted is sent once a slot freed up and the waiting user became a member
+type WaitlistPromoted struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomID) Reset() {
-	*x = RoomID{}
-	mi := &file_internal_pb_server_proto_msgTypes[19]
+func (x *WaitlistPromoted) Reset() {
+	*x = WaitlistPromoted{}
+	mi := &file_internal_pb_server_proto_msgTypes[93]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomID) String() string {
+func (x *WaitlistPromoted) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomID) ProtoMessage() {}
+func (*WaitlistPromoted) ProtoMessage() {}
 
-func (x *RoomID) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[19]
+func (x *WaitlistPromoted) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[93]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1011,45 +5996,42 @@ func (x *RoomID) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomID.ProtoReflect.Descriptor instead.
-func (*RoomID) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{19}
+// Deprecated: Use WaitlistPromoted.ProtoReflect.Descriptor instead.
+func (*WaitlistPromoted) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{93}
 }
 
-func (x *RoomID) GetId() string {
+func (x *WaitlistPromoted) GetUserId() string {
 	if x != nil {
-		return x.Id
+		return x.UserId
 	}
 	return ""
 }
 
-type RoomEvent struct {
-	state protoimpl.MessageState `protogen:"open.v1"`
-	// Types that are valid to be assigned to Event:
-	//
-	//	*RoomEvent_UserJoined
-	//	*RoomEvent_UserLeft
-	//	*RoomEvent_RoomDeleted
-	Event         isRoomEvent_Event `protobuf_oneof:"event"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// OwnershipTransferred is sent when the room changes hands, the previous owner stays on as an admin
+type OwnershipTransferred struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	PreviousOwnerId string                 `protobuf:"bytes,1,opt,name=previous_owner_id,json=previousOwnerId,proto3" json:"previous_owner_id,omitempty"`
+	NewOwnerId      string                 `protobuf:"bytes,2,opt,name=new_owner_id,json=newOwnerId,proto3" json:"new_owner_id,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
-func (x *RoomEvent) Reset() {
-	*x = RoomEvent{}
-	mi := &file_internal_pb_server_proto_msgTypes[20]
+func (x *OwnershipTransferred) Reset() {
+	*x = OwnershipTransferred{}
+	mi := &file_internal_pb_server_proto_msgTypes[94]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomEvent) String() string {
+func (x *OwnershipTransferred) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomEvent) ProtoMessage() {}
+func (*OwnershipTransferred) ProtoMessage() {}
 
-func (x *RoomEvent) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[20]
+func (x *OwnershipTransferred) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[94]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1060,90 +6042,52 @@ func (x *RoomEvent) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomEvent.ProtoReflect.Descriptor instead.
-func (*RoomEvent) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{20}
-}
-
-func (x *RoomEvent) GetEvent() isRoomEvent_Event {
-	if x != nil {
-		return x.Event
-	}
-	return nil
-}
-
-func (x *RoomEvent) GetUserJoined() *UserJoined {
-	if x != nil {
-		if x, ok := x.Event.(*RoomEvent_UserJoined); ok {
-			return x.UserJoined
-		}
-	}
-	return nil
+// Deprecated: Use OwnershipTransferred.ProtoReflect.Descriptor instead.
+func (*OwnershipTransferred) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{94}
 }
 
-func (x *RoomEvent) GetUserLeft() *UserLeft {
+func (x *OwnershipTransferred) GetPreviousOwnerId() string {
 	if x != nil {
-		if x, ok := x.Event.(*RoomEvent_UserLeft); ok {
-			return x.UserLeft
-		}
+		return x.PreviousOwnerId
 	}
-	return nil
+	return ""
 }
 
-func (x *RoomEvent) GetRoomDeleted() *RoomDeleted {
+func (x *OwnershipTransferred) GetNewOwnerId() string {
 	if x != nil {
-		if x, ok := x.Event.(*RoomEvent_RoomDeleted); ok {
-			return x.RoomDeleted
-		}
+		return x.NewOwnerId
 	}
-	return nil
-}
-
-type isRoomEvent_Event interface {
-	isRoomEvent_Event()
-}
-
-type RoomEvent_UserJoined struct {
-	UserJoined *UserJoined `protobuf:"bytes,1,opt,name=user_joined,json=userJoined,proto3,oneof"`
-}
-
-type RoomEvent_UserLeft struct {
-	UserLeft *UserLeft `protobuf:"bytes,2,opt,name=user_left,json=userLeft,proto3,oneof"`
-}
-
-type RoomEvent_RoomDeleted struct {
-	RoomDeleted *RoomDeleted `protobuf:"bytes,3,opt,name=room_deleted,json=roomDeleted,proto3,oneof"`
+	return ""
 }
 
-func (*RoomEvent_UserJoined) isRoomEvent_Event() {}
-
-func (*RoomEvent_UserLeft) isRoomEvent_Event() {}
-
-func (*RoomEvent_RoomDeleted) isRoomEvent_Event() {}
-
-type UserJoined struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
-	Username      string                 `protobuf:"bytes,2,opt,name=username,proto3" json:"username,omitempty"`
+type RoomMetadataChanged struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Namespace string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Key       string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// empty when deleted
+	Value         string `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`
+	Deleted       bool   `protobuf:"varint,4,opt,name=deleted,proto3" json:"deleted,omitempty"`
+	ChangedBy     string `protobuf:"bytes,5,opt,name=changed_by,json=changedBy,proto3" json:"changed_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserJoined) Reset() {
-	*x = UserJoined{}
-	mi := &file_internal_pb_server_proto_msgTypes[21]
+func (x *RoomMetadataChanged) Reset() {
+	*x = RoomMetadataChanged{}
+	mi := &file_internal_pb_server_proto_msgTypes[95]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserJoined) String() string {
+func (x *RoomMetadataChanged) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserJoined) ProtoMessage() {}
+func (*RoomMetadataChanged) ProtoMessage() {}
 
-func (x *UserJoined) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[21]
+func (x *RoomMetadataChanged) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[95]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1154,47 +6098,70 @@ func (x *UserJoined) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserJoined.ProtoReflect.Descriptor instead.
-func (*UserJoined) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{21}
+// Deprecated: Use RoomMetadataChanged.ProtoReflect.Descriptor instead.
+func (*RoomMetadataChanged) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{95}
 }
 
-func (x *UserJoined) GetUserId() string {
+func (x *RoomMetadataChanged) GetNamespace() string {
 	if x != nil {
-		return x.UserId
+		return x.Namespace
 	}
 	return ""
 }
 
-func (x *UserJoined) GetUsername() string {
+func (x *RoomMetadataChanged) GetKey() string {
 	if x != nil {
-		return x.Username
+		return x.Key
 	}
 	return ""
 }
 
-type UserLeft struct {
+func (x *RoomMetadataChanged) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *RoomMetadataChanged) GetDeleted() bool {
+	if x != nil {
+		return x.Deleted
+	}
+	return false
+}
+
+func (x *RoomMetadataChanged) GetChangedBy() string {
+	if x != nil {
+		return x.ChangedBy
+	}
+	return ""
+}
+
+type JoinRequestResolved struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	UserId        string                 `protobuf:"bytes,1,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	Approved      bool                   `protobuf:"varint,2,opt,name=approved,proto3" json:"approved,omitempty"`
+	ReviewedBy    string                 `protobuf:"bytes,3,opt,name=reviewed_by,json=reviewedBy,proto3" json:"reviewed_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *UserLeft) Reset() {
-	*x = UserLeft{}
-	mi := &file_internal_pb_server_proto_msgTypes[22]
+func (x *JoinRequestResolved) Reset() {
+	*x = JoinRequestResolved{}
+	mi := &file_internal_pb_server_proto_msgTypes[96]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *UserLeft) String() string {
+func (x *JoinRequestResolved) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*UserLeft) ProtoMessage() {}
+func (*JoinRequestResolved) ProtoMessage() {}
 
-func (x *UserLeft) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[22]
+func (x *JoinRequestResolved) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[96]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1205,40 +6172,55 @@ func (x *UserLeft) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use UserLeft.ProtoReflect.Descriptor instead.
-func (*UserLeft) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{22}
+// Deprecated: Use JoinRequestResolved.ProtoReflect.Descriptor instead.
+func (*JoinRequestResolved) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{96}
 }
 
-func (x *UserLeft) GetUserId() string {
+func (x *JoinRequestResolved) GetUserId() string {
 	if x != nil {
 		return x.UserId
 	}
 	return ""
 }
 
-type RoomDeleted struct {
+func (x *JoinRequestResolved) GetApproved() bool {
+	if x != nil {
+		return x.Approved
+	}
+	return false
+}
+
+func (x *JoinRequestResolved) GetReviewedBy() string {
+	if x != nil {
+		return x.ReviewedBy
+	}
+	return ""
+}
+
+type RoomUpdated struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Reason        string                 `protobuf:"bytes,1,opt,name=reason,proto3" json:"reason,omitempty"`
+	Room          *Room                  `protobuf:"bytes,1,opt,name=room,proto3" json:"room,omitempty"`
+	UpdatedBy     string                 `protobuf:"bytes,2,opt,name=updated_by,json=updatedBy,proto3" json:"updated_by,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *RoomDeleted) Reset() {
-	*x = RoomDeleted{}
-	mi := &file_internal_pb_server_proto_msgTypes[23]
+func (x *RoomUpdated) Reset() {
+	*x = RoomUpdated{}
+	mi := &file_internal_pb_server_proto_msgTypes[97]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *RoomDeleted) String() string {
+func (x *RoomUpdated) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*RoomDeleted) ProtoMessage() {}
+func (*RoomUpdated) ProtoMessage() {}
 
-func (x *RoomDeleted) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[23]
+func (x *RoomUpdated) ProtoReflect() protoreflect.Message {
+	mi := &file_internal_pb_server_proto_msgTypes[97]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1249,14 +6231,21 @@ func (x *RoomDeleted) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use RoomDeleted.ProtoReflect.Descriptor instead.
-func (*RoomDeleted) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{23}
+// Deprecated: Use RoomUpdated.ProtoReflect.Descriptor instead.
+func (*RoomUpdated) Descriptor() ([]byte, []int) {
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{97}
 }
 
-func (x *RoomDeleted) GetReason() string {
+func (x *RoomUpdated) GetRoom() *Room {
 	if x != nil {
-		return x.Reason
+		return x.Room
+	}
+	return nil
+}
+
+func (x *RoomUpdated) GetUpdatedBy() string {
+	if x != nil {
+		return x.UpdatedBy
 	}
 	return ""
 }
@@ -1267,13 +6256,16 @@ type RoomStatsResponse struct {
 	TotalMembers  int32                  `protobuf:"varint,2,opt,name=total_members,json=totalMembers,proto3" json:"total_members,omitempty"`
 	ActiveMembers int32                  `protobuf:"varint,3,opt,name=active_members,json=activeMembers,proto3" json:"active_members,omitempty"`
 	LastActivity  *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=last_activity,json=lastActivity,proto3" json:"last_activity,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	// events the answering server could not deliver to its slow streams of the room
+	DroppedEvents           uint64 `protobuf:"varint,5,opt,name=dropped_events,json=droppedEvents,proto3" json:"dropped_events,omitempty"`
+	SlowConsumerDisconnects uint64 `protobuf:"varint,6,opt,name=slow_consumer_disconnects,json=slowConsumerDisconnects,proto3" json:"slow_consumer_disconnects,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
 }
 
 func (x *RoomStatsResponse) Reset() {
 	*x = RoomStatsResponse{}
-	mi := &file_internal_pb_server_proto_msgTypes[24]
+	mi := &file_internal_pb_server_proto_msgTypes[98]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1285,7 +6277,7 @@ func (x *RoomStatsResponse) String() string {
 func (*RoomStatsResponse) ProtoMessage() {}
 
 func (x *RoomStatsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[24]
+	mi := &file_internal_pb_server_proto_msgTypes[98]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1298,7 +6290,7 @@ func (x *RoomStatsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RoomStatsResponse.ProtoReflect.Descriptor instead.
 func (*RoomStatsResponse) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{24}
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{98}
 }
 
 func (x *RoomStatsResponse) GetRoom() *Room {
@@ -1329,6 +6321,20 @@ func (x *RoomStatsResponse) GetLastActivity() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *RoomStatsResponse) GetDroppedEvents() uint64 {
+	if x != nil {
+		return x.DroppedEvents
+	}
+	return 0
+}
+
+func (x *RoomStatsResponse) GetSlowConsumerDisconnects() uint64 {
+	if x != nil {
+		return x.SlowConsumerDisconnects
+	}
+	return 0
+}
+
 type SendMessageRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	RoomId        string                 `protobuf:"bytes,1,opt,name=room_id,json=roomId,proto3" json:"room_id,omitempty"`
@@ -1339,7 +6345,7 @@ type SendMessageRequest struct {
 
 func (x *SendMessageRequest) Reset() {
 	*x = SendMessageRequest{}
-	mi := &file_internal_pb_server_proto_msgTypes[25]
+	mi := &file_internal_pb_server_proto_msgTypes[99]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1351,7 +6357,7 @@ func (x *SendMessageRequest) String() string {
 func (*SendMessageRequest) ProtoMessage() {}
 
 func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[25]
+	mi := &file_internal_pb_server_proto_msgTypes[99]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1364,7 +6370,7 @@ func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
 func (*SendMessageRequest) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{25}
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{99}
 }
 
 func (x *SendMessageRequest) GetRoomId() string {
@@ -1395,7 +6401,7 @@ type ChatMessage struct {
 
 func (x *ChatMessage) Reset() {
 	*x = ChatMessage{}
-	mi := &file_internal_pb_server_proto_msgTypes[26]
+	mi := &file_internal_pb_server_proto_msgTypes[100]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1407,7 +6413,7 @@ func (x *ChatMessage) String() string {
 func (*ChatMessage) ProtoMessage() {}
 
 func (x *ChatMessage) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[26]
+	mi := &file_internal_pb_server_proto_msgTypes[100]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1420,7 +6426,7 @@ func (x *ChatMessage) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ChatMessage.ProtoReflect.Descriptor instead.
 func (*ChatMessage) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{26}
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{100}
 }
 
 func (x *ChatMessage) GetId() string {
@@ -1475,7 +6481,7 @@ type MessageAck struct {
 
 func (x *MessageAck) Reset() {
 	*x = MessageAck{}
-	mi := &file_internal_pb_server_proto_msgTypes[27]
+	mi := &file_internal_pb_server_proto_msgTypes[101]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1487,7 +6493,7 @@ func (x *MessageAck) String() string {
 func (*MessageAck) ProtoMessage() {}
 
 func (x *MessageAck) ProtoReflect() protoreflect.Message {
-	mi := &file_internal_pb_server_proto_msgTypes[27]
+	mi := &file_internal_pb_server_proto_msgTypes[101]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1500,7 +6506,7 @@ func (x *MessageAck) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use MessageAck.ProtoReflect.Descriptor instead.
 func (*MessageAck) Descriptor() ([]byte, []int) {
-	return file_internal_pb_server_proto_rawDescGZIP(), []int{27}
+	return file_internal_pb_server_proto_rawDescGZIP(), []int{101}
 }
 
 func (x *MessageAck) GetMessageId() string {
@@ -1521,7 +6527,7 @@ var File_internal_pb_server_proto protoreflect.FileDescriptor
 
 const file_internal_pb_server_proto_rawDesc = "" +
 	"\n" +
-	"\x18internal/pb/server.proto\x12\x04chat\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"F\n" +
+	"\x18internal/pb/server.proto\x12\x04chat\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a google/protobuf/field_mask.proto\x1a\x1egoogle/protobuf/duration.proto\"F\n" +
 	"\fLoginRequest\x12\x1a\n" +
 	"\busername\x18\x01 \x01(\tR\busername\x12\x1a\n" +
 	"\bpassword\x18\x02 \x01(\tR\bpassword\"c\n" +
@@ -1551,20 +6557,147 @@ const file_internal_pb_server_proto_rawDesc = "" +
 	"\acontent\x18\x01 \x01(\tR\acontent\"A\n" +
 	"\rServerMessage\x12\x18\n" +
 	"\acontent\x18\x01 \x01(\tR\acontent\x12\x16\n" +
-	"\x06sender\x18\x02 \x01(\tR\x06sender\"F\n" +
+	"\x06sender\x18\x02 \x01(\tR\x06sender\"\x88\x01\n" +
 	"\x11CreateRoomRequest\x12\x12\n" +
 	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
 	"\n" +
-	"is_private\x18\x02 \x01(\bR\tisPrivate\"*\n" +
+	"is_private\x18\x02 \x01(\bR\tisPrivate\x12\x1f\n" +
+	"\vmax_members\x18\x03 \x01(\rR\n" +
+	"maxMembers\x12\x1f\n" +
+	"\vtemplate_id\x18\x04 \x01(\tR\n" +
+	"templateId\"\x91\x04\n" +
+	"\fRoomTemplate\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
+	"\fname_pattern\x18\x03 \x01(\tR\vnamePattern\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\x04 \x01(\bR\tisPrivate\x12I\n" +
+	"\rdefault_roles\x18\x05 \x03(\v2$.chat.RoomTemplate.DefaultRolesEntryR\fdefaultRoles\x12'\n" +
+	"\x0fwelcome_message\x18\x06 \x01(\tR\x0ewelcomeMessage\x12F\n" +
+	"\x11message_retention\x18\a \x01(\v2\x19.google.protobuf.DurationR\x10messageRetention\x122\n" +
+	"\bmetadata\x18\b \x03(\v2\x16.chat.TemplateMetadataR\bmetadata\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\t \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x1aQ\n" +
+	"\x11DefaultRolesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12&\n" +
+	"\x05value\x18\x02 \x01(\x0e2\x10.chat.MemberRoleR\x05value:\x028\x01\"\xa7\x01\n" +
+	"\x10TemplateMetadata\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12:\n" +
+	"\x06values\x18\x02 \x03(\v2\".chat.TemplateMetadata.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"K\n" +
+	"\x19CreateRoomTemplateRequest\x12.\n" +
+	"\btemplate\x18\x01 \x01(\v2\x12.chat.RoomTemplateR\btemplate\"\x1a\n" +
+	"\x18ListRoomTemplatesRequest\"M\n" +
+	"\x19ListRoomTemplatesResponse\x120\n" +
+	"\ttemplates\x18\x01 \x03(\v2\x12.chat.RoomTemplateR\ttemplates\"L\n" +
 	"\x0fJoinRoomRequest\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"D\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12 \n" +
+	"\fwait_if_full\x18\x02 \x01(\bR\n" +
+	"waitIfFull\"D\n" +
 	"\x10LeaveRoomRequest\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x17\n" +
 	"\auser_id\x18\x02 \x01(\tR\x06userId\")\n" +
 	"\x0eGetRoomRequest\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\tR\x06roomId\",\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"D\n" +
 	"\x11DeleteRoomRequest\x12\x17\n" +
-	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"\xc6\x01\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x16\n" +
+	"\x06reason\x18\x02 \x01(\tR\x06reason\"-\n" +
+	"\x12ArchiveRoomRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"/\n" +
+	"\x14UnarchiveRoomRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"\xfd\x01\n" +
+	"\n" +
+	"InviteLink\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"expires_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x19\n" +
+	"\bmax_uses\x18\x06 \x01(\rR\amaxUses\x12\x12\n" +
+	"\x04uses\x18\a \x01(\rR\x04uses\"\x88\x01\n" +
+	"\x17CreateInviteLinkRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x19\n" +
+	"\bmax_uses\x18\x02 \x01(\rR\amaxUses\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"-\n" +
+	"\x17RevokeInviteLinkRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"1\n" +
+	"\x16ListInviteLinksRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"A\n" +
+	"\x17ListInviteLinksResponse\x12&\n" +
+	"\x05links\x18\x01 \x03(\v2\x10.chat.InviteLinkR\x05links\"-\n" +
+	"\x17JoinByInviteCodeRequest\x12\x12\n" +
+	"\x04code\x18\x01 \x01(\tR\x04code\"I\n" +
+	"\x14RequestToJoinRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x18\n" +
+	"\amessage\x18\x02 \x01(\tR\amessage\"\x98\x01\n" +
+	"\vJoinRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12=\n" +
+	"\frequested_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\vrequestedAt\"\x8f\x01\n" +
+	"\x11JoinRequestUpdate\x12+\n" +
+	"\arequest\x18\x01 \x01(\v2\x11.chat.JoinRequestR\arequest\x12,\n" +
+	"\x05state\x18\x02 \x01(\x0e2\x16.chat.JoinRequestStateR\x05state\x12\x1f\n" +
+	"\vreviewed_by\x18\x03 \x01(\tR\n" +
+	"reviewedBy\"2\n" +
+	"\x17ListJoinRequestsRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"I\n" +
+	"\x18ListJoinRequestsResponse\x12-\n" +
+	"\brequests\x18\x01 \x03(\v2\x11.chat.JoinRequestR\brequests\"w\n" +
+	"\x16SetRoomMetadataRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x04 \x01(\tR\x05value\"c\n" +
+	"\x16GetRoomMetadataRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x12\n" +
+	"\x04keys\x18\x03 \x03(\tR\x04keys\"\xb8\x01\n" +
+	"\fRoomMetadata\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x126\n" +
+	"\x06values\x18\x03 \x03(\v2\x1e.chat.RoomMetadata.ValuesEntryR\x06values\x1a9\n" +
+	"\vValuesEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"d\n" +
+	"\x19DeleteRoomMetadataRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12\x10\n" +
+	"\x03key\x18\x03 \x01(\tR\x03key\"\xb5\x01\n" +
+	"\x1cSetRoomMetadataPolicyRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1c\n" +
+	"\tnamespace\x18\x02 \x01(\tR\tnamespace\x12-\n" +
+	"\tread_role\x18\x03 \x01(\x0e2\x10.chat.MemberRoleR\breadRole\x12/\n" +
+	"\n" +
+	"write_role\x18\x04 \x01(\x0e2\x10.chat.MemberRoleR\twriteRole\"0\n" +
+	"\x15ReconcileRoomsRequest\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\"\x90\x01\n" +
+	"\x11RoomInconsistency\x12+\n" +
+	"\x04kind\x18\x01 \x01(\x0e2\x17.chat.InconsistencyKindR\x04kind\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12\x19\n" +
+	"\buser_ids\x18\x03 \x03(\tR\auserIds\x12\x1a\n" +
+	"\brepaired\x18\x04 \x01(\bR\brepaired\"\x99\x01\n" +
+	"\x16ReconcileRoomsResponse\x12\x17\n" +
+	"\adry_run\x18\x01 \x01(\bR\x06dryRun\x12#\n" +
+	"\rrooms_checked\x18\x02 \x01(\rR\froomsChecked\x12A\n" +
+	"\x0finconsistencies\x18\x03 \x03(\v2\x17.chat.RoomInconsistencyR\x0finconsistencies\"N\n" +
+	"\x1aJoinRequestDecisionRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"p\n" +
+	"\x11UpdateRoomRequest\x12\x1e\n" +
+	"\x04room\x18\x01 \x01(\v2\n" +
+	".chat.RoomR\x04room\x12;\n" +
+	"\vupdate_mask\x18\x02 \x01(\v2\x1a.google.protobuf.FieldMaskR\n" +
+	"updateMask\"\xc3\x06\n" +
 	"\x04Room\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12!\n" +
@@ -1574,34 +6707,280 @@ const file_internal_pb_server_proto_rawDesc = "" +
 	"\n" +
 	"created_by\x18\x05 \x01(\tR\tcreatedBy\x129\n" +
 	"\n" +
-	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"5\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x14\n" +
+	"\x05topic\x18\a \x01(\tR\x05topic\x12 \n" +
+	"\vdescription\x18\b \x01(\tR\vdescription\x12\x1d\n" +
+	"\n" +
+	"avatar_url\x18\t \x01(\tR\tavatarUrl\x12?\n" +
+	"\rlast_activity\x18\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\x12;\n" +
+	"\varchived_at\x18\v \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"archivedAt\x12\x1f\n" +
+	"\varchived_by\x18\f \x01(\tR\n" +
+	"archivedBy\x125\n" +
+	"\bpurge_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\apurgeAt\x12\x1f\n" +
+	"\vmax_members\x18\x0e \x01(\rR\n" +
+	"maxMembers\x12\x19\n" +
+	"\bspace_id\x18\x0f \x01(\tR\aspaceId\x12\x1a\n" +
+	"\bcategory\x18\x10 \x01(\tR\bcategory\x12G\n" +
+	"\x12slow_mode_interval\x18\x11 \x01(\v2\x19.google.protobuf.DurationR\x10slowModeInterval\x12+\n" +
+	"\x11announcement_only\x18\x12 \x01(\bR\x10announcementOnly\x12F\n" +
+	"\x11message_retention\x18\x13 \x01(\v2\x19.google.protobuf.DurationR\x10messageRetention\x128\n" +
+	"\x0epinned_message\x18\x14 \x01(\v2\x11.chat.ChatMessageR\rpinnedMessage\"\x80\x01\n" +
+	"\x11MuteMemberRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\"G\n" +
+	"\x13UnmuteMemberRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"n\n" +
+	"\x14SetMemberRoleRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\x12$\n" +
+	"\x04role\x18\x03 \x01(\x0e2\x10.chat.MemberRoleR\x04role\"U\n" +
+	"\x18TransferOwnershipRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12 \n" +
+	"\fnew_owner_id\x18\x02 \x01(\tR\n" +
+	"newOwnerId\",\n" +
+	"\x11ExportRoomRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"&\n" +
+	"\x10RoomArchiveChunk\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\x83\x01\n" +
+	"\x11ImportRoomRequest\x123\n" +
+	"\aoptions\x18\x01 \x01(\v2\x17.chat.ImportRoomOptionsH\x00R\aoptions\x12.\n" +
+	"\x05chunk\x18\x02 \x01(\v2\x16.chat.RoomArchiveChunkH\x00R\x05chunkB\t\n" +
+	"\apayload\"\x99\x01\n" +
+	"\x11ImportRoomOptions\x12F\n" +
+	"\vuser_id_map\x18\x01 \x03(\v2&.chat.ImportRoomOptions.UserIdMapEntryR\tuserIdMap\x1a<\n" +
+	"\x0eUserIdMapEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xa4\x01\n" +
+	"\x05Space\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\x03 \x01(\bR\tisPrivate\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x04 \x01(\tR\tcreatedBy\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\"E\n" +
+	"\rSpaceCategory\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12 \n" +
+	"\x05rooms\x18\x02 \x03(\v2\n" +
+	".chat.RoomR\x05rooms\"g\n" +
+	"\x12CreateSpaceRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x1d\n" +
+	"\n" +
+	"is_private\x18\x02 \x01(\bR\tisPrivate\x12\x1e\n" +
+	"\n" +
+	"categories\x18\x03 \x03(\tR\n" +
+	"categories\"-\n" +
+	"\x10JoinSpaceRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\"K\n" +
+	"\x15AddSpaceMemberRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12\x17\n" +
+	"\auser_id\x18\x02 \x01(\tR\x06userId\"g\n" +
+	"\x15AddRoomToSpaceRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\x12\x17\n" +
+	"\aroom_id\x18\x02 \x01(\tR\x06roomId\x12\x1a\n" +
+	"\bcategory\x18\x03 \x01(\tR\bcategory\"b\n" +
+	"\x0fMoveRoomRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1a\n" +
+	"\bcategory\x18\x02 \x01(\tR\bcategory\x12\x1a\n" +
+	"\bposition\x18\x03 \x01(\rR\bposition\"2\n" +
+	"\x15ListSpaceRoomsRequest\x12\x19\n" +
+	"\bspace_id\x18\x01 \x01(\tR\aspaceId\"p\n" +
+	"\x16ListSpaceRoomsResponse\x12!\n" +
+	"\x05space\x18\x01 \x01(\v2\v.chat.SpaceR\x05space\x123\n" +
+	"\n" +
+	"categories\x18\x02 \x03(\v2\x13.chat.SpaceCategoryR\n" +
+	"categories\"\x93\x01\n" +
+	"\x10ListRoomsRequest\x12\x1b\n" +
+	"\tpage_size\x18\x01 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x02 \x01(\tR\tpageToken\x12(\n" +
+	"\x06filter\x18\x03 \x01(\v2\x10.chat.RoomFilterR\x06filter\x12\x19\n" +
+	"\border_by\x18\x04 \x01(\tR\aorderBy\"\xc8\x01\n" +
+	"\n" +
+	"RoomFilter\x12\x1f\n" +
+	"\vname_prefix\x18\x01 \x01(\tR\n" +
+	"namePrefix\x12\"\n" +
+	"\n" +
+	"is_private\x18\x02 \x01(\bH\x00R\tisPrivate\x88\x01\x01\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x03 \x01(\tR\tcreatedBy\x12\x1f\n" +
+	"\barchived\x18\x04 \x01(\bH\x01R\barchived\x88\x01\x01\x12\x19\n" +
+	"\bspace_id\x18\x05 \x01(\tR\aspaceIdB\r\n" +
+	"\v_is_privateB\v\n" +
+	"\t_archived\"]\n" +
 	"\x11ListRoomsResponse\x12 \n" +
 	"\x05rooms\x18\x01 \x03(\v2\n" +
-	".chat.RoomR\x05rooms\"(\n" +
-	"\vRoomMembers\x12\x19\n" +
-	"\buser_ids\x18\x01 \x03(\tR\auserIds\"\x18\n" +
+	".chat.RoomR\x05rooms\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"X\n" +
+	"\x12SearchRoomsRequest\x12\x14\n" +
+	"\x05query\x18\x01 \x01(\tR\x05query\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06cursor\x18\x03 \x01(\tR\x06cursor\"h\n" +
+	"\x13SearchRoomsResponse\x120\n" +
+	"\aresults\x18\x01 \x03(\v2\x16.chat.RoomSearchResultR\aresults\x12\x1f\n" +
+	"\vnext_cursor\x18\x02 \x01(\tR\n" +
+	"nextCursor\"P\n" +
+	"\x10RoomSearchResult\x12\x1e\n" +
+	"\x04room\x18\x01 \x01(\v2\n" +
+	".chat.RoomR\x04room\x12\x1c\n" +
+	"\trelevance\x18\x02 \x01(\x01R\trelevance\"\x13\n" +
+	"\x11WatchRoomsRequest\"\x94\x03\n" +
+	"\x12RoomDirectoryEvent\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x129\n" +
+	"\bsnapshot\x18\x02 \x01(\v2\x1b.chat.RoomDirectorySnapshotH\x00R\bsnapshot\x12/\n" +
+	"\froom_created\x18\x03 \x01(\v2\n" +
+	".chat.RoomH\x00R\vroomCreated\x12/\n" +
+	"\froom_updated\x18\x04 \x01(\v2\n" +
+	".chat.RoomH\x00R\vroomUpdated\x126\n" +
+	"\froom_deleted\x18\x05 \x01(\v2\x11.chat.RoomDeletedH\x00R\vroomDeleted\x12L\n" +
+	"\x14member_count_changed\x18\x06 \x01(\v2\x18.chat.MemberCountChangedH\x00R\x12memberCountChanged\x129\n" +
+	"\vroom_hidden\x18\a \x01(\v2\x16.google.protobuf.EmptyH\x00R\n" +
+	"roomHiddenB\a\n" +
+	"\x05event\"U\n" +
+	"\x15RoomDirectorySnapshot\x12 \n" +
+	"\x05rooms\x18\x01 \x03(\v2\n" +
+	".chat.RoomR\x05rooms\x12\x1a\n" +
+	"\bcomplete\x18\x02 \x01(\bR\bcomplete\"*\n" +
+	"\x12MemberCountChanged\x12\x14\n" +
+	"\x05delta\x18\x01 \x01(\x05R\x05delta\"\xdd\x01\n" +
+	"\x16ListRoomMembersRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x1b\n" +
+	"\tpage_size\x18\x02 \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x03 \x01(\tR\tpageToken\x12)\n" +
+	"\x04role\x18\x04 \x01(\x0e2\x10.chat.MemberRoleH\x00R\x04role\x88\x01\x01\x12/\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x12.chat.MemberStatusH\x01R\x06status\x88\x01\x01B\a\n" +
+	"\x05_roleB\t\n" +
+	"\a_status\"m\n" +
+	"\x17ListRoomMembersResponse\x12*\n" +
+	"\amembers\x18\x01 \x03(\v2\x10.chat.MemberInfoR\amembers\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xc2\x02\n" +
+	"\n" +
+	"MemberInfo\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12$\n" +
+	"\x04role\x18\x02 \x01(\x0e2\x10.chat.MemberRoleR\x04role\x12\x14\n" +
+	"\x05muted\x18\x03 \x01(\bR\x05muted\x12;\n" +
+	"\vmuted_until\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
+	"mutedUntil\x12*\n" +
+	"\x06status\x18\x05 \x01(\x0e2\x12.chat.MemberStatusR\x06status\x12\x1a\n" +
+	"\busername\x18\x06 \x01(\tR\busername\x12!\n" +
+	"\fdisplay_name\x18\a \x01(\tR\vdisplayName\x127\n" +
+	"\tjoined_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\bjoinedAt\"8\n" +
+	"\fRoomPresence\x12(\n" +
+	"\x05users\x18\x01 \x03(\v2\x12.chat.UserPresenceR\x05users\"Z\n" +
+	"\fUserPresence\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x121\n" +
+	"\bsessions\x18\x02 \x03(\v2\x15.chat.PresenceSessionR\bsessions\"\x9d\x01\n" +
+	"\x0fPresenceSession\x12\x1d\n" +
+	"\n" +
+	"session_id\x18\x01 \x01(\tR\tsessionId\x12\x17\n" +
+	"\anode_id\x18\x02 \x01(\tR\x06nodeId\x129\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\texpiresAt\x12\x17\n" +
+	"\aroom_id\x18\x04 \x01(\tR\x06roomId\"1\n" +
+	"\x16GetUserPresenceRequest\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"\x18\n" +
 	"\x06RoomID\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\xb0\x01\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\x8b\x05\n" +
 	"\tRoomEvent\x123\n" +
 	"\vuser_joined\x18\x01 \x01(\v2\x10.chat.UserJoinedH\x00R\n" +
 	"userJoined\x12-\n" +
 	"\tuser_left\x18\x02 \x01(\v2\x0e.chat.UserLeftH\x00R\buserLeft\x126\n" +
-	"\froom_deleted\x18\x03 \x01(\v2\x11.chat.RoomDeletedH\x00R\vroomDeletedB\a\n" +
+	"\froom_deleted\x18\x03 \x01(\v2\x11.chat.RoomDeletedH\x00R\vroomDeleted\x126\n" +
+	"\froom_updated\x18\x04 \x01(\v2\x11.chat.RoomUpdatedH\x00R\vroomUpdated\x122\n" +
+	"\n" +
+	"waitlisted\x18\x05 \x01(\v2\x10.chat.WaitlistedH\x00R\n" +
+	"waitlisted\x12E\n" +
+	"\x11waitlist_promoted\x18\x06 \x01(\v2\x16.chat.WaitlistPromotedH\x00R\x10waitlistPromoted\x12Q\n" +
+	"\x15ownership_transferred\x18\a \x01(\v2\x1a.chat.OwnershipTransferredH\x00R\x14ownershipTransferred\x12:\n" +
+	"\x0ejoin_requested\x18\b \x01(\v2\x11.chat.JoinRequestH\x00R\rjoinRequested\x12O\n" +
+	"\x15join_request_resolved\x18\t \x01(\v2\x19.chat.JoinRequestResolvedH\x00R\x13joinRequestResolved\x12F\n" +
+	"\x10metadata_changed\x18\n" +
+	" \x01(\v2\x19.chat.RoomMetadataChangedH\x00R\x0fmetadataChangedB\a\n" +
 	"\x05event\"A\n" +
 	"\n" +
 	"UserJoined\x12\x17\n" +
 	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
 	"\busername\x18\x02 \x01(\tR\busername\"#\n" +
 	"\bUserLeft\x12\x17\n" +
-	"\auser_id\x18\x01 \x01(\tR\x06userId\"%\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"R\n" +
+	"\x12PreviewRoomRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12#\n" +
+	"\rmessage_limit\x18\x02 \x01(\rR\fmessageLimit\"\\\n" +
+	"\vRoomPreview\x12\x1e\n" +
+	"\x04room\x18\x01 \x01(\v2\n" +
+	".chat.RoomR\x04room\x12-\n" +
+	"\bmessages\x18\x02 \x03(\v2\x11.chat.ChatMessageR\bmessages\"1\n" +
+	"\x16WatchPublicRoomRequest\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\"\xba\x01\n" +
+	"\x10RoomPreviewEvent\x12-\n" +
+	"\amessage\x18\x01 \x01(\v2\x11.chat.ChatMessageH\x00R\amessage\x126\n" +
+	"\froom_updated\x18\x02 \x01(\v2\x11.chat.RoomUpdatedH\x00R\vroomUpdated\x126\n" +
+	"\froom_deleted\x18\x03 \x01(\v2\x11.chat.RoomDeletedH\x00R\vroomDeletedB\a\n" +
+	"\x05event\"C\n" +
+	"\x10SubscribeRequest\x12/\n" +
+	"\afilters\x18\x01 \x03(\v2\x15.chat.RoomEventFilterR\afilters\"k\n" +
+	"\x0fRoomEventFilter\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12)\n" +
+	"\x05types\x18\x02 \x03(\x0e2\x13.chat.RoomEventTypeR\x05types\x12\x14\n" +
+	"\x05muted\x18\x03 \x01(\bR\x05muted\"\xc9\x02\n" +
+	"\x0eSubscribeEvent\x12\x17\n" +
+	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12+\n" +
+	"\n" +
+	"room_added\x18\x02 \x01(\v2\n" +
+	".chat.RoomH\x00R\troomAdded\x126\n" +
+	"\froom_removed\x18\x03 \x01(\v2\x11.chat.RoomRemovedH\x00R\vroomRemoved\x120\n" +
+	"\n" +
+	"room_event\x18\x04 \x01(\v2\x0f.chat.RoomEventH\x00R\troomEvent\x12-\n" +
+	"\amessage\x18\x05 \x01(\v2\x11.chat.ChatMessageH\x00R\amessage\x12O\n" +
+	"\x15join_request_resolved\x18\x06 \x01(\v2\x19.chat.JoinRequestResolvedH\x00R\x13joinRequestResolvedB\a\n" +
+	"\x05event\":\n" +
+	"\vRoomRemoved\x12+\n" +
+	"\adeleted\x18\x01 \x01(\v2\x11.chat.RoomDeletedR\adeleted\"D\n" +
 	"\vRoomDeleted\x12\x16\n" +
-	"\x06reason\x18\x01 \x01(\tR\x06reason\"\xc0\x01\n" +
+	"\x06reason\x18\x01 \x01(\tR\x06reason\x12\x1d\n" +
+	"\n" +
+	"deleted_by\x18\x02 \x01(\tR\tdeletedBy\"(\n" +
+	"\n" +
+	"Waitlisted\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\rR\bposition\"+\n" +
+	"\x10WaitlistPromoted\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\"d\n" +
+	"\x14OwnershipTransferred\x12*\n" +
+	"\x11previous_owner_id\x18\x01 \x01(\tR\x0fpreviousOwnerId\x12 \n" +
+	"\fnew_owner_id\x18\x02 \x01(\tR\n" +
+	"newOwnerId\"\x94\x01\n" +
+	"\x13RoomMetadataChanged\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\tR\x05value\x12\x18\n" +
+	"\adeleted\x18\x04 \x01(\bR\adeleted\x12\x1d\n" +
+	"\n" +
+	"changed_by\x18\x05 \x01(\tR\tchangedBy\"k\n" +
+	"\x13JoinRequestResolved\x12\x17\n" +
+	"\auser_id\x18\x01 \x01(\tR\x06userId\x12\x1a\n" +
+	"\bapproved\x18\x02 \x01(\bR\bapproved\x12\x1f\n" +
+	"\vreviewed_by\x18\x03 \x01(\tR\n" +
+	"reviewedBy\"L\n" +
+	"\vRoomUpdated\x12\x1e\n" +
+	"\x04room\x18\x01 \x01(\v2\n" +
+	".chat.RoomR\x04room\x12\x1d\n" +
+	"\n" +
+	"updated_by\x18\x02 \x01(\tR\tupdatedBy\"\xa3\x02\n" +
 	"\x11RoomStatsResponse\x12\x1e\n" +
 	"\x04room\x18\x01 \x01(\v2\n" +
 	".chat.RoomR\x04room\x12#\n" +
 	"\rtotal_members\x18\x02 \x01(\x05R\ftotalMembers\x12%\n" +
 	"\x0eactive_members\x18\x03 \x01(\x05R\ractiveMembers\x12?\n" +
-	"\rlast_activity\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\"G\n" +
+	"\rlast_activity\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\flastActivity\x12%\n" +
+	"\x0edropped_events\x18\x05 \x01(\x04R\rdroppedEvents\x12:\n" +
+	"\x19slow_consumer_disconnects\x18\x06 \x01(\x04R\x17slowConsumerDisconnects\"G\n" +
 	"\x12SendMessageRequest\x12\x17\n" +
 	"\aroom_id\x18\x01 \x01(\tR\x06roomId\x12\x18\n" +
 	"\acontent\x18\x02 \x01(\tR\acontent\"\xa3\x01\n" +
@@ -1616,26 +6995,109 @@ const file_internal_pb_server_proto_rawDesc = "" +
 	"MessageAck\x12\x1d\n" +
 	"\n" +
 	"message_id\x18\x01 \x01(\tR\tmessageId\x12\x1c\n" +
-	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp2\xb3\x02\n" +
+	"\ttimestamp\x18\x02 \x01(\tR\ttimestamp*b\n" +
+	"\x10JoinRequestState\x12\x18\n" +
+	"\x14JOIN_REQUEST_PENDING\x10\x00\x12\x19\n" +
+	"\x15JOIN_REQUEST_APPROVED\x10\x01\x12\x19\n" +
+	"\x15JOIN_REQUEST_REJECTED\x10\x02*\xce\x01\n" +
+	"\x11InconsistencyKind\x12\"\n" +
+	"\x1eINCONSISTENCY_ORPHANED_MEMBERS\x10\x00\x12\x1e\n" +
+	"\x1aINCONSISTENCY_MISSING_ROOM\x10\x01\x12$\n" +
+	" INCONSISTENCY_MEMBER_COUNT_DRIFT\x10\x02\x12)\n" +
+	"%INCONSISTENCY_MEMBERS_WITHOUT_SESSION\x10\x03\x12$\n" +
+	" INCONSISTENCY_ORPHANED_ROOM_KEYS\x10\x04*Q\n" +
+	"\n" +
+	"MemberRole\x12\x0f\n" +
+	"\vROLE_MEMBER\x10\x00\x12\x12\n" +
+	"\x0eROLE_MODERATOR\x10\x01\x12\x0e\n" +
+	"\n" +
+	"ROLE_ADMIN\x10\x02\x12\x0e\n" +
+	"\n" +
+	"ROLE_OWNER\x10\x03*@\n" +
+	"\fMemberStatus\x12\x16\n" +
+	"\x12MEMBER_STATUS_AWAY\x10\x00\x12\x18\n" +
+	"\x14MEMBER_STATUS_ONLINE\x10\x01*\xc5\x02\n" +
+	"\rRoomEventType\x12\x1a\n" +
+	"\x16ROOM_EVENT_USER_JOINED\x10\x00\x12\x18\n" +
+	"\x14ROOM_EVENT_USER_LEFT\x10\x01\x12\x1b\n" +
+	"\x17ROOM_EVENT_ROOM_DELETED\x10\x02\x12\x16\n" +
+	"\x12ROOM_EVENT_MESSAGE\x10\x03\x12\x1b\n" +
+	"\x17ROOM_EVENT_ROOM_UPDATED\x10\x04\x12 \n" +
+	"\x1cROOM_EVENT_WAITLIST_PROMOTED\x10\x05\x12$\n" +
+	" ROOM_EVENT_OWNERSHIP_TRANSFERRED\x10\x06\x12\x1d\n" +
+	"\x19ROOM_EVENT_JOIN_REQUESTED\x10\a\x12$\n" +
+	" ROOM_EVENT_JOIN_REQUEST_RESOLVED\x10\b\x12\x1f\n" +
+	"\x1bROOM_EVENT_METADATA_CHANGED\x10\t2\xb3\x02\n" +
 	"\x0fAuthGrpcService\x129\n" +
 	"\bRegister\x12\x15.chat.RegisterRequest\x1a\x16.chat.RegisterResponse\x120\n" +
 	"\x05Login\x12\x12.chat.LoginRequest\x1a\x13.chat.LoginResponse\x12E\n" +
 	"\fRefreshToken\x12\x19.chat.RefreshTokenRequest\x1a\x1a.chat.RefreshTokenResponse\x123\n" +
 	"\x06Logout\x12\x13.chat.LogoutRequest\x1a\x14.chat.LogoutResponse\x127\n" +
-	"\tCheckAuth\x12\x16.google.protobuf.Empty\x1a\x12.chat.AuthResponse2\xd3\x03\n" +
+	"\tCheckAuth\x12\x16.google.protobuf.Empty\x1a\x12.chat.AuthResponse2\xcf\x14\n" +
 	"\x0fRoomGrpcService\x121\n" +
 	"\n" +
 	"CreateRoom\x12\x17.chat.CreateRoomRequest\x1a\n" +
-	".chat.Room\x12<\n" +
-	"\tListRooms\x12\x16.google.protobuf.Empty\x1a\x17.chat.ListRoomsResponse\x124\n" +
+	".chat.Room\x12I\n" +
+	"\x12CreateRoomTemplate\x12\x1f.chat.CreateRoomTemplateRequest\x1a\x12.chat.RoomTemplate\x12T\n" +
+	"\x11ListRoomTemplates\x12\x1e.chat.ListRoomTemplatesRequest\x1a\x1f.chat.ListRoomTemplatesResponse\x12<\n" +
+	"\tListRooms\x12\x16.chat.ListRoomsRequest\x1a\x17.chat.ListRoomsResponse\x12B\n" +
+	"\vSearchRooms\x12\x18.chat.SearchRoomsRequest\x1a\x19.chat.SearchRoomsResponse\x124\n" +
 	"\bJoinRoom\x12\x15.chat.JoinRoomRequest\x1a\x0f.chat.RoomEvent0\x01\x12;\n" +
 	"\tLeaveRoom\x12\x16.chat.LeaveRoomRequest\x1a\x16.google.protobuf.Empty\x125\n" +
 	"\fGetRoomStats\x12\f.chat.RoomID\x1a\x17.chat.RoomStatsResponse\x12+\n" +
 	"\aGetRoom\x12\x14.chat.GetRoomRequest\x1a\n" +
-	".chat.Room\x12=\n" +
+	".chat.Room\x12:\n" +
+	"\vPreviewRoom\x12\x18.chat.PreviewRoomRequest\x1a\x11.chat.RoomPreview\x12I\n" +
+	"\x0fWatchPublicRoom\x12\x1c.chat.WatchPublicRoomRequest\x1a\x16.chat.RoomPreviewEvent0\x01\x12=\n" +
+	"\n" +
+	"DeleteRoom\x12\x17.chat.DeleteRoomRequest\x1a\x16.google.protobuf.Empty\x12N\n" +
+	"\x0fListRoomMembers\x12\x1c.chat.ListRoomMembersRequest\x1a\x1d.chat.ListRoomMembersResponse\x12A\n" +
 	"\n" +
-	"DeleteRoom\x12\x17.chat.DeleteRoomRequest\x1a\x16.google.protobuf.Empty\x129\n" +
-	"\x0eGetRoomMembers\x12\x14.chat.GetRoomRequest\x1a\x11.chat.RoomMembers2\x84\x01\n" +
+	"WatchRooms\x12\x17.chat.WatchRoomsRequest\x1a\x18.chat.RoomDirectoryEvent0\x01\x12=\n" +
+	"\tSubscribe\x12\x16.chat.SubscribeRequest\x1a\x14.chat.SubscribeEvent(\x010\x01\x121\n" +
+	"\n" +
+	"UpdateRoom\x12\x17.chat.UpdateRoomRequest\x1a\n" +
+	".chat.Room\x12;\n" +
+	"\x0fGetRoomPresence\x12\x14.chat.GetRoomRequest\x1a\x12.chat.RoomPresence\x12C\n" +
+	"\x0fGetUserPresence\x12\x1c.chat.GetUserPresenceRequest\x1a\x12.chat.UserPresence\x123\n" +
+	"\vArchiveRoom\x12\x18.chat.ArchiveRoomRequest\x1a\n" +
+	".chat.Room\x127\n" +
+	"\rUnarchiveRoom\x12\x1a.chat.UnarchiveRoomRequest\x1a\n" +
+	".chat.Room\x12C\n" +
+	"\x10CreateInviteLink\x12\x1d.chat.CreateInviteLinkRequest\x1a\x10.chat.InviteLink\x12I\n" +
+	"\x10RevokeInviteLink\x12\x1d.chat.RevokeInviteLinkRequest\x1a\x16.google.protobuf.Empty\x12N\n" +
+	"\x0fListInviteLinks\x12\x1c.chat.ListInviteLinksRequest\x1a\x1d.chat.ListInviteLinksResponse\x12=\n" +
+	"\x10JoinByInviteCode\x12\x1d.chat.JoinByInviteCodeRequest\x1a\n" +
+	".chat.Room\x12F\n" +
+	"\rRequestToJoin\x12\x1a.chat.RequestToJoinRequest\x1a\x17.chat.JoinRequestUpdate0\x01\x12Q\n" +
+	"\x10ListJoinRequests\x12\x1d.chat.ListJoinRequestsRequest\x1a\x1e.chat.ListJoinRequestsResponse\x12N\n" +
+	"\x12ApproveJoinRequest\x12 .chat.JoinRequestDecisionRequest\x1a\x16.google.protobuf.Empty\x12M\n" +
+	"\x11RejectJoinRequest\x12 .chat.JoinRequestDecisionRequest\x1a\x16.google.protobuf.Empty\x12G\n" +
+	"\x0fSetRoomMetadata\x12\x1c.chat.SetRoomMetadataRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\x0fGetRoomMetadata\x12\x1c.chat.GetRoomMetadataRequest\x1a\x12.chat.RoomMetadata\x12M\n" +
+	"\x12DeleteRoomMetadata\x12\x1f.chat.DeleteRoomMetadataRequest\x1a\x16.google.protobuf.Empty\x12S\n" +
+	"\x15SetRoomMetadataPolicy\x12\".chat.SetRoomMetadataPolicyRequest\x1a\x16.google.protobuf.Empty\x12C\n" +
+	"\rSetMemberRole\x12\x1a.chat.SetMemberRoleRequest\x1a\x16.google.protobuf.Empty\x12=\n" +
+	"\n" +
+	"MuteMember\x12\x17.chat.MuteMemberRequest\x1a\x16.google.protobuf.Empty\x12A\n" +
+	"\fUnmuteMember\x12\x19.chat.UnmuteMemberRequest\x1a\x16.google.protobuf.Empty\x12?\n" +
+	"\x11TransferOwnership\x12\x1e.chat.TransferOwnershipRequest\x1a\n" +
+	".chat.Room\x12?\n" +
+	"\n" +
+	"ExportRoom\x12\x17.chat.ExportRoomRequest\x1a\x16.chat.RoomArchiveChunk0\x01\x123\n" +
+	"\n" +
+	"ImportRoom\x12\x17.chat.ImportRoomRequest\x1a\n" +
+	".chat.Room(\x01\x12K\n" +
+	"\x0eReconcileRooms\x12\x1b.chat.ReconcileRoomsRequest\x1a\x1c.chat.ReconcileRoomsResponse2\xf8\x02\n" +
+	"\x10SpaceGrpcService\x124\n" +
+	"\vCreateSpace\x12\x18.chat.CreateSpaceRequest\x1a\v.chat.Space\x120\n" +
+	"\tJoinSpace\x12\x16.chat.JoinSpaceRequest\x1a\v.chat.Space\x12E\n" +
+	"\x0eAddSpaceMember\x12\x1b.chat.AddSpaceMemberRequest\x1a\x16.google.protobuf.Empty\x129\n" +
+	"\x0eAddRoomToSpace\x12\x1b.chat.AddRoomToSpaceRequest\x1a\n" +
+	".chat.Room\x12-\n" +
+	"\bMoveRoom\x12\x15.chat.MoveRoomRequest\x1a\n" +
+	".chat.Room\x12K\n" +
+	"\x0eListSpaceRooms\x12\x1b.chat.ListSpaceRoomsRequest\x1a\x1c.chat.ListSpaceRoomsResponse2\x84\x01\n" +
 	"\x12MessageGrpcService\x129\n" +
 	"\vSendMessage\x12\x18.chat.SendMessageRequest\x1a\x10.chat.MessageAck\x123\n" +
 	"\x0eStreamMessages\x12\f.chat.RoomID\x1a\x11.chat.ChatMessage0\x01B,Z*github.com/assu-2000/StreamRPC/internal/pbb\x06proto3"
@@ -1652,82 +7114,321 @@ func file_internal_pb_server_proto_rawDescGZIP() []byte {
 	return file_internal_pb_server_proto_rawDescData
 }
 
-var file_internal_pb_server_proto_msgTypes = make([]protoimpl.MessageInfo, 28)
+var file_internal_pb_server_proto_enumTypes = make([]protoimpl.EnumInfo, 5)
+var file_internal_pb_server_proto_msgTypes = make([]protoimpl.MessageInfo, 106)
 var file_internal_pb_server_proto_goTypes = []any{
-	(*LoginRequest)(nil),          // 0: chat.LoginRequest
-	(*LoginResponse)(nil),         // 1: chat.LoginResponse
-	(*RegisterRequest)(nil),       // 2: chat.RegisterRequest
-	(*RegisterResponse)(nil),      // 3: chat.RegisterResponse
-	(*RefreshTokenRequest)(nil),   // 4: chat.RefreshTokenRequest
-	(*RefreshTokenResponse)(nil),  // 5: chat.RefreshTokenResponse
-	(*LogoutRequest)(nil),         // 6: chat.LogoutRequest
-	(*LogoutResponse)(nil),        // 7: chat.LogoutResponse
-	(*AuthResponse)(nil),          // 8: chat.AuthResponse
-	(*ClientMessage)(nil),         // 9: chat.ClientMessage
-	(*ServerMessage)(nil),         // 10: chat.ServerMessage
-	(*CreateRoomRequest)(nil),     // 11: chat.CreateRoomRequest
-	(*JoinRoomRequest)(nil),       // 12: chat.JoinRoomRequest
-	(*LeaveRoomRequest)(nil),      // 13: chat.LeaveRoomRequest
-	(*GetRoomRequest)(nil),        // 14: chat.GetRoomRequest
-	(*DeleteRoomRequest)(nil),     // 15: chat.DeleteRoomRequest
-	(*Room)(nil),                  // 16: chat.Room
-	(*ListRoomsResponse)(nil),     // 17: chat.ListRoomsResponse
-	(*RoomMembers)(nil),           // 18: chat.RoomMembers
-	(*RoomID)(nil),                // 19: chat.RoomID
-	(*RoomEvent)(nil),             // 20: chat.RoomEvent
-	(*UserJoined)(nil),            // 21: chat.UserJoined
-	(*UserLeft)(nil),              // 22: chat.UserLeft
-	(*RoomDeleted)(nil),           // 23: chat.RoomDeleted
-	(*RoomStatsResponse)(nil),     // 24: chat.RoomStatsResponse
-	(*SendMessageRequest)(nil),    // 25: chat.SendMessageRequest
-	(*ChatMessage)(nil),           // 26: chat.ChatMessage
-	(*MessageAck)(nil),            // 27: chat.MessageAck
-	(*timestamppb.Timestamp)(nil), // 28: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 29: google.protobuf.Empty
+	(JoinRequestState)(0),                // 0: chat.JoinRequestState
+	(InconsistencyKind)(0),               // 1: chat.InconsistencyKind
+	(MemberRole)(0),                      // 2: chat.MemberRole
+	(MemberStatus)(0),                    // 3: chat.MemberStatus
+	(RoomEventType)(0),                   // 4: chat.RoomEventType
+	(*LoginRequest)(nil),                 // 5: chat.LoginRequest
+	(*LoginResponse)(nil),                // 6: chat.LoginResponse
+	(*RegisterRequest)(nil),              // 7: chat.RegisterRequest
+	(*RegisterResponse)(nil),             // 8: chat.RegisterResponse
+	(*RefreshTokenRequest)(nil),          // 9: chat.RefreshTokenRequest
+	(*RefreshTokenResponse)(nil),         // 10: chat.RefreshTokenResponse
+	(*LogoutRequest)(nil),                // 11: chat.LogoutRequest
+	(*LogoutResponse)(nil),               // 12: chat.LogoutResponse
+	(*AuthResponse)(nil),                 // 13: chat.AuthResponse
+	(*ClientMessage)(nil),                // 14: chat.ClientMessage
+	(*ServerMessage)(nil),                // 15: chat.ServerMessage
+	(*CreateRoomRequest)(nil),            // 16: chat.CreateRoomRequest
+	(*RoomTemplate)(nil),                 // 17: chat.RoomTemplate
+	(*TemplateMetadata)(nil),             // 18: chat.TemplateMetadata
+	(*CreateRoomTemplateRequest)(nil),    // 19: chat.CreateRoomTemplateRequest
+	(*ListRoomTemplatesRequest)(nil),     // 20: chat.ListRoomTemplatesRequest
+	(*ListRoomTemplatesResponse)(nil),    // 21: chat.ListRoomTemplatesResponse
+	(*JoinRoomRequest)(nil),              // 22: chat.JoinRoomRequest
+	(*LeaveRoomRequest)(nil),             // 23: chat.LeaveRoomRequest
+	(*GetRoomRequest)(nil),               // 24: chat.GetRoomRequest
+	(*DeleteRoomRequest)(nil),            // 25: chat.DeleteRoomRequest
+	(*ArchiveRoomRequest)(nil),           // 26: chat.ArchiveRoomRequest
+	(*UnarchiveRoomRequest)(nil),         // 27: chat.UnarchiveRoomRequest
+	(*InviteLink)(nil),                   // 28: chat.InviteLink
+	(*CreateInviteLinkRequest)(nil),      // 29: chat.CreateInviteLinkRequest
+	(*RevokeInviteLinkRequest)(nil),      // 30: chat.RevokeInviteLinkRequest
+	(*ListInviteLinksRequest)(nil),       // 31: chat.ListInviteLinksRequest
+	(*ListInviteLinksResponse)(nil),      // 32: chat.ListInviteLinksResponse
+	(*JoinByInviteCodeRequest)(nil),      // 33: chat.JoinByInviteCodeRequest
+	(*RequestToJoinRequest)(nil),         // 34: chat.RequestToJoinRequest
+	(*JoinRequest)(nil),                  // 35: chat.JoinRequest
+	(*JoinRequestUpdate)(nil),            // 36: chat.JoinRequestUpdate
+	(*ListJoinRequestsRequest)(nil),      // 37: chat.ListJoinRequestsRequest
+	(*ListJoinRequestsResponse)(nil),     // 38: chat.ListJoinRequestsResponse
+	(*SetRoomMetadataRequest)(nil),       // 39: chat.SetRoomMetadataRequest
+	(*GetRoomMetadataRequest)(nil),       // 40: chat.GetRoomMetadataRequest
+	(*RoomMetadata)(nil),                 // 41: chat.RoomMetadata
+	(*DeleteRoomMetadataRequest)(nil),    // 42: chat.DeleteRoomMetadataRequest
+	(*SetRoomMetadataPolicyRequest)(nil), // 43: chat.SetRoomMetadataPolicyRequest
+	(*ReconcileRoomsRequest)(nil),        // 44: chat.ReconcileRoomsRequest
+	(*RoomInconsistency)(nil),            // 45: chat.RoomInconsistency
+	(*ReconcileRoomsResponse)(nil),       // 46: chat.ReconcileRoomsResponse
+	(*JoinRequestDecisionRequest)(nil),   // 47: chat.JoinRequestDecisionRequest
+	(*UpdateRoomRequest)(nil),            // 48: chat.UpdateRoomRequest
+	(*Room)(nil),                         // 49: chat.Room
+	(*MuteMemberRequest)(nil),            // 50: chat.MuteMemberRequest
+	(*UnmuteMemberRequest)(nil),          // 51: chat.UnmuteMemberRequest
+	(*SetMemberRoleRequest)(nil),         // 52: chat.SetMemberRoleRequest
+	(*TransferOwnershipRequest)(nil),     // 53: chat.TransferOwnershipRequest
+	(*ExportRoomRequest)(nil),            // 54: chat.ExportRoomRequest
+	(*RoomArchiveChunk)(nil),             // 55: chat.RoomArchiveChunk
+	(*ImportRoomRequest)(nil),            // 56: chat.ImportRoomRequest
+	(*ImportRoomOptions)(nil),            // 57: chat.ImportRoomOptions
+	(*Space)(nil),                        // 58: chat.Space
+	(*SpaceCategory)(nil),                // 59: chat.SpaceCategory
+	(*CreateSpaceRequest)(nil),           // 60: chat.CreateSpaceRequest
+	(*JoinSpaceRequest)(nil),             // 61: chat.JoinSpaceRequest
+	(*AddSpaceMemberRequest)(nil),        // 62: chat.AddSpaceMemberRequest
+	(*AddRoomToSpaceRequest)(nil),        // 63: chat.AddRoomToSpaceRequest
+	(*MoveRoomRequest)(nil),              // 64: chat.MoveRoomRequest
+	(*ListSpaceRoomsRequest)(nil),        // 65: chat.ListSpaceRoomsRequest
+	(*ListSpaceRoomsResponse)(nil),       // 66: chat.ListSpaceRoomsResponse
+	(*ListRoomsRequest)(nil),             // 67: chat.ListRoomsRequest
+	(*RoomFilter)(nil),                   // 68: chat.RoomFilter
+	(*ListRoomsResponse)(nil),            // 69: chat.ListRoomsResponse
+	(*SearchRoomsRequest)(nil),           // 70: chat.SearchRoomsRequest
+	(*SearchRoomsResponse)(nil),          // 71: chat.SearchRoomsResponse
+	(*RoomSearchResult)(nil),             // 72: chat.RoomSearchResult
+	(*WatchRoomsRequest)(nil),            // 73: chat.WatchRoomsRequest
+	(*RoomDirectoryEvent)(nil),           // 74: chat.RoomDirectoryEvent
+	(*RoomDirectorySnapshot)(nil),        // 75: chat.RoomDirectorySnapshot
+	(*MemberCountChanged)(nil),           // 76: chat.MemberCountChanged
+	(*ListRoomMembersRequest)(nil),       // 77: chat.ListRoomMembersRequest
+	(*ListRoomMembersResponse)(nil),      // 78: chat.ListRoomMembersResponse
+	(*MemberInfo)(nil),                   // 79: chat.MemberInfo
+	(*RoomPresence)(nil),                 // 80: chat.RoomPresence
+	(*UserPresence)(nil),                 // 81: chat.UserPresence
+	(*PresenceSession)(nil),              // 82: chat.PresenceSession
+	(*GetUserPresenceRequest)(nil),       // 83: chat.GetUserPresenceRequest
+	(*RoomID)(nil),                       // 84: chat.RoomID
+	(*RoomEvent)(nil),                    // 85: chat.RoomEvent
+	(*UserJoined)(nil),                   // 86: chat.UserJoined
+	(*UserLeft)(nil),                     // 87: chat.UserLeft
+	(*PreviewRoomRequest)(nil),           // 88: chat.PreviewRoomRequest
+	(*RoomPreview)(nil),                  // 89: chat.RoomPreview
+	(*WatchPublicRoomRequest)(nil),       // 90: chat.WatchPublicRoomRequest
+	(*RoomPreviewEvent)(nil),             // 91: chat.RoomPreviewEvent
+	(*SubscribeRequest)(nil),             // 92: chat.SubscribeRequest
+	(*RoomEventFilter)(nil),              // 93: chat.RoomEventFilter
+	(*SubscribeEvent)(nil),               // 94: chat.SubscribeEvent
+	(*RoomRemoved)(nil),                  // 95: chat.RoomRemoved
+	(*RoomDeleted)(nil),                  // 96: chat.RoomDeleted
+	(*Waitlisted)(nil),                   // 97: chat.Waitlisted
+	(*WaitlistPromoted)(nil),             // 98: chat.WaitlistPromoted
+	(*OwnershipTransferred)(nil),         // 99: chat.OwnershipTransferred
+	(*RoomMetadataChanged)(nil),          // 100: chat.RoomMetadataChanged
+	(*JoinRequestResolved)(nil),          // 101: chat.JoinRequestResolved
+	(*RoomUpdated)(nil),                  // 102: chat.RoomUpdated
+	(*RoomStatsResponse)(nil),            // 103: chat.RoomStatsResponse
+	(*SendMessageRequest)(nil),           // 104: chat.SendMessageRequest
+	(*ChatMessage)(nil),                  // 105: chat.ChatMessage
+	(*MessageAck)(nil),                   // 106: chat.MessageAck
+	nil,                                  // 107: chat.RoomTemplate.DefaultRolesEntry
+	nil,                                  // 108: chat.TemplateMetadata.ValuesEntry
+	nil,                                  // 109: chat.RoomMetadata.ValuesEntry
+	nil,                                  // 110: chat.ImportRoomOptions.UserIdMapEntry
+	(*durationpb.Duration)(nil),          // 111: google.protobuf.Duration
+	(*timestamppb.Timestamp)(nil),        // 112: google.protobuf.Timestamp
+	(*fieldmaskpb.FieldMask)(nil),        // 113: google.protobuf.FieldMask
+	(*emptypb.Empty)(nil),                // 114: google.protobuf.Empty
 }
 var file_internal_pb_server_proto_depIdxs = []int32{
-	28, // 0: chat.Room.created_at:type_name -> google.protobuf.Timestamp
-	16, // 1: chat.ListRoomsResponse.rooms:type_name -> chat.Room
-	21, // 2: chat.RoomEvent.user_joined:type_name -> chat.UserJoined
-	22, // 3: chat.RoomEvent.user_left:type_name -> chat.UserLeft
-	23, // 4: chat.RoomEvent.room_deleted:type_name -> chat.RoomDeleted
-	16, // 5: chat.RoomStatsResponse.room:type_name -> chat.Room
-	28, // 6: chat.RoomStatsResponse.last_activity:type_name -> google.protobuf.Timestamp
-	2,  // 7: chat.AuthGrpcService.Register:input_type -> chat.RegisterRequest
-	0,  // 8: chat.AuthGrpcService.Login:input_type -> chat.LoginRequest
-	4,  // 9: chat.AuthGrpcService.RefreshToken:input_type -> chat.RefreshTokenRequest
-	6,  // 10: chat.AuthGrpcService.Logout:input_type -> chat.LogoutRequest
-	29, // 11: chat.AuthGrpcService.CheckAuth:input_type -> google.protobuf.Empty
-	11, // 12: chat.RoomGrpcService.CreateRoom:input_type -> chat.CreateRoomRequest
-	29, // 13: chat.RoomGrpcService.ListRooms:input_type -> google.protobuf.Empty
-	12, // 14: chat.RoomGrpcService.JoinRoom:input_type -> chat.JoinRoomRequest
-	13, // 15: chat.RoomGrpcService.LeaveRoom:input_type -> chat.LeaveRoomRequest
-	19, // 16: chat.RoomGrpcService.GetRoomStats:input_type -> chat.RoomID
-	14, // 17: chat.RoomGrpcService.GetRoom:input_type -> chat.GetRoomRequest
-	15, // 18: chat.RoomGrpcService.DeleteRoom:input_type -> chat.DeleteRoomRequest
-	14, // 19: chat.RoomGrpcService.GetRoomMembers:input_type -> chat.GetRoomRequest
-	25, // 20: chat.MessageGrpcService.SendMessage:input_type -> chat.SendMessageRequest
-	19, // 21: chat.MessageGrpcService.StreamMessages:input_type -> chat.RoomID
-	3,  // 22: chat.AuthGrpcService.Register:output_type -> chat.RegisterResponse
-	1,  // 23: chat.AuthGrpcService.Login:output_type -> chat.LoginResponse
-	5,  // 24: chat.AuthGrpcService.RefreshToken:output_type -> chat.RefreshTokenResponse
-	7,  // 25: chat.AuthGrpcService.Logout:output_type -> chat.LogoutResponse
-	8,  // 26: chat.AuthGrpcService.CheckAuth:output_type -> chat.AuthResponse
-	16, // 27: chat.RoomGrpcService.CreateRoom:output_type -> chat.Room
-	17, // 28: chat.RoomGrpcService.ListRooms:output_type -> chat.ListRoomsResponse
-	20, // 29: chat.RoomGrpcService.JoinRoom:output_type -> chat.RoomEvent
-	29, // 30: chat.RoomGrpcService.LeaveRoom:output_type -> google.protobuf.Empty
-	24, // 31: chat.RoomGrpcService.GetRoomStats:output_type -> chat.RoomStatsResponse
-	16, // 32: chat.RoomGrpcService.GetRoom:output_type -> chat.Room
-	29, // 33: chat.RoomGrpcService.DeleteRoom:output_type -> google.protobuf.Empty
-	18, // 34: chat.RoomGrpcService.GetRoomMembers:output_type -> chat.RoomMembers
-	27, // 35: chat.MessageGrpcService.SendMessage:output_type -> chat.MessageAck
-	26, // 36: chat.MessageGrpcService.StreamMessages:output_type -> chat.ChatMessage
-	22, // [22:37] is the sub-list for method output_type
-	7,  // [7:22] is the sub-list for method input_type
-	7,  // [7:7] is the sub-list for extension type_name
-	7,  // [7:7] is the sub-list for extension extendee
-	0,  // [0:7] is the sub-list for field type_name
+	107, // 0: chat.RoomTemplate.default_roles:type_name -> chat.RoomTemplate.DefaultRolesEntry
+	111, // 1: chat.RoomTemplate.message_retention:type_name -> google.protobuf.Duration
+	18,  // 2: chat.RoomTemplate.metadata:type_name -> chat.TemplateMetadata
+	112, // 3: chat.RoomTemplate.created_at:type_name -> google.protobuf.Timestamp
+	108, // 4: chat.TemplateMetadata.values:type_name -> chat.TemplateMetadata.ValuesEntry
+	17,  // 5: chat.CreateRoomTemplateRequest.template:type_name -> chat.RoomTemplate
+	17,  // 6: chat.ListRoomTemplatesResponse.templates:type_name -> chat.RoomTemplate
+	112, // 7: chat.InviteLink.created_at:type_name -> google.protobuf.Timestamp
+	112, // 8: chat.InviteLink.expires_at:type_name -> google.protobuf.Timestamp
+	112, // 9: chat.CreateInviteLinkRequest.expires_at:type_name -> google.protobuf.Timestamp
+	28,  // 10: chat.ListInviteLinksResponse.links:type_name -> chat.InviteLink
+	112, // 11: chat.JoinRequest.requested_at:type_name -> google.protobuf.Timestamp
+	35,  // 12: chat.JoinRequestUpdate.request:type_name -> chat.JoinRequest
+	0,   // 13: chat.JoinRequestUpdate.state:type_name -> chat.JoinRequestState
+	35,  // 14: chat.ListJoinRequestsResponse.requests:type_name -> chat.JoinRequest
+	109, // 15: chat.RoomMetadata.values:type_name -> chat.RoomMetadata.ValuesEntry
+	2,   // 16: chat.SetRoomMetadataPolicyRequest.read_role:type_name -> chat.MemberRole
+	2,   // 17: chat.SetRoomMetadataPolicyRequest.write_role:type_name -> chat.MemberRole
+	1,   // 18: chat.RoomInconsistency.kind:type_name -> chat.InconsistencyKind
+	45,  // 19: chat.ReconcileRoomsResponse.inconsistencies:type_name -> chat.RoomInconsistency
+	49,  // 20: chat.UpdateRoomRequest.room:type_name -> chat.Room
+	113, // 21: chat.UpdateRoomRequest.update_mask:type_name -> google.protobuf.FieldMask
+	112, // 22: chat.Room.created_at:type_name -> google.protobuf.Timestamp
+	112, // 23: chat.Room.last_activity:type_name -> google.protobuf.Timestamp
+	112, // 24: chat.Room.archived_at:type_name -> google.protobuf.Timestamp
+	112, // 25: chat.Room.purge_at:type_name -> google.protobuf.Timestamp
+	111, // 26: chat.Room.slow_mode_interval:type_name -> google.protobuf.Duration
+	111, // 27: chat.Room.message_retention:type_name -> google.protobuf.Duration
+	105, // 28: chat.Room.pinned_message:type_name -> chat.ChatMessage
+	112, // 29: chat.MuteMemberRequest.expires_at:type_name -> google.protobuf.Timestamp
+	2,   // 30: chat.SetMemberRoleRequest.role:type_name -> chat.MemberRole
+	57,  // 31: chat.ImportRoomRequest.options:type_name -> chat.ImportRoomOptions
+	55,  // 32: chat.ImportRoomRequest.chunk:type_name -> chat.RoomArchiveChunk
+	110, // 33: chat.ImportRoomOptions.user_id_map:type_name -> chat.ImportRoomOptions.UserIdMapEntry
+	112, // 34: chat.Space.created_at:type_name -> google.protobuf.Timestamp
+	49,  // 35: chat.SpaceCategory.rooms:type_name -> chat.Room
+	58,  // 36: chat.ListSpaceRoomsResponse.space:type_name -> chat.Space
+	59,  // 37: chat.ListSpaceRoomsResponse.categories:type_name -> chat.SpaceCategory
+	68,  // 38: chat.ListRoomsRequest.filter:type_name -> chat.RoomFilter
+	49,  // 39: chat.ListRoomsResponse.rooms:type_name -> chat.Room
+	72,  // 40: chat.SearchRoomsResponse.results:type_name -> chat.RoomSearchResult
+	49,  // 41: chat.RoomSearchResult.room:type_name -> chat.Room
+	75,  // 42: chat.RoomDirectoryEvent.snapshot:type_name -> chat.RoomDirectorySnapshot
+	49,  // 43: chat.RoomDirectoryEvent.room_created:type_name -> chat.Room
+	49,  // 44: chat.RoomDirectoryEvent.room_updated:type_name -> chat.Room
+	96,  // 45: chat.RoomDirectoryEvent.room_deleted:type_name -> chat.RoomDeleted
+	76,  // 46: chat.RoomDirectoryEvent.member_count_changed:type_name -> chat.MemberCountChanged
+	114, // 47: chat.RoomDirectoryEvent.room_hidden:type_name -> google.protobuf.Empty
+	49,  // 48: chat.RoomDirectorySnapshot.rooms:type_name -> chat.Room
+	2,   // 49: chat.ListRoomMembersRequest.role:type_name -> chat.MemberRole
+	3,   // 50: chat.ListRoomMembersRequest.status:type_name -> chat.MemberStatus
+	79,  // 51: chat.ListRoomMembersResponse.members:type_name -> chat.MemberInfo
+	2,   // 52: chat.MemberInfo.role:type_name -> chat.MemberRole
+	112, // 53: chat.MemberInfo.muted_until:type_name -> google.protobuf.Timestamp
+	3,   // 54: chat.MemberInfo.status:type_name -> chat.MemberStatus
+	112, // 55: chat.MemberInfo.joined_at:type_name -> google.protobuf.Timestamp
+	81,  // 56: chat.RoomPresence.users:type_name -> chat.UserPresence
+	82,  // 57: chat.UserPresence.sessions:type_name -> chat.PresenceSession
+	112, // 58: chat.PresenceSession.expires_at:type_name -> google.protobuf.Timestamp
+	86,  // 59: chat.RoomEvent.user_joined:type_name -> chat.UserJoined
+	87,  // 60: chat.RoomEvent.user_left:type_name -> chat.UserLeft
+	96,  // 61: chat.RoomEvent.room_deleted:type_name -> chat.RoomDeleted
+	102, // 62: chat.RoomEvent.room_updated:type_name -> chat.RoomUpdated
+	97,  // 63: chat.RoomEvent.waitlisted:type_name -> chat.Waitlisted
+	98,  // 64: chat.RoomEvent.waitlist_promoted:type_name -> chat.WaitlistPromoted
+	99,  // 65: chat.RoomEvent.ownership_transferred:type_name -> chat.OwnershipTransferred
+	35,  // 66: chat.RoomEvent.join_requested:type_name -> chat.JoinRequest
+	101, // 67: chat.RoomEvent.join_request_resolved:type_name -> chat.JoinRequestResolved
+	100, // 68: chat.RoomEvent.metadata_changed:type_name -> chat.RoomMetadataChanged
+	49,  // 69: chat.RoomPreview.room:type_name -> chat.Room
+	105, // 70: chat.RoomPreview.messages:type_name -> chat.ChatMessage
+	105, // 71: chat.RoomPreviewEvent.message:type_name -> chat.ChatMessage
+	102, // 72: chat.RoomPreviewEvent.room_updated:type_name -> chat.RoomUpdated
+	96,  // 73: chat.RoomPreviewEvent.room_deleted:type_name -> chat.RoomDeleted
+	93,  // 74: chat.SubscribeRequest.filters:type_name -> chat.RoomEventFilter
+	4,   // 75: chat.RoomEventFilter.types:type_name -> chat.RoomEventType
+	49,  // 76: chat.SubscribeEvent.room_added:type_name -> chat.Room
+	95,  // 77: chat.SubscribeEvent.room_removed:type_name -> chat.RoomRemoved
+	85,  // 78: chat.SubscribeEvent.room_event:type_name -> chat.RoomEvent
+	105, // 79: chat.SubscribeEvent.message:type_name -> chat.ChatMessage
+	101, // 80: chat.SubscribeEvent.join_request_resolved:type_name -> chat.JoinRequestResolved
+	96,  // 81: chat.RoomRemoved.deleted:type_name -> chat.RoomDeleted
+	49,  // 82: chat.RoomUpdated.room:type_name -> chat.Room
+	49,  // 83: chat.RoomStatsResponse.room:type_name -> chat.Room
+	112, // 84: chat.RoomStatsResponse.last_activity:type_name -> google.protobuf.Timestamp
+	2,   // 85: chat.RoomTemplate.DefaultRolesEntry.value:type_name -> chat.MemberRole
+	7,   // 86: chat.AuthGrpcService.Register:input_type -> chat.RegisterRequest
+	5,   // 87: chat.AuthGrpcService.Login:input_type -> chat.LoginRequest
+	9,   // 88: chat.AuthGrpcService.RefreshToken:input_type -> chat.RefreshTokenRequest
+	11,  // 89: chat.AuthGrpcService.Logout:input_type -> chat.LogoutRequest
+	114, // 90: chat.AuthGrpcService.CheckAuth:input_type -> google.protobuf.Empty
+	16,  // 91: chat.RoomGrpcService.CreateRoom:input_type -> chat.CreateRoomRequest
+	19,  // 92: chat.RoomGrpcService.CreateRoomTemplate:input_type -> chat.CreateRoomTemplateRequest
+	20,  // 93: chat.RoomGrpcService.ListRoomTemplates:input_type -> chat.ListRoomTemplatesRequest
+	67,  // 94: chat.RoomGrpcService.ListRooms:input_type -> chat.ListRoomsRequest
+	70,  // 95: chat.RoomGrpcService.SearchRooms:input_type -> chat.SearchRoomsRequest
+	22,  // 96: chat.RoomGrpcService.JoinRoom:input_type -> chat.JoinRoomRequest
+	23,  // 97: chat.RoomGrpcService.LeaveRoom:input_type -> chat.LeaveRoomRequest
+	84,  // 98: chat.RoomGrpcService.GetRoomStats:input_type -> chat.RoomID
+	24,  // 99: chat.RoomGrpcService.GetRoom:input_type -> chat.GetRoomRequest
+	88,  // 100: chat.RoomGrpcService.PreviewRoom:input_type -> chat.PreviewRoomRequest
+	90,  // 101: chat.RoomGrpcService.WatchPublicRoom:input_type -> chat.WatchPublicRoomRequest
+	25,  // 102: chat.RoomGrpcService.DeleteRoom:input_type -> chat.DeleteRoomRequest
+	77,  // 103: chat.RoomGrpcService.ListRoomMembers:input_type -> chat.ListRoomMembersRequest
+	73,  // 104: chat.RoomGrpcService.WatchRooms:input_type -> chat.WatchRoomsRequest
+	92,  // 105: chat.RoomGrpcService.Subscribe:input_type -> chat.SubscribeRequest
+	48,  // 106: chat.RoomGrpcService.UpdateRoom:input_type -> chat.UpdateRoomRequest
+	24,  // 107: chat.RoomGrpcService.GetRoomPresence:input_type -> chat.GetRoomRequest
+	83,  // 108: chat.RoomGrpcService.GetUserPresence:input_type -> chat.GetUserPresenceRequest
+	26,  // 109: chat.RoomGrpcService.ArchiveRoom:input_type -> chat.ArchiveRoomRequest
+	27,  // 110: chat.RoomGrpcService.UnarchiveRoom:input_type -> chat.UnarchiveRoomRequest
+	29,  // 111: chat.RoomGrpcService.CreateInviteLink:input_type -> chat.CreateInviteLinkRequest
+	30,  // 112: chat.RoomGrpcService.RevokeInviteLink:input_type -> chat.RevokeInviteLinkRequest
+	31,  // 113: chat.RoomGrpcService.ListInviteLinks:input_type -> chat.ListInviteLinksRequest
+	33,  // 114: chat.RoomGrpcService.JoinByInviteCode:input_type -> chat.JoinByInviteCodeRequest
+	34,  // 115: chat.RoomGrpcService.RequestToJoin:input_type -> chat.RequestToJoinRequest
+	37,  // 116: chat.RoomGrpcService.ListJoinRequests:input_type -> chat.ListJoinRequestsRequest
+	47,  // 117: chat.RoomGrpcService.ApproveJoinRequest:input_type -> chat.JoinRequestDecisionRequest
+	47,  // 118: chat.RoomGrpcService.RejectJoinRequest:input_type -> chat.JoinRequestDecisionRequest
+	39,  // 119: chat.RoomGrpcService.SetRoomMetadata:input_type -> chat.SetRoomMetadataRequest
+	40,  // 120: chat.RoomGrpcService.GetRoomMetadata:input_type -> chat.GetRoomMetadataRequest
+	42,  // 121: chat.RoomGrpcService.DeleteRoomMetadata:input_type -> chat.DeleteRoomMetadataRequest
+	43,  // 122: chat.RoomGrpcService.SetRoomMetadataPolicy:input_type -> chat.SetRoomMetadataPolicyRequest
+	52,  // 123: chat.RoomGrpcService.SetMemberRole:input_type -> chat.SetMemberRoleRequest
+	50,  // 124: chat.RoomGrpcService.MuteMember:input_type -> chat.MuteMemberRequest
+	51,  // 125: chat.RoomGrpcService.UnmuteMember:input_type -> chat.UnmuteMemberRequest
+	53,  // 126: chat.RoomGrpcService.TransferOwnership:input_type -> chat.TransferOwnershipRequest
+	54,  // 127: chat.RoomGrpcService.ExportRoom:input_type -> chat.ExportRoomRequest
+	56,  // 128: chat.RoomGrpcService.ImportRoom:input_type -> chat.ImportRoomRequest
+	44,  // 129: chat.RoomGrpcService.ReconcileRooms:input_type -> chat.ReconcileRoomsRequest
+	60,  // 130: chat.SpaceGrpcService.CreateSpace:input_type -> chat.CreateSpaceRequest
+	61,  // 131: chat.SpaceGrpcService.JoinSpace:input_type -> chat.JoinSpaceRequest
+	62,  // 132: chat.SpaceGrpcService.AddSpaceMember:input_type -> chat.AddSpaceMemberRequest
+	63,  // 133: chat.SpaceGrpcService.AddRoomToSpace:input_type -> chat.AddRoomToSpaceRequest
+	64,  // 134: chat.SpaceGrpcService.MoveRoom:input_type -> chat.MoveRoomRequest
+	65,  // 135: chat.SpaceGrpcService.ListSpaceRooms:input_type -> chat.ListSpaceRoomsRequest
+	104, // 136: chat.MessageGrpcService.SendMessage:input_type -> chat.SendMessageRequest
+	84,  // 137: chat.MessageGrpcService.StreamMessages:input_type -> chat.RoomID
+	8,   // 138: chat.AuthGrpcService.Register:output_type -> chat.RegisterResponse
+	6,   // 139: chat.AuthGrpcService.Login:output_type -> chat.LoginResponse
+	10,  // 140: chat.AuthGrpcService.RefreshToken:output_type -> chat.RefreshTokenResponse
+	12,  // 141: chat.AuthGrpcService.Logout:output_type -> chat.LogoutResponse
+	13,  // 142: chat.AuthGrpcService.CheckAuth:output_type -> chat.AuthResponse
+	49,  // 143: chat.RoomGrpcService.CreateRoom:output_type -> chat.Room
+	17,  // 144: chat.RoomGrpcService.CreateRoomTemplate:output_type -> chat.RoomTemplate
+	21,  // 145: chat.RoomGrpcService.ListRoomTemplates:output_type -> chat.ListRoomTemplatesResponse
+	69,  // 146: chat.RoomGrpcService.ListRooms:output_type -> chat.ListRoomsResponse
+	71,  // 147: chat.RoomGrpcService.SearchRooms:output_type -> chat.SearchRoomsResponse
+	85,  // 148: chat.RoomGrpcService.JoinRoom:output_type -> chat.RoomEvent
+	114, // 149: chat.RoomGrpcService.LeaveRoom:output_type -> google.protobuf.Empty
+	103, // 150: chat.RoomGrpcService.GetRoomStats:output_type -> chat.RoomStatsResponse
+	49,  // 151: chat.RoomGrpcService.GetRoom:output_type -> chat.Room
+	89,  // 152: chat.RoomGrpcService.PreviewRoom:output_type -> chat.RoomPreview
+	91,  // 153: chat.RoomGrpcService.WatchPublicRoom:output_type -> chat.RoomPreviewEvent
+	114, // 154: chat.RoomGrpcService.DeleteRoom:output_type -> google.protobuf.Empty
+	78,  // 155: chat.RoomGrpcService.ListRoomMembers:output_type -> chat.ListRoomMembersResponse
+	74,  // 156: chat.RoomGrpcService.WatchRooms:output_type -> chat.RoomDirectoryEvent
+	94,  // 157: chat.RoomGrpcService.Subscribe:output_type -> chat.SubscribeEvent
+	49,  // 158: chat.RoomGrpcService.UpdateRoom:output_type -> chat.Room
+	80,  // 159: chat.RoomGrpcService.GetRoomPresence:output_type -> chat.RoomPresence
+	81,  // 160: chat.RoomGrpcService.GetUserPresence:output_type -> chat.UserPresence
+	49,  // 161: chat.RoomGrpcService.ArchiveRoom:output_type -> chat.Room
+	49,  // 162: chat.RoomGrpcService.UnarchiveRoom:output_type -> chat.Room
+	28,  // 163: chat.RoomGrpcService.CreateInviteLink:output_type -> chat.InviteLink
+	114, // 164: chat.RoomGrpcService.RevokeInviteLink:output_type -> google.protobuf.Empty
+	32,  // 165: chat.RoomGrpcService.ListInviteLinks:output_type -> chat.ListInviteLinksResponse
+	49,  // 166: chat.RoomGrpcService.JoinByInviteCode:output_type -> chat.Room
+	36,  // 167: chat.RoomGrpcService.RequestToJoin:output_type -> chat.JoinRequestUpdate
+	38,  // 168: chat.RoomGrpcService.ListJoinRequests:output_type -> chat.ListJoinRequestsResponse
+	114, // 169: chat.RoomGrpcService.ApproveJoinRequest:output_type -> google.protobuf.Empty
+	114, // 170: chat.RoomGrpcService.RejectJoinRequest:output_type -> google.protobuf.Empty
+	114, // 171: chat.RoomGrpcService.SetRoomMetadata:output_type -> google.protobuf.Empty
+	41,  // 172: chat.RoomGrpcService.GetRoomMetadata:output_type -> chat.RoomMetadata
+	114, // 173: chat.RoomGrpcService.DeleteRoomMetadata:output_type -> google.protobuf.Empty
+	114, // 174: chat.RoomGrpcService.SetRoomMetadataPolicy:output_type -> google.protobuf.Empty
+	114, // 175: chat.RoomGrpcService.SetMemberRole:output_type -> google.protobuf.Empty
+	114, // 176: chat.RoomGrpcService.MuteMember:output_type -> google.protobuf.Empty
+	114, // 177: chat.RoomGrpcService.UnmuteMember:output_type -> google.protobuf.Empty
+	49,  // 178: chat.RoomGrpcService.TransferOwnership:output_type -> chat.Room
+	55,  // 179: chat.RoomGrpcService.ExportRoom:output_type -> chat.RoomArchiveChunk
+	49,  // 180: chat.RoomGrpcService.ImportRoom:output_type -> chat.Room
+	46,  // 181: chat.RoomGrpcService.ReconcileRooms:output_type -> chat.ReconcileRoomsResponse
+	58,  // 182: chat.SpaceGrpcService.CreateSpace:output_type -> chat.Space
+	58,  // 183: chat.SpaceGrpcService.JoinSpace:output_type -> chat.Space
+	114, // 184: chat.SpaceGrpcService.AddSpaceMember:output_type -> google.protobuf.Empty
+	49,  // 185: chat.SpaceGrpcService.AddRoomToSpace:output_type -> chat.Room
+	49,  // 186: chat.SpaceGrpcService.MoveRoom:output_type -> chat.Room
+	66,  // 187: chat.SpaceGrpcService.ListSpaceRooms:output_type -> chat.ListSpaceRoomsResponse
+	106, // 188: chat.MessageGrpcService.SendMessage:output_type -> chat.MessageAck
+	105, // 189: chat.MessageGrpcService.StreamMessages:output_type -> chat.ChatMessage
+	138, // [138:190] is the sub-list for method output_type
+	86,  // [86:138] is the sub-list for method input_type
+	86,  // [86:86] is the sub-list for extension type_name
+	86,  // [86:86] is the sub-list for extension extendee
+	0,   // [0:86] is the sub-list for field type_name
 }
 
 func init() { file_internal_pb_server_proto_init() }
@@ -1735,23 +7436,57 @@ func file_internal_pb_server_proto_init() {
 	if File_internal_pb_server_proto != nil {
 		return
 	}
-	file_internal_pb_server_proto_msgTypes[20].OneofWrappers = []any{
+	file_internal_pb_server_proto_msgTypes[51].OneofWrappers = []any{
+		(*ImportRoomRequest_Options)(nil),
+		(*ImportRoomRequest_Chunk)(nil),
+	}
+	file_internal_pb_server_proto_msgTypes[63].OneofWrappers = []any{}
+	file_internal_pb_server_proto_msgTypes[69].OneofWrappers = []any{
+		(*RoomDirectoryEvent_Snapshot)(nil),
+		(*RoomDirectoryEvent_RoomCreated)(nil),
+		(*RoomDirectoryEvent_RoomUpdated)(nil),
+		(*RoomDirectoryEvent_RoomDeleted)(nil),
+		(*RoomDirectoryEvent_MemberCountChanged)(nil),
+		(*RoomDirectoryEvent_RoomHidden)(nil),
+	}
+	file_internal_pb_server_proto_msgTypes[72].OneofWrappers = []any{}
+	file_internal_pb_server_proto_msgTypes[80].OneofWrappers = []any{
 		(*RoomEvent_UserJoined)(nil),
 		(*RoomEvent_UserLeft)(nil),
 		(*RoomEvent_RoomDeleted)(nil),
+		(*RoomEvent_RoomUpdated)(nil),
+		(*RoomEvent_Waitlisted)(nil),
+		(*RoomEvent_WaitlistPromoted)(nil),
+		(*RoomEvent_OwnershipTransferred)(nil),
+		(*RoomEvent_JoinRequested)(nil),
+		(*RoomEvent_JoinRequestResolved)(nil),
+		(*RoomEvent_MetadataChanged)(nil),
+	}
+	file_internal_pb_server_proto_msgTypes[86].OneofWrappers = []any{
+		(*RoomPreviewEvent_Message)(nil),
+		(*RoomPreviewEvent_RoomUpdated)(nil),
+		(*RoomPreviewEvent_RoomDeleted)(nil),
+	}
+	file_internal_pb_server_proto_msgTypes[89].OneofWrappers = []any{
+		(*SubscribeEvent_RoomAdded)(nil),
+		(*SubscribeEvent_RoomRemoved)(nil),
+		(*SubscribeEvent_RoomEvent)(nil),
+		(*SubscribeEvent_Message)(nil),
+		(*SubscribeEvent_JoinRequestResolved)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_internal_pb_server_proto_rawDesc), len(file_internal_pb_server_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   28,
+			NumEnums:      5,
+			NumMessages:   106,
 			NumExtensions: 0,
-			NumServices:   3,
+			NumServices:   4,
 		},
 		GoTypes:           file_internal_pb_server_proto_goTypes,
 		DependencyIndexes: file_internal_pb_server_proto_depIdxs,
+		EnumInfos:         file_internal_pb_server_proto_enumTypes,
 		MessageInfos:      file_internal_pb_server_proto_msgTypes,
 	}.Build()
 	File_internal_pb_server_proto = out.File
@@ -274,14 +274,45 @@ var AuthGrpcService_ServiceDesc = grpc.ServiceDesc{
 }
 
 const (
-	RoomGrpcService_CreateRoom_FullMethodName     = "/chat.RoomGrpcService/CreateRoom"
-	RoomGrpcService_ListRooms_FullMethodName      = "/chat.RoomGrpcService/ListRooms"
-	RoomGrpcService_JoinRoom_FullMethodName       = "/chat.RoomGrpcService/JoinRoom"
-	RoomGrpcService_LeaveRoom_FullMethodName      = "/chat.RoomGrpcService/LeaveRoom"
-	RoomGrpcService_GetRoomStats_FullMethodName   = "/chat.RoomGrpcService/GetRoomStats"
-	RoomGrpcService_GetRoom_FullMethodName        = "/chat.RoomGrpcService/GetRoom"
-	RoomGrpcService_DeleteRoom_FullMethodName     = "/chat.RoomGrpcService/DeleteRoom"
-	RoomGrpcService_GetRoomMembers_FullMethodName = "/chat.RoomGrpcService/GetRoomMembers"
+	RoomGrpcService_CreateRoom_FullMethodName            = "/chat.RoomGrpcService/CreateRoom"
+	RoomGrpcService_CreateRoomTemplate_FullMethodName    = "/chat.RoomGrpcService/CreateRoomTemplate"
+	RoomGrpcService_ListRoomTemplates_FullMethodName     = "/chat.RoomGrpcService/ListRoomTemplates"
+	RoomGrpcService_ListRooms_FullMethodName             = "/chat.RoomGrpcService/ListRooms"
+	RoomGrpcService_SearchRooms_FullMethodName           = "/chat.RoomGrpcService/SearchRooms"
+	RoomGrpcService_JoinRoom_FullMethodName              = "/chat.RoomGrpcService/JoinRoom"
+	RoomGrpcService_LeaveRoom_FullMethodName             = "/chat.RoomGrpcService/LeaveRoom"
+	RoomGrpcService_GetRoomStats_FullMethodName          = "/chat.RoomGrpcService/GetRoomStats"
+	RoomGrpcService_GetRoom_FullMethodName               = "/chat.RoomGrpcService/GetRoom"
+	RoomGrpcService_PreviewRoom_FullMethodName           = "/chat.RoomGrpcService/PreviewRoom"
+	RoomGrpcService_WatchPublicRoom_FullMethodName       = "/chat.RoomGrpcService/WatchPublicRoom"
+	RoomGrpcService_DeleteRoom_FullMethodName            = "/chat.RoomGrpcService/DeleteRoom"
+	RoomGrpcService_ListRoomMembers_FullMethodName       = "/chat.RoomGrpcService/ListRoomMembers"
+	RoomGrpcService_WatchRooms_FullMethodName            = "/chat.RoomGrpcService/WatchRooms"
+	RoomGrpcService_Subscribe_FullMethodName             = "/chat.RoomGrpcService/Subscribe"
+	RoomGrpcService_UpdateRoom_FullMethodName            = "/chat.RoomGrpcService/UpdateRoom"
+	RoomGrpcService_GetRoomPresence_FullMethodName       = "/chat.RoomGrpcService/GetRoomPresence"
+	RoomGrpcService_GetUserPresence_FullMethodName       = "/chat.RoomGrpcService/GetUserPresence"
+	RoomGrpcService_ArchiveRoom_FullMethodName           = "/chat.RoomGrpcService/ArchiveRoom"
+	RoomGrpcService_UnarchiveRoom_FullMethodName         = "/chat.RoomGrpcService/UnarchiveRoom"
+	RoomGrpcService_CreateInviteLink_FullMethodName      = "/chat.RoomGrpcService/CreateInviteLink"
+	RoomGrpcService_RevokeInviteLink_FullMethodName      = "/chat.RoomGrpcService/RevokeInviteLink"
+	RoomGrpcService_ListInviteLinks_FullMethodName       = "/chat.RoomGrpcService/ListInviteLinks"
+	RoomGrpcService_JoinByInviteCode_FullMethodName      = "/chat.RoomGrpcService/JoinByInviteCode"
+	RoomGrpcService_RequestToJoin_FullMethodName         = "/chat.RoomGrpcService/RequestToJoin"
+	RoomGrpcService_ListJoinRequests_FullMethodName      = "/chat.RoomGrpcService/ListJoinRequests"
+	RoomGrpcService_ApproveJoinRequest_FullMethodName    = "/chat.RoomGrpcService/ApproveJoinRequest"
+	RoomGrpcService_RejectJoinRequest_FullMethodName     = "/chat.RoomGrpcService/RejectJoinRequest"
+	RoomGrpcService_SetRoomMetadata_FullMethodName       = "/chat.RoomGrpcService/SetRoomMetadata"
+	RoomGrpcService_GetRoomMetadata_FullMethodName       = "/chat.RoomGrpcService/GetRoomMetadata"
+	RoomGrpcService_DeleteRoomMetadata_FullMethodName    = "/chat.RoomGrpcService/DeleteRoomMetadata"
+	RoomGrpcService_SetRoomMetadataPolicy_FullMethodName = "/chat.RoomGrpcService/SetRoomMetadataPolicy"
+	RoomGrpcService_SetMemberRole_FullMethodName         = "/chat.RoomGrpcService/SetMemberRole"
+	RoomGrpcService_MuteMember_FullMethodName            = "/chat.RoomGrpcService/MuteMember"
+	RoomGrpcService_UnmuteMember_FullMethodName          = "/chat.RoomGrpcService/UnmuteMember"
+	RoomGrpcService_TransferOwnership_FullMethodName     = "/chat.RoomGrpcService/TransferOwnership"
+	RoomGrpcService_ExportRoom_FullMethodName            = "/chat.RoomGrpcService/ExportRoom"
+	RoomGrpcService_ImportRoom_FullMethodName            = "/chat.RoomGrpcService/ImportRoom"
+	RoomGrpcService_ReconcileRooms_FullMethodName        = "/chat.RoomGrpcService/ReconcileRooms"
 )
 
 // RoomGrpcServiceClient is the client API for RoomGrpcService service.
@@ -289,13 +320,54 @@ const (
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
 type RoomGrpcServiceClient interface {
 	CreateRoom(ctx context.Context, in *CreateRoomRequest, opts ...grpc.CallOption) (*Room, error)
-	ListRooms(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRoomsResponse, error)
+	// CreateRoomTemplate is reserved to the admins, every user can list the templates and create rooms from them
+	CreateRoomTemplate(ctx context.Context, in *CreateRoomTemplateRequest, opts ...grpc.CallOption) (*RoomTemplate, error)
+	ListRoomTemplates(ctx context.Context, in *ListRoomTemplatesRequest, opts ...grpc.CallOption) (*ListRoomTemplatesResponse, error)
+	// ListRooms leaves out the private rooms the caller neither created nor joined
+	ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error)
+	// SearchRooms matches the name and topic of the rooms despite typos, best matches first
+	SearchRooms(ctx context.Context, in *SearchRoomsRequest, opts ...grpc.CallOption) (*SearchRoomsResponse, error)
 	JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomEvent], error)
 	LeaveRoom(ctx context.Context, in *LeaveRoomRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetRoomStats(ctx context.Context, in *RoomID, opts ...grpc.CallOption) (*RoomStatsResponse, error)
 	GetRoom(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	// PreviewRoom and WatchPublicRoom also serve anonymous callers, private rooms are left to their members
+	PreviewRoom(ctx context.Context, in *PreviewRoomRequest, opts ...grpc.CallOption) (*RoomPreview, error)
+	WatchPublicRoom(ctx context.Context, in *WatchPublicRoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomPreviewEvent], error)
 	DeleteRoom(ctx context.Context, in *DeleteRoomRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
-	GetRoomMembers(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*RoomMembers, error)
+	ListRoomMembers(ctx context.Context, in *ListRoomMembersRequest, opts ...grpc.CallOption) (*ListRoomMembersResponse, error)
+	WatchRooms(ctx context.Context, in *WatchRoomsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomDirectoryEvent], error)
+	// Subscribe streams the events of every room of the user over one stream, the client
+	// sends filters whenever it wants to change the events it gets from a room
+	Subscribe(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SubscribeRequest, SubscribeEvent], error)
+	UpdateRoom(ctx context.Context, in *UpdateRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	// the presence of a private room is only shown to its members, GetUserPresence leaves out
+	// the private rooms the caller is not a member of
+	GetRoomPresence(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*RoomPresence, error)
+	GetUserPresence(ctx context.Context, in *GetUserPresenceRequest, opts ...grpc.CallOption) (*UserPresence, error)
+	ArchiveRoom(ctx context.Context, in *ArchiveRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	UnarchiveRoom(ctx context.Context, in *UnarchiveRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	CreateInviteLink(ctx context.Context, in *CreateInviteLinkRequest, opts ...grpc.CallOption) (*InviteLink, error)
+	RevokeInviteLink(ctx context.Context, in *RevokeInviteLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ListInviteLinks(ctx context.Context, in *ListInviteLinksRequest, opts ...grpc.CallOption) (*ListInviteLinksResponse, error)
+	JoinByInviteCode(ctx context.Context, in *JoinByInviteCodeRequest, opts ...grpc.CallOption) (*Room, error)
+	RequestToJoin(ctx context.Context, in *RequestToJoinRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JoinRequestUpdate], error)
+	ListJoinRequests(ctx context.Context, in *ListJoinRequestsRequest, opts ...grpc.CallOption) (*ListJoinRequestsResponse, error)
+	ApproveJoinRequest(ctx context.Context, in *JoinRequestDecisionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RejectJoinRequest(ctx context.Context, in *JoinRequestDecisionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetRoomMetadata(ctx context.Context, in *SetRoomMetadataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetRoomMetadata(ctx context.Context, in *GetRoomMetadataRequest, opts ...grpc.CallOption) (*RoomMetadata, error)
+	DeleteRoomMetadata(ctx context.Context, in *DeleteRoomMetadataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetRoomMetadataPolicy(ctx context.Context, in *SetRoomMetadataPolicyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	SetMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	MuteMember(ctx context.Context, in *MuteMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UnmuteMember(ctx context.Context, in *UnmuteMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*Room, error)
+	ExportRoom(ctx context.Context, in *ExportRoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomArchiveChunk], error)
+	// ImportRoom is restricted to server admins, it recreates the memberships and messages of the archived users
+	ImportRoom(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportRoomRequest, Room], error)
+	// ReconcileRooms is reserved to server admins
+	ReconcileRooms(ctx context.Context, in *ReconcileRoomsRequest, opts ...grpc.CallOption) (*ReconcileRoomsResponse, error)
 }
 
 type roomGrpcServiceClient struct {
@@ -316,7 +388,27 @@ func (c *roomGrpcServiceClient) CreateRoom(ctx context.Context, in *CreateRoomRe
 	return out, nil
 }
 
-func (c *roomGrpcServiceClient) ListRooms(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*ListRoomsResponse, error) {
+func (c *roomGrpcServiceClient) CreateRoomTemplate(ctx context.Context, in *CreateRoomTemplateRequest, opts ...grpc.CallOption) (*RoomTemplate, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoomTemplate)
+	err := c.cc.Invoke(ctx, RoomGrpcService_CreateRoomTemplate_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) ListRoomTemplates(ctx context.Context, in *ListRoomTemplatesRequest, opts ...grpc.CallOption) (*ListRoomTemplatesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoomTemplatesResponse)
+	err := c.cc.Invoke(ctx, RoomGrpcService_ListRoomTemplates_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) ListRooms(ctx context.Context, in *ListRoomsRequest, opts ...grpc.CallOption) (*ListRoomsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ListRoomsResponse)
 	err := c.cc.Invoke(ctx, RoomGrpcService_ListRooms_FullMethodName, in, out, cOpts...)
@@ -326,322 +418,1719 @@ func (c *roomGrpcServiceClient) ListRooms(ctx context.Context, in *emptypb.Empty
 	return out, nil
 }
 
-func (c *roomGrpcServiceClient) JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomEvent], error) {
+func (c *roomGrpcServiceClient) SearchRooms(ctx context.Context, in *SearchRoomsRequest, opts ...grpc.CallOption) (*SearchRoomsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchRoomsResponse)
+	err := c.cc.Invoke(ctx, RoomGrpcService_SearchRooms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) JoinRoom(ctx context.Context, in *JoinRoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoomGrpcService_ServiceDesc.Streams[0], RoomGrpcService_JoinRoom_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[JoinRoomRequest, RoomEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_JoinRoomClient = grpc.ServerStreamingClient[RoomEvent]
+
+func (c *roomGrpcServiceClient) LeaveRoom(ctx context.Context, in *LeaveRoomRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_LeaveRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) GetRoomStats(ctx context.Context, in *RoomID, opts ...grpc.CallOption) (*RoomStatsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoomStatsResponse)
+	err := c.cc.Invoke(ctx, RoomGrpcService_GetRoomStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) GetRoom(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, RoomGrpcService_GetRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) PreviewRoom(ctx context.Context, in *PreviewRoomRequest, opts ...grpc.CallOption) (*RoomPreview, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoomPreview)
+	err := c.cc.Invoke(ctx, RoomGrpcService_PreviewRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) WatchPublicRoom(ctx context.Context, in *WatchPublicRoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomPreviewEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoomGrpcService_ServiceDesc.Streams[1], RoomGrpcService_WatchPublicRoom_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchPublicRoomRequest, RoomPreviewEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_WatchPublicRoomClient = grpc.ServerStreamingClient[RoomPreviewEvent]
+
+func (c *roomGrpcServiceClient) DeleteRoom(ctx context.Context, in *DeleteRoomRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_DeleteRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) ListRoomMembers(ctx context.Context, in *ListRoomMembersRequest, opts ...grpc.CallOption) (*ListRoomMembersResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListRoomMembersResponse)
+	err := c.cc.Invoke(ctx, RoomGrpcService_ListRoomMembers_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) WatchRooms(ctx context.Context, in *WatchRoomsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomDirectoryEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoomGrpcService_ServiceDesc.Streams[2], RoomGrpcService_WatchRooms_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRoomsRequest, RoomDirectoryEvent]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_WatchRoomsClient = grpc.ServerStreamingClient[RoomDirectoryEvent]
+
+func (c *roomGrpcServiceClient) Subscribe(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[SubscribeRequest, SubscribeEvent], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoomGrpcService_ServiceDesc.Streams[3], RoomGrpcService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[SubscribeRequest, SubscribeEvent]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_SubscribeClient = grpc.BidiStreamingClient[SubscribeRequest, SubscribeEvent]
+
+func (c *roomGrpcServiceClient) UpdateRoom(ctx context.Context, in *UpdateRoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, RoomGrpcService_UpdateRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) GetRoomPresence(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*RoomPresence, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoomPresence)
+	err := c.cc.Invoke(ctx, RoomGrpcService_GetRoomPresence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) GetUserPresence(ctx context.Context, in *GetUserPresenceRequest, opts ...grpc.CallOption) (*UserPresence, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserPresence)
+	err := c.cc.Invoke(ctx, RoomGrpcService_GetUserPresence_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) ArchiveRoom(ctx context.Context, in *ArchiveRoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, RoomGrpcService_ArchiveRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) UnarchiveRoom(ctx context.Context, in *UnarchiveRoomRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, RoomGrpcService_UnarchiveRoom_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) CreateInviteLink(ctx context.Context, in *CreateInviteLinkRequest, opts ...grpc.CallOption) (*InviteLink, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(InviteLink)
+	err := c.cc.Invoke(ctx, RoomGrpcService_CreateInviteLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) RevokeInviteLink(ctx context.Context, in *RevokeInviteLinkRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_RevokeInviteLink_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) ListInviteLinks(ctx context.Context, in *ListInviteLinksRequest, opts ...grpc.CallOption) (*ListInviteLinksResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListInviteLinksResponse)
+	err := c.cc.Invoke(ctx, RoomGrpcService_ListInviteLinks_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) JoinByInviteCode(ctx context.Context, in *JoinByInviteCodeRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, RoomGrpcService_JoinByInviteCode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) RequestToJoin(ctx context.Context, in *RequestToJoinRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[JoinRequestUpdate], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoomGrpcService_ServiceDesc.Streams[4], RoomGrpcService_RequestToJoin_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[RequestToJoinRequest, JoinRequestUpdate]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_RequestToJoinClient = grpc.ServerStreamingClient[JoinRequestUpdate]
+
+func (c *roomGrpcServiceClient) ListJoinRequests(ctx context.Context, in *ListJoinRequestsRequest, opts ...grpc.CallOption) (*ListJoinRequestsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListJoinRequestsResponse)
+	err := c.cc.Invoke(ctx, RoomGrpcService_ListJoinRequests_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) ApproveJoinRequest(ctx context.Context, in *JoinRequestDecisionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_ApproveJoinRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) RejectJoinRequest(ctx context.Context, in *JoinRequestDecisionRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_RejectJoinRequest_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) SetRoomMetadata(ctx context.Context, in *SetRoomMetadataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_SetRoomMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) GetRoomMetadata(ctx context.Context, in *GetRoomMetadataRequest, opts ...grpc.CallOption) (*RoomMetadata, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RoomMetadata)
+	err := c.cc.Invoke(ctx, RoomGrpcService_GetRoomMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) DeleteRoomMetadata(ctx context.Context, in *DeleteRoomMetadataRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_DeleteRoomMetadata_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) SetRoomMetadataPolicy(ctx context.Context, in *SetRoomMetadataPolicyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_SetRoomMetadataPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) SetMemberRole(ctx context.Context, in *SetMemberRoleRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_SetMemberRole_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) MuteMember(ctx context.Context, in *MuteMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_MuteMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) UnmuteMember(ctx context.Context, in *UnmuteMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, RoomGrpcService_UnmuteMember_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) TransferOwnership(ctx context.Context, in *TransferOwnershipRequest, opts ...grpc.CallOption) (*Room, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, RoomGrpcService_TransferOwnership_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *roomGrpcServiceClient) ExportRoom(ctx context.Context, in *ExportRoomRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[RoomArchiveChunk], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoomGrpcService_ServiceDesc.Streams[5], RoomGrpcService_ExportRoom_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ExportRoomRequest, RoomArchiveChunk]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_ExportRoomClient = grpc.ServerStreamingClient[RoomArchiveChunk]
+
+func (c *roomGrpcServiceClient) ImportRoom(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[ImportRoomRequest, Room], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &RoomGrpcService_ServiceDesc.Streams[6], RoomGrpcService_ImportRoom_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ImportRoomRequest, Room]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_ImportRoomClient = grpc.ClientStreamingClient[ImportRoomRequest, Room]
+
+func (c *roomGrpcServiceClient) ReconcileRooms(ctx context.Context, in *ReconcileRoomsRequest, opts ...grpc.CallOption) (*ReconcileRoomsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReconcileRoomsResponse)
+	err := c.cc.Invoke(ctx, RoomGrpcService_ReconcileRooms_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// RoomGrpcServiceServer is the server API for RoomGrpcService service.
+// All implementations must embed UnimplementedRoomGrpcServiceServer
+// for forward compatibility.
+type RoomGrpcServiceServer interface {
+	CreateRoom(context.Context, *CreateRoomRequest) (*Room, error)
+	// CreateRoomTemplate is reserved to the admins, every user can list the templates and create rooms from them
+	CreateRoomTemplate(context.Context, *CreateRoomTemplateRequest) (*RoomTemplate, error)
+	ListRoomTemplates(context.Context, *ListRoomTemplatesRequest) (*ListRoomTemplatesResponse, error)
+	// ListRooms leaves out the private rooms the caller neither created nor joined
+	ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error)
+	// SearchRooms matches the name and topic of the rooms despite typos, best matches first
+	SearchRooms(context.Context, *SearchRoomsRequest) (*SearchRoomsResponse, error)
+	JoinRoom(*JoinRoomRequest, grpc.ServerStreamingServer[RoomEvent]) error
+	LeaveRoom(context.Context, *LeaveRoomRequest) (*emptypb.Empty, error)
+	GetRoomStats(context.Context, *RoomID) (*RoomStatsResponse, error)
+	GetRoom(context.Context, *GetRoomRequest) (*Room, error)
+	// PreviewRoom and WatchPublicRoom also serve anonymous callers, private rooms are left to their members
+	PreviewRoom(context.Context, *PreviewRoomRequest) (*RoomPreview, error)
+	WatchPublicRoom(*WatchPublicRoomRequest, grpc.ServerStreamingServer[RoomPreviewEvent]) error
+	DeleteRoom(context.Context, *DeleteRoomRequest) (*emptypb.Empty, error)
+	ListRoomMembers(context.Context, *ListRoomMembersRequest) (*ListRoomMembersResponse, error)
+	WatchRooms(*WatchRoomsRequest, grpc.ServerStreamingServer[RoomDirectoryEvent]) error
+	// Subscribe streams the events of every room of the user over one stream, the client
+	// sends filters whenever it wants to change the events it gets from a room
+	Subscribe(grpc.BidiStreamingServer[SubscribeRequest, SubscribeEvent]) error
+	UpdateRoom(context.Context, *UpdateRoomRequest) (*Room, error)
+	// the presence of a private room is only shown to its members, GetUserPresence leaves out
+	// the private rooms the caller is not a member of
+	GetRoomPresence(context.Context, *GetRoomRequest) (*RoomPresence, error)
+	GetUserPresence(context.Context, *GetUserPresenceRequest) (*UserPresence, error)
+	ArchiveRoom(context.Context, *ArchiveRoomRequest) (*Room, error)
+	UnarchiveRoom(context.Context, *UnarchiveRoomRequest) (*Room, error)
+	CreateInviteLink(context.Context, *CreateInviteLinkRequest) (*InviteLink, error)
+	RevokeInviteLink(context.Context, *RevokeInviteLinkRequest) (*emptypb.Empty, error)
+	ListInviteLinks(context.Context, *ListInviteLinksRequest) (*ListInviteLinksResponse, error)
+	JoinByInviteCode(context.Context, *JoinByInviteCodeRequest) (*Room, error)
+	RequestToJoin(*RequestToJoinRequest, grpc.ServerStreamingServer[JoinRequestUpdate]) error
+	ListJoinRequests(context.Context, *ListJoinRequestsRequest) (*ListJoinRequestsResponse, error)
+	ApproveJoinRequest(context.Context, *JoinRequestDecisionRequest) (*emptypb.Empty, error)
+	RejectJoinRequest(context.Context, *JoinRequestDecisionRequest) (*emptypb.Empty, error)
+	SetRoomMetadata(context.Context, *SetRoomMetadataRequest) (*emptypb.Empty, error)
+	GetRoomMetadata(context.Context, *GetRoomMetadataRequest) (*RoomMetadata, error)
+	DeleteRoomMetadata(context.Context, *DeleteRoomMetadataRequest) (*emptypb.Empty, error)
+	SetRoomMetadataPolicy(context.Context, *SetRoomMetadataPolicyRequest) (*emptypb.Empty, error)
+	SetMemberRole(context.Context, *SetMemberRoleRequest) (*emptypb.Empty, error)
+	MuteMember(context.Context, *MuteMemberRequest) (*emptypb.Empty, error)
+	UnmuteMember(context.Context, *UnmuteMemberRequest) (*emptypb.Empty, error)
+	TransferOwnership(context.Context, *TransferOwnershipRequest) (*Room, error)
+	ExportRoom(*ExportRoomRequest, grpc.ServerStreamingServer[RoomArchiveChunk]) error
+	// ImportRoom is restricted to server admins, it recreates the memberships and messages of the archived users
+	ImportRoom(grpc.ClientStreamingServer[ImportRoomRequest, Room]) error
+	// ReconcileRooms is reserved to server admins
+	ReconcileRooms(context.Context, *ReconcileRoomsRequest) (*ReconcileRoomsResponse, error)
+	mustEmbedUnimplementedRoomGrpcServiceServer()
+}
+
+// UnimplementedRoomGrpcServiceServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRoomGrpcServiceServer struct{}
+
+func (UnimplementedRoomGrpcServiceServer) CreateRoom(context.Context, *CreateRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) CreateRoomTemplate(context.Context, *CreateRoomTemplateRequest) (*RoomTemplate, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateRoomTemplate not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ListRoomTemplates(context.Context, *ListRoomTemplatesRequest) (*ListRoomTemplatesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoomTemplates not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ListRooms(context.Context, *ListRoomsRequest) (*ListRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRooms not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) SearchRooms(context.Context, *SearchRoomsRequest) (*SearchRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SearchRooms not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) JoinRoom(*JoinRoomRequest, grpc.ServerStreamingServer[RoomEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method JoinRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) LeaveRoom(context.Context, *LeaveRoomRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method LeaveRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) GetRoomStats(context.Context, *RoomID) (*RoomStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoomStats not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) GetRoom(context.Context, *GetRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) PreviewRoom(context.Context, *PreviewRoomRequest) (*RoomPreview, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method PreviewRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) WatchPublicRoom(*WatchPublicRoomRequest, grpc.ServerStreamingServer[RoomPreviewEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchPublicRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) DeleteRoom(context.Context, *DeleteRoomRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ListRoomMembers(context.Context, *ListRoomMembersRequest) (*ListRoomMembersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListRoomMembers not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) WatchRooms(*WatchRoomsRequest, grpc.ServerStreamingServer[RoomDirectoryEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method WatchRooms not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) Subscribe(grpc.BidiStreamingServer[SubscribeRequest, SubscribeEvent]) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) UpdateRoom(context.Context, *UpdateRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) GetRoomPresence(context.Context, *GetRoomRequest) (*RoomPresence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoomPresence not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) GetUserPresence(context.Context, *GetUserPresenceRequest) (*UserPresence, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetUserPresence not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ArchiveRoom(context.Context, *ArchiveRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ArchiveRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) UnarchiveRoom(context.Context, *UnarchiveRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnarchiveRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) CreateInviteLink(context.Context, *CreateInviteLinkRequest) (*InviteLink, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateInviteLink not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) RevokeInviteLink(context.Context, *RevokeInviteLinkRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RevokeInviteLink not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ListInviteLinks(context.Context, *ListInviteLinksRequest) (*ListInviteLinksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListInviteLinks not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) JoinByInviteCode(context.Context, *JoinByInviteCodeRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinByInviteCode not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) RequestToJoin(*RequestToJoinRequest, grpc.ServerStreamingServer[JoinRequestUpdate]) error {
+	return status.Errorf(codes.Unimplemented, "method RequestToJoin not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ListJoinRequests(context.Context, *ListJoinRequestsRequest) (*ListJoinRequestsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListJoinRequests not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ApproveJoinRequest(context.Context, *JoinRequestDecisionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ApproveJoinRequest not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) RejectJoinRequest(context.Context, *JoinRequestDecisionRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RejectJoinRequest not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) SetRoomMetadata(context.Context, *SetRoomMetadataRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoomMetadata not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) GetRoomMetadata(context.Context, *GetRoomMetadataRequest) (*RoomMetadata, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRoomMetadata not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) DeleteRoomMetadata(context.Context, *DeleteRoomMetadataRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoomMetadata not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) SetRoomMetadataPolicy(context.Context, *SetRoomMetadataPolicyRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetRoomMetadataPolicy not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) SetMemberRole(context.Context, *SetMemberRoleRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SetMemberRole not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) MuteMember(context.Context, *MuteMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MuteMember not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) UnmuteMember(context.Context, *UnmuteMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UnmuteMember not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) TransferOwnership(context.Context, *TransferOwnershipRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TransferOwnership not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ExportRoom(*ExportRoomRequest, grpc.ServerStreamingServer[RoomArchiveChunk]) error {
+	return status.Errorf(codes.Unimplemented, "method ExportRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ImportRoom(grpc.ClientStreamingServer[ImportRoomRequest, Room]) error {
+	return status.Errorf(codes.Unimplemented, "method ImportRoom not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) ReconcileRooms(context.Context, *ReconcileRoomsRequest) (*ReconcileRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReconcileRooms not implemented")
+}
+func (UnimplementedRoomGrpcServiceServer) mustEmbedUnimplementedRoomGrpcServiceServer() {}
+func (UnimplementedRoomGrpcServiceServer) testEmbeddedByValue()                         {}
+
+// UnsafeRoomGrpcServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RoomGrpcServiceServer will
+// result in compilation errors.
+type UnsafeRoomGrpcServiceServer interface {
+	mustEmbedUnimplementedRoomGrpcServiceServer()
+}
+
+func RegisterRoomGrpcServiceServer(s grpc.ServiceRegistrar, srv RoomGrpcServiceServer) {
+	// If the following call pancis, it indicates UnimplementedRoomGrpcServiceServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&RoomGrpcService_ServiceDesc, srv)
+}
+
+func _RoomGrpcService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).CreateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_CreateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).CreateRoom(ctx, req.(*CreateRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_CreateRoomTemplate_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateRoomTemplateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).CreateRoomTemplate(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_CreateRoomTemplate_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).CreateRoomTemplate(ctx, req.(*CreateRoomTemplateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_ListRoomTemplates_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomTemplatesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).ListRoomTemplates(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_ListRoomTemplates_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).ListRoomTemplates(ctx, req.(*ListRoomTemplatesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).ListRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_ListRooms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).ListRooms(ctx, req.(*ListRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_SearchRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).SearchRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_SearchRooms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).SearchRooms(ctx, req.(*SearchRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_JoinRoom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(JoinRoomRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoomGrpcServiceServer).JoinRoom(m, &grpc.GenericServerStream[JoinRoomRequest, RoomEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_JoinRoomServer = grpc.ServerStreamingServer[RoomEvent]
+
+func _RoomGrpcService_LeaveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LeaveRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).LeaveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_LeaveRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).LeaveRoom(ctx, req.(*LeaveRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_GetRoomStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RoomID)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).GetRoomStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_GetRoomStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).GetRoomStats(ctx, req.(*RoomID))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_GetRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).GetRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_GetRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).GetRoom(ctx, req.(*GetRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_PreviewRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PreviewRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).PreviewRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_PreviewRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).PreviewRoom(ctx, req.(*PreviewRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_WatchPublicRoom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchPublicRoomRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoomGrpcServiceServer).WatchPublicRoom(m, &grpc.GenericServerStream[WatchPublicRoomRequest, RoomPreviewEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_WatchPublicRoomServer = grpc.ServerStreamingServer[RoomPreviewEvent]
+
+func _RoomGrpcService_DeleteRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).DeleteRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_DeleteRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).DeleteRoom(ctx, req.(*DeleteRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_ListRoomMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListRoomMembersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).ListRoomMembers(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_ListRoomMembers_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).ListRoomMembers(ctx, req.(*ListRoomMembersRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_WatchRooms_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRoomsRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoomGrpcServiceServer).WatchRooms(m, &grpc.GenericServerStream[WatchRoomsRequest, RoomDirectoryEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_WatchRoomsServer = grpc.ServerStreamingServer[RoomDirectoryEvent]
+
+func _RoomGrpcService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RoomGrpcServiceServer).Subscribe(&grpc.GenericServerStream[SubscribeRequest, SubscribeEvent]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_SubscribeServer = grpc.BidiStreamingServer[SubscribeRequest, SubscribeEvent]
+
+func _RoomGrpcService_UpdateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).UpdateRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_UpdateRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).UpdateRoom(ctx, req.(*UpdateRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_GetRoomPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).GetRoomPresence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_GetRoomPresence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).GetRoomPresence(ctx, req.(*GetRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_GetUserPresence_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetUserPresenceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).GetUserPresence(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_GetUserPresence_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).GetUserPresence(ctx, req.(*GetUserPresenceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_ArchiveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ArchiveRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).ArchiveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_ArchiveRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).ArchiveRoom(ctx, req.(*ArchiveRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_UnarchiveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnarchiveRoomRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).UnarchiveRoom(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_UnarchiveRoom_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).UnarchiveRoom(ctx, req.(*UnarchiveRoomRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_CreateInviteLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateInviteLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).CreateInviteLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_CreateInviteLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).CreateInviteLink(ctx, req.(*CreateInviteLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_RevokeInviteLink_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeInviteLinkRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).RevokeInviteLink(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_RevokeInviteLink_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).RevokeInviteLink(ctx, req.(*RevokeInviteLinkRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_ListInviteLinks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListInviteLinksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).ListInviteLinks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_ListInviteLinks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).ListInviteLinks(ctx, req.(*ListInviteLinksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_JoinByInviteCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinByInviteCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).JoinByInviteCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_JoinByInviteCode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).JoinByInviteCode(ctx, req.(*JoinByInviteCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_RequestToJoin_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(RequestToJoinRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoomGrpcServiceServer).RequestToJoin(m, &grpc.GenericServerStream[RequestToJoinRequest, JoinRequestUpdate]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_RequestToJoinServer = grpc.ServerStreamingServer[JoinRequestUpdate]
+
+func _RoomGrpcService_ListJoinRequests_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListJoinRequestsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).ListJoinRequests(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_ListJoinRequests_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).ListJoinRequests(ctx, req.(*ListJoinRequestsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_ApproveJoinRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequestDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).ApproveJoinRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_ApproveJoinRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).ApproveJoinRequest(ctx, req.(*JoinRequestDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_RejectJoinRequest_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinRequestDecisionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).RejectJoinRequest(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_RejectJoinRequest_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).RejectJoinRequest(ctx, req.(*JoinRequestDecisionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_SetRoomMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoomMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).SetRoomMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_SetRoomMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).SetRoomMetadata(ctx, req.(*SetRoomMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_GetRoomMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRoomMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).GetRoomMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_GetRoomMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).GetRoomMetadata(ctx, req.(*GetRoomMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_DeleteRoomMetadata_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteRoomMetadataRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).DeleteRoomMetadata(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_DeleteRoomMetadata_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).DeleteRoomMetadata(ctx, req.(*DeleteRoomMetadataRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_SetRoomMetadataPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetRoomMetadataPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).SetRoomMetadataPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_SetRoomMetadataPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).SetRoomMetadataPolicy(ctx, req.(*SetRoomMetadataPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_SetMemberRole_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMemberRoleRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).SetMemberRole(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_SetMemberRole_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).SetMemberRole(ctx, req.(*SetMemberRoleRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_MuteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MuteMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).MuteMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_MuteMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).MuteMember(ctx, req.(*MuteMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_UnmuteMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnmuteMemberRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).UnmuteMember(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_UnmuteMember_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).UnmuteMember(ctx, req.(*UnmuteMemberRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_TransferOwnership_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TransferOwnershipRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).TransferOwnership(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_TransferOwnership_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).TransferOwnership(ctx, req.(*TransferOwnershipRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _RoomGrpcService_ExportRoom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(ExportRoomRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(RoomGrpcServiceServer).ExportRoom(m, &grpc.GenericServerStream[ExportRoomRequest, RoomArchiveChunk]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_ExportRoomServer = grpc.ServerStreamingServer[RoomArchiveChunk]
+
+func _RoomGrpcService_ImportRoom_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RoomGrpcServiceServer).ImportRoom(&grpc.GenericServerStream[ImportRoomRequest, Room]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type RoomGrpcService_ImportRoomServer = grpc.ClientStreamingServer[ImportRoomRequest, Room]
+
+func _RoomGrpcService_ReconcileRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReconcileRoomsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RoomGrpcServiceServer).ReconcileRooms(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: RoomGrpcService_ReconcileRooms_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RoomGrpcServiceServer).ReconcileRooms(ctx, req.(*ReconcileRoomsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// RoomGrpcService_ServiceDesc is the grpc.ServiceDesc for RoomGrpcService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var RoomGrpcService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chat.RoomGrpcService",
+	HandlerType: (*RoomGrpcServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "CreateRoom",
+			Handler:    _RoomGrpcService_CreateRoom_Handler,
+		},
+		{
+			MethodName: "CreateRoomTemplate",
+			Handler:    _RoomGrpcService_CreateRoomTemplate_Handler,
+		},
+		{
+			MethodName: "ListRoomTemplates",
+			Handler:    _RoomGrpcService_ListRoomTemplates_Handler,
+		},
+		{
+			MethodName: "ListRooms",
+			Handler:    _RoomGrpcService_ListRooms_Handler,
+		},
+		{
+			MethodName: "SearchRooms",
+			Handler:    _RoomGrpcService_SearchRooms_Handler,
+		},
+		{
+			MethodName: "LeaveRoom",
+			Handler:    _RoomGrpcService_LeaveRoom_Handler,
+		},
+		{
+			MethodName: "GetRoomStats",
+			Handler:    _RoomGrpcService_GetRoomStats_Handler,
+		},
+		{
+			MethodName: "GetRoom",
+			Handler:    _RoomGrpcService_GetRoom_Handler,
+		},
+		{
+			MethodName: "PreviewRoom",
+			Handler:    _RoomGrpcService_PreviewRoom_Handler,
+		},
+		{
+			MethodName: "DeleteRoom",
+			Handler:    _RoomGrpcService_DeleteRoom_Handler,
+		},
+		{
+			MethodName: "ListRoomMembers",
+			Handler:    _RoomGrpcService_ListRoomMembers_Handler,
+		},
+		{
+			MethodName: "UpdateRoom",
+			Handler:    _RoomGrpcService_UpdateRoom_Handler,
+		},
+		{
+			MethodName: "GetRoomPresence",
+			Handler:    _RoomGrpcService_GetRoomPresence_Handler,
+		},
+		{
+			MethodName: "GetUserPresence",
+			Handler:    _RoomGrpcService_GetUserPresence_Handler,
+		},
+		{
+			MethodName: "ArchiveRoom",
+			Handler:    _RoomGrpcService_ArchiveRoom_Handler,
+		},
+		{
+			MethodName: "UnarchiveRoom",
+			Handler:    _RoomGrpcService_UnarchiveRoom_Handler,
+		},
+		{
+			MethodName: "CreateInviteLink",
+			Handler:    _RoomGrpcService_CreateInviteLink_Handler,
+		},
+		{
+			MethodName: "RevokeInviteLink",
+			Handler:    _RoomGrpcService_RevokeInviteLink_Handler,
+		},
+		{
+			MethodName: "ListInviteLinks",
+			Handler:    _RoomGrpcService_ListInviteLinks_Handler,
+		},
+		{
+			MethodName: "JoinByInviteCode",
+			Handler:    _RoomGrpcService_JoinByInviteCode_Handler,
+		},
+		{
+			MethodName: "ListJoinRequests",
+			Handler:    _RoomGrpcService_ListJoinRequests_Handler,
+		},
+		{
+			MethodName: "ApproveJoinRequest",
+			Handler:    _RoomGrpcService_ApproveJoinRequest_Handler,
+		},
+		{
+			MethodName: "RejectJoinRequest",
+			Handler:    _RoomGrpcService_RejectJoinRequest_Handler,
+		},
+		{
+			MethodName: "SetRoomMetadata",
+			Handler:    _RoomGrpcService_SetRoomMetadata_Handler,
+		},
+		{
+			MethodName: "GetRoomMetadata",
+			Handler:    _RoomGrpcService_GetRoomMetadata_Handler,
+		},
+		{
+			MethodName: "DeleteRoomMetadata",
+			Handler:    _RoomGrpcService_DeleteRoomMetadata_Handler,
+		},
+		{
+			MethodName: "SetRoomMetadataPolicy",
+			Handler:    _RoomGrpcService_SetRoomMetadataPolicy_Handler,
+		},
+		{
+			MethodName: "SetMemberRole",
+			Handler:    _RoomGrpcService_SetMemberRole_Handler,
+		},
+		{
+			MethodName: "MuteMember",
+			Handler:    _RoomGrpcService_MuteMember_Handler,
+		},
+		{
+			MethodName: "UnmuteMember",
+			Handler:    _RoomGrpcService_UnmuteMember_Handler,
+		},
+		{
+			MethodName: "TransferOwnership",
+			Handler:    _RoomGrpcService_TransferOwnership_Handler,
+		},
+		{
+			MethodName: "ReconcileRooms",
+			Handler:    _RoomGrpcService_ReconcileRooms_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "JoinRoom",
+			Handler:       _RoomGrpcService_JoinRoom_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchPublicRoom",
+			Handler:       _RoomGrpcService_WatchPublicRoom_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "WatchRooms",
+			Handler:       _RoomGrpcService_WatchRooms_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "Subscribe",
+			Handler:       _RoomGrpcService_Subscribe_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+		{
+			StreamName:    "RequestToJoin",
+			Handler:       _RoomGrpcService_RequestToJoin_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ExportRoom",
+			Handler:       _RoomGrpcService_ExportRoom_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "ImportRoom",
+			Handler:       _RoomGrpcService_ImportRoom_Handler,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "internal/pb/server.proto",
+}
+
+const (
+	SpaceGrpcService_CreateSpace_FullMethodName    = "/chat.SpaceGrpcService/CreateSpace"
+	SpaceGrpcService_JoinSpace_FullMethodName      = "/chat.SpaceGrpcService/JoinSpace"
+	SpaceGrpcService_AddSpaceMember_FullMethodName = "/chat.SpaceGrpcService/AddSpaceMember"
+	SpaceGrpcService_AddRoomToSpace_FullMethodName = "/chat.SpaceGrpcService/AddRoomToSpace"
+	SpaceGrpcService_MoveRoom_FullMethodName       = "/chat.SpaceGrpcService/MoveRoom"
+	SpaceGrpcService_ListSpaceRooms_FullMethodName = "/chat.SpaceGrpcService/ListSpaceRooms"
+)
+
+// SpaceGrpcServiceClient is the client API for SpaceGrpcService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+type SpaceGrpcServiceClient interface {
+	CreateSpace(ctx context.Context, in *CreateSpaceRequest, opts ...grpc.CallOption) (*Space, error)
+	JoinSpace(ctx context.Context, in *JoinSpaceRequest, opts ...grpc.CallOption) (*Space, error)
+	AddSpaceMember(ctx context.Context, in *AddSpaceMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	AddRoomToSpace(ctx context.Context, in *AddRoomToSpaceRequest, opts ...grpc.CallOption) (*Room, error)
+	MoveRoom(ctx context.Context, in *MoveRoomRequest, opts ...grpc.CallOption) (*Room, error)
+	ListSpaceRooms(ctx context.Context, in *ListSpaceRoomsRequest, opts ...grpc.CallOption) (*ListSpaceRoomsResponse, error)
+}
+
+type spaceGrpcServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewSpaceGrpcServiceClient(cc grpc.ClientConnInterface) SpaceGrpcServiceClient {
+	return &spaceGrpcServiceClient{cc}
+}
+
+func (c *spaceGrpcServiceClient) CreateSpace(ctx context.Context, in *CreateSpaceRequest, opts ...grpc.CallOption) (*Space, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	stream, err := c.cc.NewStream(ctx, &RoomGrpcService_ServiceDesc.Streams[0], RoomGrpcService_JoinRoom_FullMethodName, cOpts...)
+	out := new(Space)
+	err := c.cc.Invoke(ctx, SpaceGrpcService_CreateSpace_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
-	x := &grpc.GenericClientStream[JoinRoomRequest, RoomEvent]{ClientStream: stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
-	}
-	return x, nil
+	return out, nil
 }
 
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type RoomGrpcService_JoinRoomClient = grpc.ServerStreamingClient[RoomEvent]
-
-func (c *roomGrpcServiceClient) LeaveRoom(ctx context.Context, in *LeaveRoomRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *spaceGrpcServiceClient) JoinSpace(ctx context.Context, in *JoinSpaceRequest, opts ...grpc.CallOption) (*Space, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, RoomGrpcService_LeaveRoom_FullMethodName, in, out, cOpts...)
+	out := new(Space)
+	err := c.cc.Invoke(ctx, SpaceGrpcService_JoinSpace_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *roomGrpcServiceClient) GetRoomStats(ctx context.Context, in *RoomID, opts ...grpc.CallOption) (*RoomStatsResponse, error) {
+func (c *spaceGrpcServiceClient) AddSpaceMember(ctx context.Context, in *AddSpaceMemberRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RoomStatsResponse)
-	err := c.cc.Invoke(ctx, RoomGrpcService_GetRoomStats_FullMethodName, in, out, cOpts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, SpaceGrpcService_AddSpaceMember_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *roomGrpcServiceClient) GetRoom(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*Room, error) {
+func (c *spaceGrpcServiceClient) AddRoomToSpace(ctx context.Context, in *AddRoomToSpaceRequest, opts ...grpc.CallOption) (*Room, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Room)
-	err := c.cc.Invoke(ctx, RoomGrpcService_GetRoom_FullMethodName, in, out, cOpts...)
+	err := c.cc.Invoke(ctx, SpaceGrpcService_AddRoomToSpace_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *roomGrpcServiceClient) DeleteRoom(ctx context.Context, in *DeleteRoomRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *spaceGrpcServiceClient) MoveRoom(ctx context.Context, in *MoveRoomRequest, opts ...grpc.CallOption) (*Room, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, RoomGrpcService_DeleteRoom_FullMethodName, in, out, cOpts...)
+	out := new(Room)
+	err := c.cc.Invoke(ctx, SpaceGrpcService_MoveRoom_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-func (c *roomGrpcServiceClient) GetRoomMembers(ctx context.Context, in *GetRoomRequest, opts ...grpc.CallOption) (*RoomMembers, error) {
+func (c *spaceGrpcServiceClient) ListSpaceRooms(ctx context.Context, in *ListSpaceRoomsRequest, opts ...grpc.CallOption) (*ListSpaceRoomsResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
-	out := new(RoomMembers)
-	err := c.cc.Invoke(ctx, RoomGrpcService_GetRoomMembers_FullMethodName, in, out, cOpts...)
+	out := new(ListSpaceRoomsResponse)
+	err := c.cc.Invoke(ctx, SpaceGrpcService_ListSpaceRooms_FullMethodName, in, out, cOpts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// RoomGrpcServiceServer is the server API for RoomGrpcService service.
-// All implementations must embed UnimplementedRoomGrpcServiceServer
+// SpaceGrpcServiceServer is the server API for SpaceGrpcService service.
+// All implementations must embed UnimplementedSpaceGrpcServiceServer
 // for forward compatibility.
-type RoomGrpcServiceServer interface {
-	CreateRoom(context.Context, *CreateRoomRequest) (*Room, error)
-	ListRooms(context.Context, *emptypb.Empty) (*ListRoomsResponse, error)
-	JoinRoom(*JoinRoomRequest, grpc.ServerStreamingServer[RoomEvent]) error
-	LeaveRoom(context.Context, *LeaveRoomRequest) (*emptypb.Empty, error)
-	GetRoomStats(context.Context, *RoomID) (*RoomStatsResponse, error)
-	GetRoom(context.Context, *GetRoomRequest) (*Room, error)
-	DeleteRoom(context.Context, *DeleteRoomRequest) (*emptypb.Empty, error)
-	GetRoomMembers(context.Context, *GetRoomRequest) (*RoomMembers, error)
-	mustEmbedUnimplementedRoomGrpcServiceServer()
+type SpaceGrpcServiceServer interface {
+	CreateSpace(context.Context, *CreateSpaceRequest) (*Space, error)
+	JoinSpace(context.Context, *JoinSpaceRequest) (*Space, error)
+	AddSpaceMember(context.Context, *AddSpaceMemberRequest) (*emptypb.Empty, error)
+	AddRoomToSpace(context.Context, *AddRoomToSpaceRequest) (*Room, error)
+	MoveRoom(context.Context, *MoveRoomRequest) (*Room, error)
+	ListSpaceRooms(context.Context, *ListSpaceRoomsRequest) (*ListSpaceRoomsResponse, error)
+	mustEmbedUnimplementedSpaceGrpcServiceServer()
 }
 
-// UnimplementedRoomGrpcServiceServer must be embedded to have
+// UnimplementedSpaceGrpcServiceServer must be embedded to have
 // forward compatible implementations.
 //
 // NOTE: this should be embedded by value instead of pointer to avoid a nil
 // pointer dereference when methods are called.
-type UnimplementedRoomGrpcServiceServer struct{}
+type UnimplementedSpaceGrpcServiceServer struct{}
 
-func (UnimplementedRoomGrpcServiceServer) CreateRoom(context.Context, *CreateRoomRequest) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method CreateRoom not implemented")
-}
-func (UnimplementedRoomGrpcServiceServer) ListRooms(context.Context, *emptypb.Empty) (*ListRoomsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method ListRooms not implemented")
+func (UnimplementedSpaceGrpcServiceServer) CreateSpace(context.Context, *CreateSpaceRequest) (*Space, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateSpace not implemented")
 }
-func (UnimplementedRoomGrpcServiceServer) JoinRoom(*JoinRoomRequest, grpc.ServerStreamingServer[RoomEvent]) error {
-	return status.Errorf(codes.Unimplemented, "method JoinRoom not implemented")
-}
-func (UnimplementedRoomGrpcServiceServer) LeaveRoom(context.Context, *LeaveRoomRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method LeaveRoom not implemented")
+func (UnimplementedSpaceGrpcServiceServer) JoinSpace(context.Context, *JoinSpaceRequest) (*Space, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method JoinSpace not implemented")
 }
-func (UnimplementedRoomGrpcServiceServer) GetRoomStats(context.Context, *RoomID) (*RoomStatsResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRoomStats not implemented")
+func (UnimplementedSpaceGrpcServiceServer) AddSpaceMember(context.Context, *AddSpaceMemberRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddSpaceMember not implemented")
 }
-func (UnimplementedRoomGrpcServiceServer) GetRoom(context.Context, *GetRoomRequest) (*Room, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRoom not implemented")
+func (UnimplementedSpaceGrpcServiceServer) AddRoomToSpace(context.Context, *AddRoomToSpaceRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddRoomToSpace not implemented")
 }
-func (UnimplementedRoomGrpcServiceServer) DeleteRoom(context.Context, *DeleteRoomRequest) (*emptypb.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method DeleteRoom not implemented")
+func (UnimplementedSpaceGrpcServiceServer) MoveRoom(context.Context, *MoveRoomRequest) (*Room, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MoveRoom not implemented")
 }
-func (UnimplementedRoomGrpcServiceServer) GetRoomMembers(context.Context, *GetRoomRequest) (*RoomMembers, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetRoomMembers not implemented")
+func (UnimplementedSpaceGrpcServiceServer) ListSpaceRooms(context.Context, *ListSpaceRoomsRequest) (*ListSpaceRoomsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSpaceRooms not implemented")
 }
-func (UnimplementedRoomGrpcServiceServer) mustEmbedUnimplementedRoomGrpcServiceServer() {}
-func (UnimplementedRoomGrpcServiceServer) testEmbeddedByValue()                         {}
+func (UnimplementedSpaceGrpcServiceServer) mustEmbedUnimplementedSpaceGrpcServiceServer() {}
+func (UnimplementedSpaceGrpcServiceServer) testEmbeddedByValue()                          {}
 
-// UnsafeRoomGrpcServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to RoomGrpcServiceServer will
+// UnsafeSpaceGrpcServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to SpaceGrpcServiceServer will
 // result in compilation errors.
-type UnsafeRoomGrpcServiceServer interface {
-	mustEmbedUnimplementedRoomGrpcServiceServer()
+type UnsafeSpaceGrpcServiceServer interface {
+	mustEmbedUnimplementedSpaceGrpcServiceServer()
 }
 
-func RegisterRoomGrpcServiceServer(s grpc.ServiceRegistrar, srv RoomGrpcServiceServer) {
-	// If the following call pancis, it indicates UnimplementedRoomGrpcServiceServer was
+func RegisterSpaceGrpcServiceServer(s grpc.ServiceRegistrar, srv SpaceGrpcServiceServer) {
+	// If the following call pancis, it indicates UnimplementedSpaceGrpcServiceServer was
 	// embedded by pointer and is nil.  This will cause panics if an
 	// unimplemented method is ever invoked, so we test this at initialization
 	// time to prevent it from happening at runtime later due to I/O.
 	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
 		t.testEmbeddedByValue()
 	}
-	s.RegisterService(&RoomGrpcService_ServiceDesc, srv)
-}
-
-func _RoomGrpcService_CreateRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreateRoomRequest)
-	if err := dec(in); err != nil {
-		return nil, err
-	}
-	if interceptor == nil {
-		return srv.(RoomGrpcServiceServer).CreateRoom(ctx, in)
-	}
-	info := &grpc.UnaryServerInfo{
-		Server:     srv,
-		FullMethod: RoomGrpcService_CreateRoom_FullMethodName,
-	}
-	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomGrpcServiceServer).CreateRoom(ctx, req.(*CreateRoomRequest))
-	}
-	return interceptor(ctx, in, info, handler)
+	s.RegisterService(&SpaceGrpcService_ServiceDesc, srv)
 }
 
-func _RoomGrpcService_ListRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(emptypb.Empty)
+func _SpaceGrpcService_CreateSpace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateSpaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomGrpcServiceServer).ListRooms(ctx, in)
+		return srv.(SpaceGrpcServiceServer).CreateSpace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomGrpcService_ListRooms_FullMethodName,
+		FullMethod: SpaceGrpcService_CreateSpace_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomGrpcServiceServer).ListRooms(ctx, req.(*emptypb.Empty))
+		return srv.(SpaceGrpcServiceServer).CreateSpace(ctx, req.(*CreateSpaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomGrpcService_JoinRoom_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(JoinRoomRequest)
-	if err := stream.RecvMsg(m); err != nil {
-		return err
-	}
-	return srv.(RoomGrpcServiceServer).JoinRoom(m, &grpc.GenericServerStream[JoinRoomRequest, RoomEvent]{ServerStream: stream})
-}
-
-// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
-type RoomGrpcService_JoinRoomServer = grpc.ServerStreamingServer[RoomEvent]
-
-func _RoomGrpcService_LeaveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LeaveRoomRequest)
+func _SpaceGrpcService_JoinSpace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(JoinSpaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomGrpcServiceServer).LeaveRoom(ctx, in)
+		return srv.(SpaceGrpcServiceServer).JoinSpace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomGrpcService_LeaveRoom_FullMethodName,
+		FullMethod: SpaceGrpcService_JoinSpace_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomGrpcServiceServer).LeaveRoom(ctx, req.(*LeaveRoomRequest))
+		return srv.(SpaceGrpcServiceServer).JoinSpace(ctx, req.(*JoinSpaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomGrpcService_GetRoomStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(RoomID)
+func _SpaceGrpcService_AddSpaceMember_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddSpaceMemberRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomGrpcServiceServer).GetRoomStats(ctx, in)
+		return srv.(SpaceGrpcServiceServer).AddSpaceMember(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomGrpcService_GetRoomStats_FullMethodName,
+		FullMethod: SpaceGrpcService_AddSpaceMember_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomGrpcServiceServer).GetRoomStats(ctx, req.(*RoomID))
+		return srv.(SpaceGrpcServiceServer).AddSpaceMember(ctx, req.(*AddSpaceMemberRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomGrpcService_GetRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRoomRequest)
+func _SpaceGrpcService_AddRoomToSpace_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddRoomToSpaceRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomGrpcServiceServer).GetRoom(ctx, in)
+		return srv.(SpaceGrpcServiceServer).AddRoomToSpace(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomGrpcService_GetRoom_FullMethodName,
+		FullMethod: SpaceGrpcService_AddRoomToSpace_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomGrpcServiceServer).GetRoom(ctx, req.(*GetRoomRequest))
+		return srv.(SpaceGrpcServiceServer).AddRoomToSpace(ctx, req.(*AddRoomToSpaceRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomGrpcService_DeleteRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(DeleteRoomRequest)
+func _SpaceGrpcService_MoveRoom_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MoveRoomRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomGrpcServiceServer).DeleteRoom(ctx, in)
+		return srv.(SpaceGrpcServiceServer).MoveRoom(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomGrpcService_DeleteRoom_FullMethodName,
+		FullMethod: SpaceGrpcService_MoveRoom_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomGrpcServiceServer).DeleteRoom(ctx, req.(*DeleteRoomRequest))
+		return srv.(SpaceGrpcServiceServer).MoveRoom(ctx, req.(*MoveRoomRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _RoomGrpcService_GetRoomMembers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(GetRoomRequest)
+func _SpaceGrpcService_ListSpaceRooms_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSpaceRoomsRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(RoomGrpcServiceServer).GetRoomMembers(ctx, in)
+		return srv.(SpaceGrpcServiceServer).ListSpaceRooms(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: RoomGrpcService_GetRoomMembers_FullMethodName,
+		FullMethod: SpaceGrpcService_ListSpaceRooms_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(RoomGrpcServiceServer).GetRoomMembers(ctx, req.(*GetRoomRequest))
+		return srv.(SpaceGrpcServiceServer).ListSpaceRooms(ctx, req.(*ListSpaceRoomsRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// RoomGrpcService_ServiceDesc is the grpc.ServiceDesc for RoomGrpcService service.
+// SpaceGrpcService_ServiceDesc is the grpc.ServiceDesc for SpaceGrpcService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var RoomGrpcService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "chat.RoomGrpcService",
-	HandlerType: (*RoomGrpcServiceServer)(nil),
+var SpaceGrpcService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "chat.SpaceGrpcService",
+	HandlerType: (*SpaceGrpcServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
-			MethodName: "CreateRoom",
-			Handler:    _RoomGrpcService_CreateRoom_Handler,
-		},
-		{
-			MethodName: "ListRooms",
-			Handler:    _RoomGrpcService_ListRooms_Handler,
-		},
-		{
-			MethodName: "LeaveRoom",
-			Handler:    _RoomGrpcService_LeaveRoom_Handler,
+			MethodName: "CreateSpace",
+			Handler:    _SpaceGrpcService_CreateSpace_Handler,
 		},
 		{
-			MethodName: "GetRoomStats",
-			Handler:    _RoomGrpcService_GetRoomStats_Handler,
+			MethodName: "JoinSpace",
+			Handler:    _SpaceGrpcService_JoinSpace_Handler,
 		},
 		{
-			MethodName: "GetRoom",
-			Handler:    _RoomGrpcService_GetRoom_Handler,
+			MethodName: "AddSpaceMember",
+			Handler:    _SpaceGrpcService_AddSpaceMember_Handler,
 		},
 		{
-			MethodName: "DeleteRoom",
-			Handler:    _RoomGrpcService_DeleteRoom_Handler,
+			MethodName: "AddRoomToSpace",
+			Handler:    _SpaceGrpcService_AddRoomToSpace_Handler,
 		},
 		{
-			MethodName: "GetRoomMembers",
-			Handler:    _RoomGrpcService_GetRoomMembers_Handler,
+			MethodName: "MoveRoom",
+			Handler:    _SpaceGrpcService_MoveRoom_Handler,
 		},
-	},
-	Streams: []grpc.StreamDesc{
 		{
-			StreamName:    "JoinRoom",
-			Handler:       _RoomGrpcService_JoinRoom_Handler,
-			ServerStreams: true,
+			MethodName: "ListSpaceRooms",
+			Handler:    _SpaceGrpcService_ListSpaceRooms_Handler,
 		},
 	},
+	Streams:  []grpc.StreamDesc{},
 	Metadata: "internal/pb/server.proto",
 }
 
@@ -0,0 +1,102 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// archivedRoomsKey holds the archived rooms scored by the time they are due for deletion
+const archivedRoomsKey = "rooms:archived"
+
+// archiveRoomScript marks the room hash KEYS[1] as archived, schedules its deletion in KEYS[2]
+// and takes it out of the unarchived rooms KEYS[3]
+var archiveRoomScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'archived_at', ARGV[2], 'archived_by', ARGV[3], 'purge_at', ARGV[4])
+redis.call('ZADD', KEYS[2], ARGV[5], ARGV[1])
+redis.call('SREM', KEYS[3], ARGV[1])
+return 1
+`)
+
+// unarchiveRoomScript undoes archiveRoomScript, only putting back in the unarchived rooms
+// a room that still exists
+var unarchiveRoomScript = redis.NewScript(`
+redis.call('ZREM', KEYS[2], ARGV[1])
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HDEL', KEYS[1], 'archived_at', 'archived_by', 'purge_at')
+redis.call('SADD', KEYS[3], ARGV[1])
+return 1
+`)
+
+// claimPurgeScript moves up to ARGV[3] rooms due before ARGV[1] to ARGV[2], so each room is purged
+// by a single node and claimed again when its purge did not remove it from the schedule
+var claimPurgeScript = redis.NewScript(`
+local due = redis.call('ZRANGEBYSCORE', KEYS[1], '-inf', ARGV[1], 'LIMIT', 0, ARGV[3])
+for _, roomID in ipairs(due) do
+	redis.call('ZADD', KEYS[1], 'XX', ARGV[2], roomID)
+end
+return due
+`)
+
+func (r *RedisRepository) ArchiveRoom(ctx context.Context, roomID, userID string, archivedAt, purgeAt time.Time) error {
+	keys := []string{fmt.Sprintf(roomKeyFormat, roomKey, roomID), archivedRoomsKey, roomsUnarchivedKey}
+	archived, err := archiveRoomScript.Run(ctx, r.client, keys,
+		roomID,
+		archivedAt.Format(time.RFC3339),
+		userID,
+		purgeAt.Format(time.RFC3339),
+		purgeAt.UnixMilli(),
+	).Int()
+	if err != nil {
+		return err
+	}
+	if archived == 0 {
+		return ErrRoomNotFound
+	}
+	return nil
+}
+
+func (r *RedisRepository) UnarchiveRoom(ctx context.Context, roomID string) error {
+	keys := []string{fmt.Sprintf(roomKeyFormat, roomKey, roomID), archivedRoomsKey, roomsUnarchivedKey}
+	return unarchiveRoomScript.Run(ctx, r.client, keys, roomID).Err()
+}
+
+// ClaimRoomsToPurge returns the archived rooms due before now and reschedules them to until
+func (r *RedisRepository) ClaimRoomsToPurge(ctx context.Context, now, until time.Time, limit int) ([]string, error) {
+	keys := []string{archivedRoomsKey}
+	return claimPurgeScript.Run(ctx, r.client, keys, now.UnixMilli(), until.UnixMilli(), limit).StringSlice()
+}
+
+// PurgeArchivedRoom watches the room hash along with its invites and sessions, so an unarchive or a new
+// invite landing after the check fails the deletion instead of being wiped with the room
+func (r *RedisRepository) PurgeArchivedRoom(ctx context.Context, roomID string, now time.Time) (bool, error) {
+	key := fmt.Sprintf(roomKeyFormat, roomKey, roomID)
+	purged := false
+	err := r.client.Watch(ctx, func(tx *redis.Tx) error {
+		purgeAt, err := tx.HGet(ctx, key, "purge_at").Result()
+		if errors.Is(err, redis.Nil) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if due, err := time.Parse(time.RFC3339, purgeAt); err != nil || due.After(now) {
+			return err
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			return r.queueRoomDeletion(ctx, pipe, roomID)
+		})
+		purged = err == nil
+		return err
+	}, key, fmt.Sprintf(roomInvitesKeyFormat, roomID), fmt.Sprintf(roomPresenceKeyFormat, roomID))
+	return purged, err
+}
@@ -0,0 +1,118 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+const (
+	purgeBatchSize = 100
+	// purgeClaimLease is how long a claimed room stays off the schedule, a room whose purge failed
+	// or whose node stopped is claimed again once it is over
+	purgeClaimLease = 10 * time.Minute
+	purgeReason     = "the archived room reached the end of its grace period"
+)
+
+var (
+	ErrRoomArchived    = errors.New("room is archived")
+	ErrRoomNotArchived = errors.New("room is not archived")
+)
+
+// ArchiveRoom makes the room read-only and hides it from the default room listing,
+// members are kept until the grace period ends and the room is deleted
+func (s *RoomService) ArchiveRoom(ctx context.Context, roomID, userID string) (*Room, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.CreatedBy != userID {
+		return nil, ErrNotRoomOwner
+	}
+	if room.IsArchived() {
+		return nil, ErrRoomArchived
+	}
+
+	room.ArchivedAt = time.Now()
+	room.ArchivedBy = userID
+	room.PurgeAt = room.ArchivedAt.Add(s.archiveGracePeriod)
+	if err := s.repo.ArchiveRoom(ctx, roomID, userID, room.ArchivedAt, room.PurgeAt); err != nil {
+		return nil, err
+	}
+
+	s.broadcastRoomUpdate(room, userID)
+	return room, nil
+}
+
+// UnarchiveRoom restores an archived room and cancels its scheduled deletion
+func (s *RoomService) UnarchiveRoom(ctx context.Context, roomID, userID string) (*Room, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.CreatedBy != userID {
+		return nil, ErrNotRoomOwner
+	}
+	if !room.IsArchived() {
+		return nil, ErrRoomNotArchived
+	}
+
+	if err := s.repo.UnarchiveRoom(ctx, roomID); err != nil {
+		return nil, err
+	}
+	room.ArchivedAt = time.Time{}
+	room.ArchivedBy = ""
+	room.PurgeAt = time.Time{}
+
+	s.broadcastRoomUpdate(room, userID)
+	return room, nil
+}
+
+// RunArchivePurger deletes archived rooms whose grace period is over until ctx is done,
+// it can run on every node since each due room is claimed by a single one
+func (s *RoomService) RunArchivePurger(ctx context.Context) {
+	ticker := time.NewTicker(s.archivePurgeInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			now := time.Now()
+			roomIDs, err := s.repo.ClaimRoomsToPurge(ctx, now, now.Add(purgeClaimLease), purgeBatchSize)
+			if err != nil {
+				log.Printf("Failed to claim archived rooms: %v", err)
+				continue
+			}
+
+			for _, roomID := range roomIDs {
+				if err := s.purgeRoom(ctx, roomID, now); err != nil {
+					log.Printf("Failed to purge archived room %s, it is retried in %s: %v", roomID, purgeClaimLease, err)
+				}
+			}
+		}
+	}
+}
+
+// purgeRoom deletes a claimed room unless it was unarchived or archived again since it was scheduled,
+// the store checks that as part of the deletion
+func (s *RoomService) purgeRoom(ctx context.Context, roomID string, now time.Time) error {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if errors.Is(err, ErrRoomNotFound) {
+		// drop what is left of a room deleted since it was scheduled
+		return s.repo.UnarchiveRoom(ctx, roomID)
+	}
+	if err != nil {
+		return err
+	}
+
+	purged, err := s.repo.PurgeArchivedRoom(ctx, roomID, now)
+	if err != nil || !purged {
+		return err
+	}
+	return s.roomDeleted(roomID, room.IsPrivate, "", purgeReason)
+}
@@ -0,0 +1,448 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// roomCacheVersionKeyFormat counts the invalidations of a cached room, a copy read from the store
+// is only cached while the count is the one seen before the read
+const roomCacheVersionKeyFormat = "room:%s:cache_version"
+
+// CachedRepository keeps rooms and their memberships in a RoomStore and uses Redis as a
+// read-through cache in front of it. Everything short-lived or tied to Redis stays in Redis
+// only: presence, pub/sub, mutes, slow mode and the layout of spaces.
+//
+// A cached room is its hash, member set and roles hash, loaded together on a miss and
+// dropped together after every write to the store. They expire after ttl. Every drop bumps the
+// cache version of the room, so a read of the store that a write overtook does not cache its copy.
+type CachedRepository struct {
+	*RedisRepository
+	store RoomStore
+	ttl   time.Duration
+}
+
+func NewCachedRepository(store RoomStore, cache *RedisRepository, ttl time.Duration) *CachedRepository {
+	return &CachedRepository{RedisRepository: cache, store: store, ttl: ttl}
+}
+
+func (c *CachedRepository) CreateRoom(ctx context.Context, room *Room) error {
+	if err := c.store.CreateRoom(ctx, room); err != nil {
+		return err
+	}
+	c.cacheRoom(ctx, room, nil, nil)
+	return nil
+}
+
+// GetRoom serves the room from Redis and loads it from the store on a miss. The cache version
+// is read before the store so that a write committed meanwhile keeps the copy out of the cache.
+func (c *CachedRepository) GetRoom(ctx context.Context, roomID string) (*Room, error) {
+	room, err := c.RedisRepository.GetRoom(ctx, roomID)
+	if !errors.Is(err, ErrRoomNotFound) {
+		return room, err
+	}
+
+	version, err := c.client.Get(ctx, fmt.Sprintf(roomCacheVersionKeyFormat, roomID)).Result()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	room, err = c.store.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	members, err := c.store.GetRoomMembers(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := c.store.GetMemberRoles(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	c.fillCache(ctx, version, room, members, roles)
+	return room, nil
+}
+
+func (c *CachedRepository) UpdateRoom(ctx context.Context, room *Room) error {
+	return c.invalidateAfter(ctx, room.ID, c.store.UpdateRoom(ctx, room))
+}
+
+// DeleteRoom loads the room first so its space and invites are cleaned from Redis as well,
+// the cache version is bumped last for a read of the room that started before the delete
+func (c *CachedRepository) DeleteRoom(ctx context.Context, roomID string) error {
+	if _, err := c.GetRoom(ctx, roomID); err != nil && !errors.Is(err, ErrRoomNotFound) {
+		return err
+	}
+	if err := c.store.DeleteRoom(ctx, roomID); err != nil {
+		return err
+	}
+	return c.invalidateAfter(ctx, roomID, c.RedisRepository.DeleteRoom(ctx, roomID))
+}
+
+func (c *CachedRepository) RoomExists(ctx context.Context, roomID string) (bool, error) {
+	_, err := c.GetRoom(ctx, roomID)
+	if errors.Is(err, ErrRoomNotFound) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+func (c *CachedRepository) ListRoomIDs(ctx context.Context) ([]string, error) {
+	return c.store.ListRoomIDs(ctx)
+}
+
+func (c *CachedRepository) ListRooms(ctx context.Context, opts RoomListOptions) ([]*Room, string, error) {
+	return c.store.ListRooms(ctx, opts)
+}
+
+func (c *CachedRepository) SearchRoomsPage(ctx context.Context, query, userID string, after *searchCursor, limit int) ([]RoomMatch, error) {
+	return c.store.SearchRoomsPage(ctx, query, userID, after, limit)
+}
+
+// room templates are only read when a room is created from one, they are left out of the cache
+func (c *CachedRepository) CreateRoomTemplate(ctx context.Context, template *RoomTemplate) error {
+	return c.store.CreateRoomTemplate(ctx, template)
+}
+
+func (c *CachedRepository) GetRoomTemplate(ctx context.Context, templateID string) (*RoomTemplate, error) {
+	return c.store.GetRoomTemplate(ctx, templateID)
+}
+
+func (c *CachedRepository) ListRoomTemplates(ctx context.Context) ([]*RoomTemplate, error) {
+	return c.store.ListRoomTemplates(ctx)
+}
+
+// CreateRoomWithSetup creates the room and its members in one store transaction, then writes the
+// metadata and messages to Redis, where they live. They are not part of the transaction: a failure
+// to write them is logged and leaves the created room without them.
+func (c *CachedRepository) CreateRoomWithSetup(ctx context.Context, room *Room, setup RoomSetup) error {
+	if err := c.store.CreateRoomWithMembers(ctx, room, setup.Members); err != nil {
+		return err
+	}
+
+	pipe := c.client.TxPipeline()
+	err := queueRoomContent(ctx, pipe, room.ID, setup)
+	if err == nil {
+		_, err = pipe.Exec(ctx)
+	}
+	if err != nil {
+		log.Printf("Failed to write the metadata and messages of room %s: %v", room.ID, err)
+	}
+	return nil
+}
+
+func (c *CachedRepository) ArchiveRoom(ctx context.Context, roomID, userID string, archivedAt, purgeAt time.Time) error {
+	return c.invalidateAfter(ctx, roomID, c.store.ArchiveRoom(ctx, roomID, userID, archivedAt, purgeAt))
+}
+
+func (c *CachedRepository) UnarchiveRoom(ctx context.Context, roomID string) error {
+	return c.invalidateAfter(ctx, roomID, c.store.UnarchiveRoom(ctx, roomID))
+}
+
+func (c *CachedRepository) ClaimRoomsToPurge(ctx context.Context, now, until time.Time, limit int) ([]string, error) {
+	return c.store.ClaimRoomsToPurge(ctx, now, until, limit)
+}
+
+// PurgeArchivedRoom drops the Redis side of the room only once the store deleted it
+func (c *CachedRepository) PurgeArchivedRoom(ctx context.Context, roomID string, now time.Time) (bool, error) {
+	purged, err := c.store.PurgeArchivedRoom(ctx, roomID, now)
+	if err != nil || !purged {
+		return purged, err
+	}
+	return true, c.RedisRepository.DeleteRoom(ctx, roomID)
+}
+
+// invites are kept by the store next to the memberships, a join by invite is one store transaction
+func (c *CachedRepository) CreateInvite(ctx context.Context, invite *InviteLink) error {
+	return c.store.CreateInvite(ctx, invite)
+}
+
+func (c *CachedRepository) GetInvite(ctx context.Context, code string) (*InviteLink, error) {
+	return c.store.GetInvite(ctx, code)
+}
+
+func (c *CachedRepository) ListInvites(ctx context.Context, roomID string) ([]*InviteLink, error) {
+	return c.store.ListInvites(ctx, roomID)
+}
+
+func (c *CachedRepository) DeleteInvite(ctx context.Context, roomID, code string) error {
+	return c.store.DeleteInvite(ctx, roomID, code)
+}
+
+func (c *CachedRepository) JoinByInvite(ctx context.Context, code, userID string) (bool, error) {
+	invite, err := c.store.GetInvite(ctx, code)
+	if err != nil {
+		return false, err
+	}
+	joined, err := c.store.JoinByInvite(ctx, code, userID)
+	if !joined {
+		return false, err
+	}
+	return true, c.invalidateAfter(ctx, invite.RoomID, nil)
+}
+
+func (c *CachedRepository) AddRoomMember(ctx context.Context, roomID, userID string) error {
+	return c.invalidateAfter(ctx, roomID, c.store.AddRoomMember(ctx, roomID, userID))
+}
+
+func (c *CachedRepository) RemoveRoomMember(ctx context.Context, roomID, userID string) error {
+	return c.invalidateAfter(ctx, roomID, c.store.RemoveRoomMember(ctx, roomID, userID))
+}
+
+func (c *CachedRepository) QueueRoomMember(ctx context.Context, roomID, userID string) (int, error) {
+	position, err := c.store.QueueRoomMember(ctx, roomID, userID)
+	return position, c.invalidateAfter(ctx, roomID, err)
+}
+
+func (c *CachedRepository) PromoteWaitlist(ctx context.Context, roomID string) ([]string, error) {
+	promoted, err := c.store.PromoteWaitlist(ctx, roomID)
+	if len(promoted) == 0 {
+		return promoted, err
+	}
+	return promoted, c.invalidateAfter(ctx, roomID, err)
+}
+
+func (c *CachedRepository) RemoveFromWaitlist(ctx context.Context, roomID, userID string) error {
+	return c.store.RemoveFromWaitlist(ctx, roomID, userID)
+}
+
+func (c *CachedRepository) RemoveAllMembers(ctx context.Context, roomID string) error {
+	return c.invalidateAfter(ctx, roomID, c.store.RemoveAllMembers(ctx, roomID))
+}
+
+func (c *CachedRepository) GetRoomMembers(ctx context.Context, roomID string) ([]string, error) {
+	if err := c.ensureCached(ctx, roomID); err != nil {
+		return nil, err
+	}
+	return c.RedisRepository.GetRoomMembers(ctx, roomID)
+}
+
+func (c *CachedRepository) IsRoomMember(ctx context.Context, roomID, userID string) (bool, error) {
+	if err := c.ensureCached(ctx, roomID); err != nil {
+		return false, err
+	}
+	return c.RedisRepository.IsRoomMember(ctx, roomID, userID)
+}
+
+// ListUserRooms goes to the store, the cache only holds the member sets of the rooms recently read
+func (c *CachedRepository) ListUserRooms(ctx context.Context, userID string) ([]string, error) {
+	return c.store.ListUserRooms(ctx, userID)
+}
+
+// TouchRoomActivity updates the cached copy in place, it runs on every message
+func (c *CachedRepository) TouchRoomActivity(ctx context.Context, roomID string, at time.Time) error {
+	if err := c.store.TouchRoomActivity(ctx, roomID, at); err != nil {
+		return err
+	}
+	return c.RedisRepository.TouchRoomActivity(ctx, roomID, at)
+}
+
+func (c *CachedRepository) SetMemberRole(ctx context.Context, roomID, userID string, role MemberRole) error {
+	return c.invalidateAfter(ctx, roomID, c.store.SetMemberRole(ctx, roomID, userID, role))
+}
+
+func (c *CachedRepository) GetMemberRole(ctx context.Context, roomID, userID string) (MemberRole, error) {
+	if err := c.ensureCached(ctx, roomID); err != nil {
+		return RoleMember, err
+	}
+	return c.RedisRepository.GetMemberRole(ctx, roomID, userID)
+}
+
+func (c *CachedRepository) GetMemberRoles(ctx context.Context, roomID string) (map[string]MemberRole, error) {
+	if err := c.ensureCached(ctx, roomID); err != nil {
+		return nil, err
+	}
+	return c.RedisRepository.GetMemberRoles(ctx, roomID)
+}
+
+func (c *CachedRepository) TransferOwnership(ctx context.Context, roomID, ownerID, newOwnerID string) error {
+	return c.invalidateAfter(ctx, roomID, c.store.TransferOwnership(ctx, roomID, ownerID, newOwnerID))
+}
+
+// GetMemberJoinTimes is not cached, it is only read when ownership is handed over
+func (c *CachedRepository) GetMemberJoinTimes(ctx context.Context, roomID string) (map[string]time.Time, error) {
+	return c.store.GetMemberJoinTimes(ctx, roomID)
+}
+
+func (c *CachedRepository) ListRoomMembersPage(ctx context.Context, roomID string, after *memberCursor, limit int) ([]MemberInfo, error) {
+	return c.store.ListRoomMembersPage(ctx, roomID, after, limit)
+}
+
+// PlaceRoomInSpace updates the space layout in Redis, which checks the move, then records
+// the space of the room in the store
+func (c *CachedRepository) PlaceRoomInSpace(ctx context.Context, spaceID, roomID, category string, position int) error {
+	if _, err := c.GetRoom(ctx, roomID); err != nil {
+		return err
+	}
+	if err := c.RedisRepository.PlaceRoomInSpace(ctx, spaceID, roomID, category, position); err != nil {
+		return err
+	}
+	return c.invalidateAfter(ctx, roomID, c.store.SetRoomSpace(ctx, roomID, spaceID, category))
+}
+
+// ListSpaceRooms resolves the rooms of the space layout through the cache
+func (c *CachedRepository) ListSpaceRooms(ctx context.Context, spaceID string) ([]SpaceCategory, error) {
+	names, roomIDs, err := c.spaceLayout(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]SpaceCategory, len(names))
+	for i, name := range names {
+		categories[i] = SpaceCategory{Name: name, Rooms: make([]*Room, 0, len(roomIDs[i]))}
+		for _, roomID := range roomIDs[i] {
+			room, err := c.GetRoom(ctx, roomID)
+			if errors.Is(err, ErrRoomNotFound) {
+				continue
+			}
+			if err != nil {
+				return nil, err
+			}
+			categories[i].Rooms = append(categories[i].Rooms, room)
+		}
+	}
+	return categories, nil
+}
+
+// ensureCached loads the room into the cache, an unknown room simply has no members
+func (c *CachedRepository) ensureCached(ctx context.Context, roomID string) error {
+	_, err := c.GetRoom(ctx, roomID)
+	if errors.Is(err, ErrRoomNotFound) {
+		return nil
+	}
+	return err
+}
+
+// cacheRoom replaces the cached copy of the room, a cache that cannot be written is only logged
+// since the store already holds the data
+func (c *CachedRepository) cacheRoom(ctx context.Context, room *Room, members []string, roles map[string]MemberRole) {
+	pipe := c.client.TxPipeline()
+	c.queueRoomCache(ctx, pipe, room, members, roles)
+	if _, err := pipe.Exec(ctx); err != nil {
+		log.Printf("Failed to cache room %s: %v", room.ID, err)
+	}
+}
+
+// fillCache caches the room read from the store unless the cache version moved past version,
+// in which case a write was invalidated after the read began and the copy may be stale
+func (c *CachedRepository) fillCache(ctx context.Context, version string, room *Room, members []string, roles map[string]MemberRole) {
+	versionKey := fmt.Sprintf(roomCacheVersionKeyFormat, room.ID)
+	err := c.client.Watch(ctx, func(tx *redis.Tx) error {
+		current, err := tx.Get(ctx, versionKey).Result()
+		if err != nil && !errors.Is(err, redis.Nil) {
+			return err
+		}
+		if current != version {
+			return nil
+		}
+
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			c.queueRoomCache(ctx, pipe, room, members, roles)
+			return nil
+		})
+		return err
+	}, versionKey)
+	if err != nil && !errors.Is(err, redis.TxFailedErr) {
+		log.Printf("Failed to cache room %s: %v", room.ID, err)
+	}
+}
+
+func (c *CachedRepository) queueRoomCache(ctx context.Context, pipe redis.Pipeliner, room *Room, members []string, roles map[string]MemberRole) {
+	keys := c.roomCacheKeys(room.ID)
+	pipe.Del(ctx, keys...)
+	pipe.HSet(ctx, keys[0], roomFields(room)...)
+	if len(members) > 0 {
+		values := make([]interface{}, len(members))
+		for i, member := range members {
+			values[i] = member
+		}
+		pipe.SAdd(ctx, keys[1], values...)
+	}
+	for userID, role := range roles {
+		pipe.HSet(ctx, keys[2], userID, int(role))
+	}
+	for _, key := range keys {
+		pipe.PExpire(ctx, key, c.ttl)
+	}
+}
+
+// FindRoomInconsistencies scans the room keys of Redis for rooms the store no longer has, e.g.
+// deleted along with their owner's account. The store keeps rooms and memberships consistent
+// itself. The scan comes before the store is read, so a room created meanwhile is never reported.
+// Cache versions are left out, they outlive deleted rooms on purpose and expire on their own.
+func (c *CachedRepository) FindRoomInconsistencies(ctx context.Context) ([]RoomInconsistency, error) {
+	cached := make(map[string]struct{})
+	iter := c.client.Scan(ctx, 0, roomKey+":*", reconcileScanCount).Iterator()
+	for iter.Next(ctx) {
+		roomID, rest, _ := strings.Cut(strings.TrimPrefix(iter.Val(), roomKey+":"), ":")
+		if rest == "cache_version" {
+			continue
+		}
+		cached[roomID] = struct{}{}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	roomIDs, err := c.store.ListRoomIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range roomIDs {
+		delete(cached, id)
+	}
+
+	found := make([]RoomInconsistency, 0, len(cached))
+	for roomID := range cached {
+		found = append(found, RoomInconsistency{Kind: InconsistencyOrphanedRoomKeys, RoomID: roomID})
+	}
+	return found, nil
+}
+
+// RepairRoomInconsistency deletes the Redis keys of a room once the store confirms it is gone
+func (c *CachedRepository) RepairRoomInconsistency(ctx context.Context, inconsistency RoomInconsistency) error {
+	if inconsistency.Kind != InconsistencyOrphanedRoomKeys {
+		return fmt.Errorf("cannot repair inconsistency of kind %d", inconsistency.Kind)
+	}
+
+	_, err := c.store.GetRoom(ctx, inconsistency.RoomID)
+	if err == nil {
+		return nil
+	}
+	if !errors.Is(err, ErrRoomNotFound) {
+		return err
+	}
+	if err := c.client.Del(ctx, c.roomCacheKeys(inconsistency.RoomID)...).Err(); err != nil {
+		return err
+	}
+	return c.deleteRoomRemains(ctx, inconsistency.RoomID, false)
+}
+
+// invalidateAfter drops the cached copy of the room once the store write err went through and
+// bumps its cache version, which outlives the copy so a fill still in flight sees it moved
+func (c *CachedRepository) invalidateAfter(ctx context.Context, roomID string, err error) error {
+	if err != nil {
+		return err
+	}
+	versionKey := fmt.Sprintf(roomCacheVersionKeyFormat, roomID)
+	pipe := c.client.TxPipeline()
+	pipe.Del(ctx, c.roomCacheKeys(roomID)...)
+	pipe.Incr(ctx, versionKey)
+	pipe.PExpire(ctx, versionKey, c.ttl)
+	_, err = pipe.Exec(ctx)
+	return err
+}
+
+func (c *CachedRepository) roomCacheKeys(roomID string) []string {
+	return []string{
+		fmt.Sprintf(roomKeyFormat, roomKey, roomID),
+		fmt.Sprintf(roomMembersKeyFormat, roomID),
+		fmt.Sprintf(roomRolesKeyFormat, roomID),
+	}
+}
@@ -0,0 +1,64 @@
+package room
+
+import (
+	"log"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *RoomHandler) WatchRooms(req *pb.WatchRoomsRequest, stream pb.RoomGrpcService_WatchRoomsServer) error {
+	userID, ok := stream.Context().Value("user_id").(uuid.UUID)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	err := h.service.WatchRooms(stream.Context(), userID.String(), func(event DirectoryEvent) error {
+		resp := convertToPbDirectoryEvent(event)
+		if resp == nil {
+			log.Printf("Skipping directory event of unknown type %d", event.Type)
+			return nil
+		}
+		return stream.Send(resp)
+	})
+	if err != nil {
+		return statusFromError(err, "failed to watch rooms")
+	}
+	return nil
+}
+
+func convertToPbDirectoryEvent(event DirectoryEvent) *pb.RoomDirectoryEvent {
+	resp := &pb.RoomDirectoryEvent{RoomId: event.RoomID}
+	switch event.Type {
+	case DirectorySnapshot:
+		rooms := make([]*pb.Room, len(event.Rooms))
+		for i, room := range event.Rooms {
+			rooms[i] = convertToPbRoom(room)
+		}
+		resp.Event = &pb.RoomDirectoryEvent_Snapshot{
+			Snapshot: &pb.RoomDirectorySnapshot{Rooms: rooms, Complete: event.SnapshotDone},
+		}
+	case DirectoryRoomCreated:
+		resp.Event = &pb.RoomDirectoryEvent_RoomCreated{RoomCreated: convertToPbRoom(event.Room)}
+	case DirectoryRoomUpdated:
+		resp.Event = &pb.RoomDirectoryEvent_RoomUpdated{RoomUpdated: convertToPbRoom(event.Room)}
+	case DirectoryRoomDeleted:
+		deleted := &pb.RoomDeleted{DeletedBy: event.UserID}
+		if event.Deletion != nil {
+			deleted.Reason = event.Deletion.Reason
+		}
+		resp.Event = &pb.RoomDirectoryEvent_RoomDeleted{RoomDeleted: deleted}
+	case DirectoryMemberCountChanged:
+		resp.Event = &pb.RoomDirectoryEvent_MemberCountChanged{
+			MemberCountChanged: &pb.MemberCountChanged{Delta: int32(event.MemberDelta)},
+		}
+	case DirectoryRoomHidden:
+		resp.Event = &pb.RoomDirectoryEvent_RoomHidden{RoomHidden: &emptypb.Empty{}}
+	default:
+		return nil
+	}
+	return resp
+}
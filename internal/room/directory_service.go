@@ -0,0 +1,157 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+)
+
+// WatchRooms passes the rooms userID can see to send, starting with a snapshot made of
+// DirectorySnapshot pages, the last one having SnapshotDone set, then every change until ctx
+// is done. Public rooms are seen by everyone and private rooms by their creator and members. Archived
+// rooms are left out of the snapshot, archiving a room comes as an update.
+func (s *RoomService) WatchRooms(ctx context.Context, userID string, send func(DirectoryEvent) error) error {
+	// subscribing before taking the snapshot so no change falls in between,
+	// a change made meanwhile may show up in the snapshot and again after it
+	sub := s.repo.SubscribeToDirectory(ctx)
+	defer sub.Close()
+	// a blocked Receive does not always give up on ctx, closing the subscription does stop it
+	go func() {
+		<-ctx.Done()
+		sub.Close()
+	}()
+
+	watch := &directoryWatch{service: s, userID: userID, shown: make(map[string]struct{})}
+	if err := watch.sendSnapshot(ctx, send); err != nil {
+		return err
+	}
+
+	for {
+		payload, err := sub.Receive(ctx)
+		if err != nil {
+			if ctx.Err() != nil || errors.Is(err, ErrSubscriptionClosed) {
+				return nil
+			}
+			return err
+		}
+
+		var event DirectoryEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			log.Printf("Failed to decode directory event: %v", err)
+			continue
+		}
+
+		event, visible, err := watch.filter(ctx, event)
+		if err != nil {
+			return err
+		}
+		if !visible {
+			continue
+		}
+		if err := send(event); err != nil {
+			return err
+		}
+	}
+}
+
+// directoryWatch remembers the rooms shown to a watcher, so it hears about them going away
+type directoryWatch struct {
+	service *RoomService
+	userID  string
+	shown   map[string]struct{}
+}
+
+func (w *directoryWatch) sendSnapshot(ctx context.Context, send func(DirectoryEvent) error) error {
+	opts := RoomListOptions{PageSize: maxRoomPageSize, Filter: RoomFilter{VisibleTo: w.userID}}
+	for {
+		rooms, next, err := w.service.repo.ListRooms(ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, room := range rooms {
+			w.shown[room.ID] = struct{}{}
+		}
+
+		event := DirectoryEvent{Type: DirectorySnapshot, Rooms: rooms, SnapshotDone: next == ""}
+		if err := send(event); err != nil {
+			return err
+		}
+		if next == "" {
+			return nil
+		}
+		opts.PageToken = next
+	}
+}
+
+// filter decides whether the watcher gets the event and turns it into what the watcher should
+// hear: a room coming into sight is sent in full and a room going out of sight is hidden
+func (w *directoryWatch) filter(ctx context.Context, event DirectoryEvent) (DirectoryEvent, bool, error) {
+	_, shown := w.shown[event.RoomID]
+	if event.Type == DirectoryRoomDeleted {
+		delete(w.shown, event.RoomID)
+		return event, shown || !event.IsPrivate, nil
+	}
+
+	// a member count change carries no room, it is loaded to check a private room or to show a
+	// room the watcher has not seen yet, e.g. it was just invited
+	room := event.Room
+	if room == nil && (event.IsPrivate || !shown) {
+		var err error
+		room, err = w.service.repo.GetRoom(ctx, event.RoomID)
+		if errors.Is(err, ErrRoomNotFound) {
+			return event, false, nil
+		}
+		if err != nil {
+			return event, false, err
+		}
+	}
+
+	canSee := !event.IsPrivate
+	if !canSee {
+		var err error
+		if canSee, err = w.service.isVisibleRoom(ctx, room, w.userID); err != nil {
+			return event, false, err
+		}
+	}
+
+	switch {
+	case !canSee && shown:
+		delete(w.shown, event.RoomID)
+		return DirectoryEvent{Type: DirectoryRoomHidden, RoomID: event.RoomID}, true, nil
+	case !canSee:
+		return event, false, nil
+	case !shown && event.Room == nil:
+		event = DirectoryEvent{Type: DirectoryRoomUpdated, RoomID: room.ID, IsPrivate: room.IsPrivate, Room: room}
+	}
+
+	w.shown[event.RoomID] = struct{}{}
+	return event, true, nil
+}
+
+// publishDirectoryEvent tells the watchers on every node about a change to the directory
+func (s *RoomService) publishDirectoryEvent(event DirectoryEvent) {
+	if err := s.repo.PublishDirectoryEvent(context.Background(), event); err != nil {
+		log.Printf("Failed to publish directory event: %v", err)
+	}
+}
+
+func (s *RoomService) publishRoomChange(eventType DirectoryEventType, room *Room, userID string) {
+	s.publishDirectoryEvent(DirectoryEvent{
+		Type:      eventType,
+		RoomID:    room.ID,
+		IsPrivate: room.IsPrivate,
+		UserID:    userID,
+		Room:      room,
+	})
+}
+
+func (s *RoomService) publishMemberDelta(room *Room, userID string, delta int) {
+	s.publishDirectoryEvent(DirectoryEvent{
+		Type:        DirectoryMemberCountChanged,
+		RoomID:      room.ID,
+		IsPrivate:   room.IsPrivate,
+		UserID:      userID,
+		MemberDelta: delta,
+	})
+}
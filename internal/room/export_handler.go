@@ -0,0 +1,94 @@
+package room
+
+import (
+	"bufio"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// archiveChunkSize is the largest piece of archive sent in a single message
+const archiveChunkSize = 32 * 1024
+
+func (h *RoomHandler) ExportRoom(req *pb.ExportRoomRequest, stream pb.RoomGrpcService_ExportRoomServer) error {
+	userID, ok := stream.Context().Value("user_id").(uuid.UUID)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	w := bufio.NewWriterSize(archiveChunkWriter{stream: stream}, archiveChunkSize)
+	if err := h.service.ExportRoom(stream.Context(), req.RoomId, userID.String(), w); err != nil {
+		return statusFromError(err, "failed to export room")
+	}
+	if err := w.Flush(); err != nil {
+		return statusFromError(err, "failed to export room")
+	}
+	return nil
+}
+
+func (h *RoomHandler) ImportRoom(stream pb.RoomGrpcService_ImportRoomServer) error {
+	userID, ok := stream.Context().Value("user_id").(uuid.UUID)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	first, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	options := first.GetOptions()
+	if options == nil {
+		return status.Error(codes.InvalidArgument, "the first message must carry the import options")
+	}
+
+	room, err := h.service.ImportRoom(stream.Context(), &archiveChunkReader{stream: stream}, options.UserIdMap, userID.String())
+	if err != nil {
+		return statusFromError(err, "failed to import room")
+	}
+
+	return stream.SendAndClose(convertToPbRoom(room))
+}
+
+// archiveChunkWriter sends what is written to it as archive chunks
+type archiveChunkWriter struct {
+	stream pb.RoomGrpcService_ExportRoomServer
+}
+
+func (w archiveChunkWriter) Write(p []byte) (int, error) {
+	written := 0
+	for written < len(p) {
+		n := min(len(p)-written, archiveChunkSize)
+		chunk := &pb.RoomArchiveChunk{Data: append([]byte(nil), p[written:written+n]...)}
+		if err := w.stream.Send(chunk); err != nil {
+			return written, err
+		}
+		written += n
+	}
+	return written, nil
+}
+
+// archiveChunkReader reads the archive chunks of an import stream as one byte stream
+type archiveChunkReader struct {
+	stream pb.RoomGrpcService_ImportRoomServer
+	buf    []byte
+}
+
+func (r *archiveChunkReader) Read(p []byte) (int, error) {
+	for len(r.buf) == 0 {
+		req, err := r.stream.Recv()
+		if err != nil {
+			return 0, err
+		}
+		chunk := req.GetChunk()
+		if chunk == nil {
+			return 0, ErrInvalidArchive
+		}
+		r.buf = chunk.Data
+	}
+
+	n := copy(p, r.buf)
+	r.buf = r.buf[n:]
+	return n, nil
+}
@@ -0,0 +1,282 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// A room archive is newline-delimited JSON: a header record holding the format version and
+// the room, then one record per member in join order and one per message, oldest first.
+// Records are written and read one at a time so archives of any size can be streamed.
+const roomArchiveVersion = 1
+
+// importBatchSize is the number of messages written to the history at once during an import
+const importBatchSize = 100
+
+var (
+	ErrInvalidArchive     = errors.New("invalid room archive")
+	ErrUnsupportedArchive = fmt.Errorf("unsupported room archive version, this server reads up to version %d", roomArchiveVersion)
+)
+
+type archiveRecord struct {
+	Header  *archiveHeader  `json:"header,omitempty"`
+	Member  *archiveMember  `json:"member,omitempty"`
+	Message *archiveMessage `json:"message,omitempty"`
+}
+
+type archiveHeader struct {
+	Version    int         `json:"version"`
+	ExportedAt time.Time   `json:"exported_at"`
+	Room       archiveRoom `json:"room"`
+}
+
+// archiveRoom holds the settings of the room, its space and archived state belong to the deployment
+type archiveRoom struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	Topic            string    `json:"topic"`
+	Description      string    `json:"description"`
+	AvatarURL        string    `json:"avatar_url"`
+	CreatedAt        time.Time `json:"created_at"`
+	Owner            string    `json:"owner"`
+	IsPrivate        bool      `json:"is_private"`
+	MaxMembers       int       `json:"max_members"`
+	SlowModeMs       int64     `json:"slow_mode_interval_ms"`
+	AnnouncementOnly bool      `json:"announcement_only"`
+}
+
+type archiveMember struct {
+	UserID   string     `json:"user_id"`
+	Role     MemberRole `json:"role"`
+	JoinedAt time.Time  `json:"joined_at"`
+}
+
+type archiveMessage struct {
+	ID        string    `json:"id"`
+	UserID    string    `json:"user_id"`
+	Content   string    `json:"content"`
+	Timestamp time.Time `json:"timestamp"`
+}
+
+// ExportRoom writes the archive of the room to w, only its owner can export it
+func (s *RoomService) ExportRoom(ctx context.Context, roomID, userID string, w io.Writer) error {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if room.CreatedBy != userID {
+		return ErrNotRoomOwner
+	}
+
+	members, err := s.repo.GetRoomMembers(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	roles, err := s.repo.GetMemberRoles(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	joined, err := s.repo.GetMemberJoinTimes(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	messages, err := s.repo.GetMessages(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	messages = retainedMessages(room, messages)
+
+	sortByJoinTime(members, joined)
+
+	enc := json.NewEncoder(w)
+	header := &archiveHeader{
+		Version:    roomArchiveVersion,
+		ExportedAt: time.Now(),
+		Room: archiveRoom{
+			ID:               room.ID,
+			Name:             room.Name,
+			Topic:            room.Topic,
+			Description:      room.Description,
+			AvatarURL:        room.AvatarURL,
+			CreatedAt:        room.CreatedAt,
+			Owner:            room.CreatedBy,
+			IsPrivate:        room.IsPrivate,
+			MaxMembers:       room.MaxMembers,
+			SlowModeMs:       room.SlowModeInterval.Milliseconds(),
+			AnnouncementOnly: room.AnnouncementOnly,
+		},
+	}
+	if err := enc.Encode(archiveRecord{Header: header}); err != nil {
+		return err
+	}
+
+	for _, memberID := range members {
+		member := &archiveMember{UserID: memberID, Role: roles[memberID], JoinedAt: joined[memberID]}
+		if memberID == room.CreatedBy {
+			member.Role = RoleOwner
+		}
+		if err := enc.Encode(archiveRecord{Member: member}); err != nil {
+			return err
+		}
+	}
+
+	for _, msg := range messages {
+		record := archiveRecord{Message: &archiveMessage{
+			ID:        msg.ID,
+			UserID:    msg.UserID,
+			Content:   msg.Content,
+			Timestamp: msg.Timestamp,
+		}}
+		if err := enc.Encode(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ImportRoom recreates the room archived in r under a new ID. User IDs found in userIDs are
+// replaced by their mapping, the others are kept as they are. The import makes the archived
+// users members and authors of the room, so only server admins can run it. A failed import
+// leaves nothing behind.
+func (s *RoomService) ImportRoom(ctx context.Context, r io.Reader, userIDs map[string]string, userID string) (*Room, error) {
+	if !s.IsAdmin(userID) {
+		return nil, ErrNotAdmin
+	}
+
+	mapUser := func(id string) string {
+		if mapped, ok := userIDs[id]; ok {
+			return mapped
+		}
+		return id
+	}
+
+	dec := json.NewDecoder(r)
+	var record archiveRecord
+	if err := dec.Decode(&record); err != nil || record.Header == nil {
+		return nil, ErrInvalidArchive
+	}
+	header := record.Header
+	if header.Version < 1 || header.Version > roomArchiveVersion {
+		return nil, ErrUnsupportedArchive
+	}
+	if header.Room.Name == "" || header.Room.Owner == "" || header.Room.MaxMembers < 0 || header.Room.SlowModeMs < 0 {
+		return nil, ErrInvalidArchive
+	}
+
+	// the capacity is only set once the members are in, a room may hold more members than
+	// its current capacity when it was lowered after they joined
+	room := &Room{
+		ID:               uuid.New().String(),
+		Name:             header.Room.Name,
+		Topic:            header.Room.Topic,
+		Description:      header.Room.Description,
+		AvatarURL:        header.Room.AvatarURL,
+		CreatedAt:        header.Room.CreatedAt,
+		CreatedBy:        mapUser(header.Room.Owner),
+		IsPrivate:        header.Room.IsPrivate,
+		SlowModeInterval: time.Duration(header.Room.SlowModeMs) * time.Millisecond,
+		AnnouncementOnly: header.Room.AnnouncementOnly,
+		LastActivity:     time.Now(),
+	}
+	if room.CreatedAt.IsZero() {
+		room.CreatedAt = room.LastActivity
+	}
+	if err := s.repo.CreateRoom(ctx, room); err != nil {
+		return nil, err
+	}
+
+	err := s.importRecords(ctx, dec, room, mapUser)
+	if err == nil {
+		room.MaxMembers = header.Room.MaxMembers
+		err = s.repo.UpdateRoom(ctx, room)
+	}
+	if err != nil {
+		if deleteErr := s.repo.DeleteRoom(context.Background(), room.ID); deleteErr != nil {
+			log.Printf("Failed to clean up partial import of room %s: %v", room.ID, deleteErr)
+		}
+		return nil, err
+	}
+
+	imported, err := s.repo.GetRoom(ctx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishRoomChange(DirectoryRoomCreated, imported, userID)
+
+	// the members only hear of the room once the whole archive went in
+	members, err := s.repo.GetRoomMembers(ctx, imported.ID)
+	if err != nil {
+		log.Printf("Failed to list the members of imported room %s: %v", imported.ID, err)
+	}
+	for _, memberID := range members {
+		s.notifyUser(memberID, UserEvent{Type: UserRoomAdded, RoomID: imported.ID})
+	}
+	return imported, nil
+}
+
+// importRecords adds the members and messages following the archive header to the room
+func (s *RoomService) importRecords(ctx context.Context, dec *json.Decoder, room *Room, mapUser func(string) string) error {
+	batch := make([]*ChatMessage, 0, importBatchSize)
+	for {
+		var record archiveRecord
+		err := dec.Decode(&record)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			// a broken stream is reported as is, anything else is a malformed archive
+			var syntaxErr *json.SyntaxError
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &syntaxErr) || errors.As(err, &typeErr) || errors.Is(err, io.ErrUnexpectedEOF) {
+				return ErrInvalidArchive
+			}
+			return err
+		}
+
+		switch {
+		case record.Member != nil:
+			memberID := mapUser(record.Member.UserID)
+			if memberID == "" || record.Member.Role < RoleMember || record.Member.Role > RoleOwner {
+				return ErrInvalidArchive
+			}
+			if err := s.repo.AddRoomMember(ctx, room.ID, memberID); err != nil {
+				return err
+			}
+			// ownership comes from Room.CreatedBy, never from a role entry
+			role := min(record.Member.Role, RoleAdmin)
+			if memberID != room.CreatedBy && role > RoleMember {
+				if err := s.repo.SetMemberRole(ctx, room.ID, memberID, role); err != nil {
+					return err
+				}
+			}
+		case record.Message != nil:
+			msg := record.Message
+			if msg.Content == "" {
+				return ErrInvalidArchive
+			}
+			batch = append(batch, &ChatMessage{
+				ID:        msg.ID,
+				RoomID:    room.ID,
+				UserID:    mapUser(msg.UserID),
+				Content:   msg.Content,
+				Timestamp: msg.Timestamp,
+			})
+			if len(batch) == importBatchSize {
+				if err := s.repo.AppendMessages(ctx, room.ID, batch, s.messageHistorySize); err != nil {
+					return err
+				}
+				batch = batch[:0]
+			}
+		default:
+			return ErrInvalidArchive
+		}
+	}
+	return s.repo.AppendMessages(ctx, room.ID, batch, s.messageHistorySize)
+}
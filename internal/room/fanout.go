@@ -0,0 +1,437 @@
+package room
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"math"
+	"os"
+	"sync"
+	"sync/atomic"
+
+	"github.com/assu-2000/StreamRPC/config"
+)
+
+var (
+	ErrSlowConsumer = errors.New("stream could not keep up with the room events")
+	ErrRoomDeleted  = errors.New("room was deleted")
+)
+
+// EventStream is the subscription of one stream to a room. Events are queued per
+// stream and the configured SlowConsumerPolicy applies once the queue is full.
+type EventStream struct {
+	roomID string
+	events chan RoomEvent
+	notify chan struct{}
+	done   chan struct{}
+	// accept filters the events delivered to the stream, nil accepts them all
+	accept func(RoomEvent) bool
+
+	mu    sync.Mutex
+	queue []RoomEvent
+	// spill holds the events queued past the buffer under the Spill policy, they come after queue
+	spill  eventSpill
+	err    error
+	closed bool
+	// finishing streams are closed with finishErr once their queue is drained
+	finishing bool
+	finishErr error
+}
+
+// Events is closed once the stream is unsubscribed or disconnected
+func (s *EventStream) Events() <-chan RoomEvent {
+	return s.events
+}
+
+// Err tells why Events was closed, it is nil for a regular unsubscribe
+func (s *EventStream) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// close stops the stream, the events still queued are discarded
+func (s *EventStream) close(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.closed = true
+	s.err = err
+	s.queue = nil
+	s.spill.discard()
+	close(s.done)
+}
+
+// finish closes the stream with err after the events already queued are delivered
+func (s *EventStream) finish(err error) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.finishing = true
+	s.finishErr = err
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+// forward moves queued events to the events channel at the pace of the consumer
+func (s *EventStream) forward() {
+	defer close(s.events)
+
+	for {
+		select {
+		case <-s.done:
+			return
+		case <-s.notify:
+		}
+
+		for {
+			s.mu.Lock()
+			if s.closed {
+				s.mu.Unlock()
+				break
+			}
+			if len(s.queue) == 0 && s.spill.pending > 0 {
+				event, err := s.spill.pop()
+				if err != nil {
+					s.mu.Unlock()
+					log.Printf("Failed to read the spilled events of room %s: %v", s.roomID, err)
+					s.close(ErrSlowConsumer)
+					return
+				}
+				s.queue = append(s.queue, event)
+			}
+			if len(s.queue) == 0 {
+				finishing, err := s.finishing, s.finishErr
+				s.mu.Unlock()
+				if finishing {
+					s.close(err)
+					return
+				}
+				break
+			}
+			event := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+
+			select {
+			case s.events <- event:
+			case <-s.done:
+				return
+			}
+		}
+	}
+}
+
+// eventSpill queues events in a temporary file, read back in the order they were written.
+// The file is emptied each time its last event is read.
+type eventSpill struct {
+	file    *os.File
+	reader  *bufio.Reader
+	pending int
+}
+
+func (s *eventSpill) push(event RoomEvent) error {
+	if s.file == nil {
+		file, err := os.CreateTemp("", "room-events-*.spill")
+		if err != nil {
+			return err
+		}
+		s.file = file
+		s.reader = bufio.NewReader(io.NewSectionReader(file, 0, math.MaxInt64))
+	}
+
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	s.pending++
+	return nil
+}
+
+func (s *eventSpill) pop() (RoomEvent, error) {
+	var event RoomEvent
+	line, err := s.reader.ReadBytes('\n')
+	if err != nil {
+		return event, err
+	}
+	if err := json.Unmarshal(line, &event); err != nil {
+		return event, err
+	}
+
+	s.pending--
+	if s.pending == 0 {
+		if err := s.file.Truncate(0); err != nil {
+			return event, err
+		}
+		if _, err := s.file.Seek(0, io.SeekStart); err != nil {
+			return event, err
+		}
+		s.reader.Reset(io.NewSectionReader(s.file, 0, math.MaxInt64))
+	}
+	return event, nil
+}
+
+// discard deletes the file along with the events it still holds
+func (s *eventSpill) discard() {
+	if s.file == nil {
+		return
+	}
+	s.file.Close()
+	os.Remove(s.file.Name())
+	s.file, s.reader, s.pending = nil, nil, 0
+}
+
+// roomFeed is the single subscription this process holds for a room
+type roomFeed struct {
+	sub         Subscription
+	subscribers map[*EventStream]struct{}
+	counters    *deliveryCounters
+}
+
+// deliveryCounters outlive the feeds of their room, so its stats do not start over
+// each time the last local stream of the room goes away
+type deliveryCounters struct {
+	dropped     atomic.Uint64
+	disconnects atomic.Uint64
+}
+
+// DeliveryStats counts the events this process failed to deliver for a room
+type DeliveryStats struct {
+	DroppedEvents           uint64
+	SlowConsumerDisconnects uint64
+}
+
+// roomHub shares one subscription per active room between all the local streams of that room
+type roomHub struct {
+	repo       RoomRepository
+	policy     config.SlowConsumerPolicy
+	bufferSize int
+	spillSize  int
+
+	mu    sync.Mutex
+	feeds map[string]*roomFeed
+	// counters are kept per room until the room is deleted
+	counters map[string]*deliveryCounters
+}
+
+func newRoomHub(repo RoomRepository, cfg config.RoomConfig) *roomHub {
+	return &roomHub{
+		repo:       repo,
+		policy:     cfg.SlowConsumerPolicy,
+		bufferSize: cfg.EventBufferSize,
+		spillSize:  cfg.EventSpillSize,
+		feeds:      make(map[string]*roomFeed),
+		counters:   make(map[string]*deliveryCounters),
+	}
+}
+
+// subscribe attaches a new stream to the room feed, opening the feed if this is its first stream.
+// The stream only gets the events accept returns true for and is detached once ctx is done.
+func (h *roomHub) subscribe(ctx context.Context, roomID string, accept func(RoomEvent) bool) *EventStream {
+	stream := &EventStream{
+		roomID: roomID,
+		events: make(chan RoomEvent),
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+		accept: accept,
+	}
+
+	h.mu.Lock()
+	feed, ok := h.feeds[roomID]
+	if !ok {
+		counters, ok := h.counters[roomID]
+		if !ok {
+			counters = &deliveryCounters{}
+			h.counters[roomID] = counters
+		}
+		feed = &roomFeed{
+			sub:         h.repo.SubscribeToRoom(context.Background(), roomID),
+			subscribers: make(map[*EventStream]struct{}),
+			counters:    counters,
+		}
+		h.feeds[roomID] = feed
+		go h.pump(roomID, feed)
+	}
+	feed.subscribers[stream] = struct{}{}
+	h.mu.Unlock()
+
+	go stream.forward()
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-stream.done:
+		}
+		h.unsubscribe(stream, nil)
+	}()
+
+	return stream
+}
+
+// unsubscribe detaches the stream and closes the room feed once nobody listens to it
+func (h *roomHub) unsubscribe(stream *EventStream, err error) {
+	h.mu.Lock()
+	if feed, ok := h.feeds[stream.roomID]; ok {
+		if _, subscribed := feed.subscribers[stream]; !subscribed {
+			// the stream belonged to a feed closed by closeFeed
+			h.mu.Unlock()
+			stream.close(err)
+			return
+		}
+		delete(feed.subscribers, stream)
+		if len(feed.subscribers) == 0 {
+			delete(h.feeds, stream.roomID)
+			feed.sub.Close()
+		}
+	}
+	h.mu.Unlock()
+
+	stream.close(err)
+}
+
+// pump reads the room feed and hands every event to the local streams
+func (h *roomHub) pump(roomID string, feed *roomFeed) {
+	for {
+		payload, err := feed.sub.Receive(context.Background())
+		if err != nil {
+			if errors.Is(err, ErrSubscriptionClosed) {
+				return
+			}
+			log.Printf("PubSub error: %v", err)
+			continue
+		}
+
+		var event RoomEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			log.Printf("Failed to unmarshal event: %v", err)
+			continue
+		}
+
+		h.mu.Lock()
+		streams := make([]*EventStream, 0, len(feed.subscribers))
+		for stream := range feed.subscribers {
+			streams = append(streams, stream)
+		}
+		h.mu.Unlock()
+
+		for _, stream := range streams {
+			if stream.accept != nil && !stream.accept(event) {
+				continue
+			}
+			if !h.deliver(feed, stream, event) {
+				log.Printf("Disconnecting slow stream of room %s", roomID)
+				h.unsubscribe(stream, ErrSlowConsumer)
+			}
+		}
+
+		if event.Type == EventRoomDeleted {
+			h.closeFeed(roomID, feed)
+			return
+		}
+	}
+}
+
+// closeFeed drops the feed of a deleted room and its subscription, the streams still
+// get the events queued so far, the deletion included, and then end with ErrRoomDeleted
+func (h *roomHub) closeFeed(roomID string, feed *roomFeed) {
+	h.mu.Lock()
+	if h.feeds[roomID] == feed {
+		delete(h.feeds, roomID)
+	}
+	delete(h.counters, roomID)
+	streams := feed.subscribers
+	feed.subscribers = make(map[*EventStream]struct{})
+	h.mu.Unlock()
+
+	feed.sub.Close()
+	for stream := range streams {
+		stream.finish(ErrRoomDeleted)
+	}
+}
+
+// deliver queues the event on the stream, it returns false when the stream has to be disconnected
+func (h *roomHub) deliver(feed *roomFeed, stream *EventStream, event RoomEvent) bool {
+	stream.mu.Lock()
+	if stream.closed {
+		stream.mu.Unlock()
+		return true
+	}
+
+	// once events are spilled the next ones follow them, so they are all delivered in order
+	if h.policy == config.Spill && (stream.spill.pending > 0 || len(stream.queue) >= h.bufferSize) {
+		err := ErrSlowConsumer
+		if stream.spill.pending < h.spillSize {
+			err = stream.spill.push(event)
+		}
+		stream.mu.Unlock()
+		if err != nil {
+			if !errors.Is(err, ErrSlowConsumer) {
+				log.Printf("Failed to spill an event of room %s: %v", stream.roomID, err)
+			}
+			feed.counters.dropped.Add(1)
+			feed.counters.disconnects.Add(1)
+			return false
+		}
+
+		select {
+		case stream.notify <- struct{}{}:
+		default:
+		}
+		return true
+	}
+
+	if len(stream.queue) >= h.bufferSize {
+		feed.counters.dropped.Add(1)
+		if h.policy != config.DropOldest {
+			stream.mu.Unlock()
+			feed.counters.disconnects.Add(1)
+			return false
+		}
+		stream.queue = stream.queue[1:]
+	}
+	stream.queue = append(stream.queue, event)
+	stream.mu.Unlock()
+
+	select {
+	case stream.notify <- struct{}{}:
+	default:
+	}
+	return true
+}
+
+// stats returns the delivery counters of a room since this process first followed it,
+// zero when no local stream ever did
+func (h *roomHub) stats(roomID string) DeliveryStats {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	counters, ok := h.counters[roomID]
+	if !ok {
+		return DeliveryStats{}
+	}
+	return DeliveryStats{
+		DroppedEvents:           counters.dropped.Load(),
+		SlowConsumerDisconnects: counters.disconnects.Load(),
+	}
+}
+
+// forget drops the counters of a deleted room, the feeds of the room drop them on their own
+// when the deletion reaches them
+func (h *roomHub) forget(roomID string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	delete(h.counters, roomID)
+}
@@ -2,11 +2,17 @@ package room
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/google/uuid"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 	"log"
+	"strings"
+	"time"
 
 	"github.com/assu-2000/StreamRPC/internal/pb"
 	"google.golang.org/grpc/codes"
@@ -28,68 +34,176 @@ func (h *RoomHandler) CreateRoom(ctx context.Context, req *pb.CreateRoomRequest)
 		return nil, status.Error(codes.Unauthenticated, "invalid user")
 	}
 
-	room, err := h.service.CreateRoom(ctx, req.Name, userID.String(), req.IsPrivate)
+	if req.TemplateId != "" {
+		room, err := h.service.CreateRoomFromTemplate(ctx, req.TemplateId, req.Name, userID.String(), int(req.MaxMembers))
+		if err != nil {
+			return nil, statusFromError(err, "failed to create room")
+		}
+		return convertToPbRoom(room), nil
+	}
+
+	room, err := h.service.CreateRoom(ctx, req.Name, userID.String(), req.IsPrivate, int(req.MaxMembers))
 	if err != nil {
 		log.Printf("Failed to create room: %v", err)
 		return nil, status.Error(codes.Internal, "failed to create room")
 	}
 
-	return &pb.Room{
-		Id:        room.ID,
-		Name:      room.Name,
-		IsPrivate: room.IsPrivate,
-	}, nil
+	return convertToPbRoom(room), nil
 }
 
 func (h *RoomHandler) JoinRoom(req *pb.JoinRoomRequest, stream pb.RoomGrpcService_JoinRoomServer) error {
 	userID, ok := stream.Context().Value("user_id").(uuid.UUID)
-	fmt.Println("userid ", userID)
 	if !ok {
 		return status.Error(codes.Unauthenticated, "invalid user")
 	}
 
-	events, err := h.service.JoinRoom(stream.Context(), req.RoomId, userID.String())
+	events, position, err := h.service.JoinRoom(stream.Context(), req.RoomId, userID.String(), req.WaitIfFull)
 	if err != nil {
-		return status.Error(codes.Internal, "failed to join room")
-	}
-
-	for event := range events {
-		var resp *pb.RoomEvent
-		switch event.Type {
-		case EventUserJoined:
-			resp = &pb.RoomEvent{
-				Event: &pb.RoomEvent_UserJoined{
-					UserJoined: &pb.UserJoined{
-						UserId: event.UserID,
-					},
-				},
-			}
-		case EventUserLeft:
-			resp = &pb.RoomEvent{
-				Event: &pb.RoomEvent_UserLeft{
-					UserLeft: &pb.UserLeft{
-						UserId: event.UserID,
-					},
-				},
-			}
-		case EventMessage:
-			// Handled by MessageService
+		return statusFromError(err, "failed to join room")
+	}
+
+	if position > 0 {
+		resp := &pb.RoomEvent{
+			Event: &pb.RoomEvent_Waitlisted{
+				Waitlisted: &pb.Waitlisted{Position: uint32(position)},
+			},
+		}
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+
+	for event := range events.Events() {
+		resp := h.roomEventFor(stream.Context(), req.RoomId, userID.String(), event)
+		if resp == nil {
 			continue
-		default:
-			fmt.Println("unhandled case")
 		}
 
+		// a broken stream only ends this session, the user stays a member of the room
 		if err := stream.Send(resp); err != nil {
-			err := h.service.LeaveRoom(stream.Context(), req.RoomId, userID.String())
-			if err != nil {
-				fmt.Printf("Failed to send response to user: %v", err)
-				return err
-			}
 			return err
 		}
+		if event.Type == EventUserLeft && event.UserID == userID.String() {
+			// the user left the room from another call or device, this stream is done
+			return nil
+		}
 	}
 
-	return nil
+	return eventStreamError(events)
+}
+
+// roomEventFor converts a room event for a member of the room, it returns nil for the events
+// the member does not get: messages, which have their own stream, and events restricted by role
+func (h *RoomHandler) roomEventFor(ctx context.Context, roomID, userID string, event RoomEvent) *pb.RoomEvent {
+	var resp *pb.RoomEvent
+	switch event.Type {
+	case EventUserJoined:
+		resp = &pb.RoomEvent{
+			Event: &pb.RoomEvent_UserJoined{
+				UserJoined: &pb.UserJoined{
+					UserId: event.UserID,
+				},
+			},
+		}
+	case EventUserLeft:
+		resp = &pb.RoomEvent{
+			Event: &pb.RoomEvent_UserLeft{
+				UserLeft: &pb.UserLeft{
+					UserId: event.UserID,
+				},
+			},
+		}
+	case EventRoomUpdated:
+		var room Room
+		if err := event.DecodePayload(&room); err != nil {
+			log.Printf("Failed to decode room update: %v", err)
+			return nil
+		}
+		resp = &pb.RoomEvent{
+			Event: &pb.RoomEvent_RoomUpdated{
+				RoomUpdated: &pb.RoomUpdated{
+					Room:      convertToPbRoom(&room),
+					UpdatedBy: event.UserID,
+				},
+			},
+		}
+	case EventWaitlistPromoted:
+		resp = &pb.RoomEvent{
+			Event: &pb.RoomEvent_WaitlistPromoted{
+				WaitlistPromoted: &pb.WaitlistPromoted{
+					UserId: event.UserID,
+				},
+			},
+		}
+	case EventOwnershipTransferred:
+		var transfer OwnershipTransfer
+		if err := event.DecodePayload(&transfer); err != nil {
+			log.Printf("Failed to decode ownership transfer: %v", err)
+			return nil
+		}
+		resp = &pb.RoomEvent{
+			Event: &pb.RoomEvent_OwnershipTransferred{
+				OwnershipTransferred: &pb.OwnershipTransferred{
+					PreviousOwnerId: transfer.PreviousOwnerID,
+					NewOwnerId:      event.UserID,
+				},
+			},
+		}
+	case EventRoomDeleted:
+		var deletion RoomDeletion
+		if err := event.DecodePayload(&deletion); err != nil {
+			log.Printf("Failed to decode room deletion: %v", err)
+		}
+		// the stream ends with ErrRoomDeleted right after this event
+		resp = &pb.RoomEvent{
+			Event: &pb.RoomEvent_RoomDeleted{
+				RoomDeleted: &pb.RoomDeleted{
+					Reason:    deletion.Reason,
+					DeletedBy: event.UserID,
+				},
+			},
+		}
+	case EventJoinRequested, EventJoinRequestResolved:
+		reviewer, err := h.service.ReviewsJoinRequests(ctx, roomID, userID)
+		if err != nil {
+			log.Printf("Failed to check join request reviewer: %v", err)
+			return nil
+		}
+		if !reviewer {
+			return nil
+		}
+		resp = convertToPbJoinRequestEvent(event)
+	case EventMetadataChanged:
+		var change MetadataChange
+		if err := event.DecodePayload(&change); err != nil {
+			log.Printf("Failed to decode metadata change: %v", err)
+			return nil
+		}
+		canRead, err := h.service.CanReadMetadata(ctx, roomID, change.Namespace, userID)
+		if err != nil {
+			log.Printf("Failed to check metadata access: %v", err)
+			return nil
+		}
+		if !canRead {
+			return nil
+		}
+		resp = &pb.RoomEvent{
+			Event: &pb.RoomEvent_MetadataChanged{
+				MetadataChanged: &pb.RoomMetadataChanged{
+					Namespace: change.Namespace,
+					Key:       change.Key,
+					Value:     change.Value,
+					Deleted:   change.Deleted,
+					ChangedBy: event.UserID,
+				},
+			},
+		}
+	case EventMessage:
+		// Handled by MessageService
+	default:
+		log.Printf("Skipping room event of unknown type %d", event.Type)
+	}
+	return resp
 }
 
 func (h *RoomHandler) GetRoomStats(ctx context.Context, req *pb.RoomID) (*pb.RoomStatsResponse, error) {
@@ -99,14 +213,30 @@ func (h *RoomHandler) GetRoomStats(ctx context.Context, req *pb.RoomID) (*pb.Roo
 	}
 
 	return &pb.RoomStatsResponse{
-		Room:          convertToPbRoom(stats.Room),
-		TotalMembers:  int32(stats.TotalMembers),
-		ActiveMembers: int32(stats.ActiveMembers),
+		Room:                    convertToPbRoom(stats.Room),
+		TotalMembers:            int32(stats.TotalMembers),
+		ActiveMembers:           int32(stats.ActiveMembers),
+		LastActivity:            timestamppb.New(stats.Room.LastActivity),
+		DroppedEvents:           stats.Delivery.DroppedEvents,
+		SlowConsumerDisconnects: stats.Delivery.SlowConsumerDisconnects,
 	}, nil
 }
 
+// LeaveRoom makes the caller leave the room, or removes req.UserId when it names someone else
 func (h *RoomHandler) LeaveRoom(ctx context.Context, req *pb.LeaveRoomRequest) (*emptypb.Empty, error) {
-	if err := h.service.LeaveRoom(ctx, req.RoomId, req.UserId); err != nil {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if req.UserId != "" && req.UserId != userID.String() {
+		if err := h.service.RemoveMember(ctx, req.RoomId, req.UserId, userID.String()); err != nil {
+			return nil, statusFromError(err, "failed to remove member")
+		}
+		return &emptypb.Empty{}, nil
+	}
+
+	if err := h.service.LeaveRoom(ctx, req.RoomId, userID.String()); err != nil {
 		log.Printf("LeaveRoom failed: %v", err)
 		return nil, status.Error(codes.Internal, "failed to leave room")
 	}
@@ -119,49 +249,200 @@ func (h *RoomHandler) GetRoom(ctx context.Context, req *pb.GetRoomRequest) (*pb.
 		return nil, status.Error(codes.NotFound, "room not found")
 	}
 
-	return &pb.Room{
-		Id:          room.ID,
-		Name:        room.Name,
-		CreatedBy:   room.CreatedBy,
-		CreatedAt:   timestamppb.New(room.CreatedAt),
-		IsPrivate:   room.IsPrivate,
-		MemberCount: uint32(0), // TODO -readjust the struct
-	}, nil
+	return convertToPbRoom(room), nil
 }
 
-func (h *RoomHandler) ListRooms(ctx context.Context, _ *emptypb.Empty) (*pb.ListRoomsResponse, error) {
-	rooms, err := h.service.ListRooms(ctx)
+func (h *RoomHandler) UpdateRoom(ctx context.Context, req *pb.UpdateRoomRequest) (*pb.Room, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if req.Room == nil || req.Room.Id == "" {
+		return nil, status.Error(codes.InvalidArgument, "room id is required")
+	}
+
+	update, err := roomUpdateFromMask(req.Room, req.UpdateMask)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	room, err := h.service.UpdateRoom(ctx, req.Room.Id, userID.String(), update)
+	if err != nil {
+		return nil, statusFromError(err, "failed to update room")
+	}
+
+	return convertToPbRoom(room), nil
+}
+
+func (h *RoomHandler) ListRooms(ctx context.Context, req *pb.ListRoomsRequest) (*pb.ListRoomsResponse, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	opts, err := roomListOptionsFromRequest(req)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	opts.Filter.VisibleTo = userID.String()
+
+	rooms, nextPageToken, err := h.service.ListRooms(ctx, opts)
 	if err != nil {
-		return nil, status.Error(codes.Internal, "failed to list rooms")
+		return nil, statusFromError(err, "failed to list rooms")
 	}
 
 	pbRooms := make([]*pb.Room, 0, len(rooms))
 	for _, r := range rooms {
-		pbRooms = append(pbRooms, &pb.Room{
-			Id:        r.ID,
-			Name:      r.Name,
-			CreatedBy: r.CreatedBy,
-			CreatedAt: timestamppb.New(r.CreatedAt),
-		})
+		pbRooms = append(pbRooms, convertToPbRoom(r))
 	}
 
-	return &pb.ListRoomsResponse{Rooms: pbRooms}, nil
+	return &pb.ListRoomsResponse{Rooms: pbRooms, NextPageToken: nextPageToken}, nil
 }
 
 func (h *RoomHandler) DeleteRoom(ctx context.Context, req *pb.DeleteRoomRequest) (*emptypb.Empty, error) {
-	if err := h.service.DeleteRoom(ctx, req.RoomId); err != nil {
-		return nil, status.Error(codes.Internal, "failed to delete room")
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if err := h.service.DeleteRoom(ctx, req.RoomId, userID.String(), req.Reason); err != nil {
+		return nil, statusFromError(err, "failed to delete room")
 	}
 	return &emptypb.Empty{}, nil
 }
 
-func (h *RoomHandler) GetRoomMembers(ctx context.Context, req *pb.GetRoomRequest) (*pb.RoomMembers, error) {
-	members, err := h.service.GetRoomMembers(ctx, req.RoomId)
+func (h *RoomHandler) ArchiveRoom(ctx context.Context, req *pb.ArchiveRoomRequest) (*pb.Room, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	room, err := h.service.ArchiveRoom(ctx, req.RoomId, userID.String())
 	if err != nil {
-		return nil, status.Error(codes.NotFound, "room not found")
+		return nil, statusFromError(err, "failed to archive room")
+	}
+
+	return convertToPbRoom(room), nil
+}
+
+func (h *RoomHandler) UnarchiveRoom(ctx context.Context, req *pb.UnarchiveRoomRequest) (*pb.Room, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	room, err := h.service.UnarchiveRoom(ctx, req.RoomId, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to unarchive room")
+	}
+
+	return convertToPbRoom(room), nil
+}
+
+func (h *RoomHandler) SetMemberRole(ctx context.Context, req *pb.SetMemberRoleRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if err := h.service.SetMemberRole(ctx, req.RoomId, req.UserId, MemberRole(req.Role), userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to set member role")
 	}
 
-	return &pb.RoomMembers{UserIds: members}, nil
+	return &emptypb.Empty{}, nil
+}
+
+func (h *RoomHandler) ListRoomMembers(ctx context.Context, req *pb.ListRoomMembersRequest) (*pb.ListRoomMembersResponse, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+	if req.PageSize < 0 {
+		return nil, status.Error(codes.InvalidArgument, "page_size cannot be negative")
+	}
+
+	opts := MemberListOptions{
+		PageSize:  int(req.PageSize),
+		PageToken: req.PageToken,
+	}
+	if req.Role != nil {
+		role := MemberRole(*req.Role)
+		opts.Role = &role
+	}
+	if req.Status != nil {
+		memberStatus := MemberStatus(*req.Status)
+		opts.Status = &memberStatus
+	}
+
+	members, nextPageToken, err := h.service.ListRoomMembers(ctx, req.RoomId, userID.String(), opts)
+	if err != nil {
+		return nil, statusFromError(err, "failed to list room members")
+	}
+
+	resp := &pb.ListRoomMembersResponse{
+		Members:       make([]*pb.MemberInfo, len(members)),
+		NextPageToken: nextPageToken,
+	}
+	for i, member := range members {
+		resp.Members[i] = &pb.MemberInfo{
+			UserId:      member.UserID,
+			Role:        pb.MemberRole(member.Role),
+			Muted:       member.Muted,
+			MutedUntil:  optionalTimestamp(member.MutedUntil),
+			Status:      pb.MemberStatus(member.Status()),
+			Username:    member.Username,
+			DisplayName: member.DisplayName,
+			JoinedAt:    optionalTimestamp(member.JoinedAt),
+		}
+	}
+
+	return resp, nil
+}
+
+func (h *RoomHandler) GetRoomPresence(ctx context.Context, req *pb.GetRoomRequest) (*pb.RoomPresence, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	sessions, err := h.service.RoomPresence(ctx, req.RoomId, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to get room presence")
+	}
+
+	byUser := make(map[string]*pb.UserPresence)
+	users := make([]*pb.UserPresence, 0)
+	for _, session := range sessions {
+		user, ok := byUser[session.UserID]
+		if !ok {
+			user = &pb.UserPresence{UserId: session.UserID}
+			byUser[session.UserID] = user
+			users = append(users, user)
+		}
+		user.Sessions = append(user.Sessions, convertToPbPresenceSession(session))
+	}
+
+	return &pb.RoomPresence{Users: users}, nil
+}
+
+func (h *RoomHandler) GetUserPresence(ctx context.Context, req *pb.GetUserPresenceRequest) (*pb.UserPresence, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	sessions, err := h.service.UserPresence(ctx, req.UserId, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to get user presence")
+	}
+
+	presence := &pb.UserPresence{UserId: req.UserId}
+	for _, session := range sessions {
+		presence.Sessions = append(presence.Sessions, convertToPbPresenceSession(session))
+	}
+
+	return presence, nil
 }
 
 func convertToPbRoom(room *Room) *pb.Room {
@@ -169,11 +450,202 @@ func convertToPbRoom(room *Room) *pb.Room {
 		return nil
 	}
 
-	return &pb.Room{
-		Id:        room.ID,
-		Name:      room.Name,
-		CreatedBy: room.CreatedBy,
-		CreatedAt: timestamppb.New(room.CreatedAt),
-		IsPrivate: room.IsPrivate,
+	pbRoom := &pb.Room{
+		Id:           room.ID,
+		Name:         room.Name,
+		Topic:        room.Topic,
+		Description:  room.Description,
+		AvatarUrl:    room.AvatarURL,
+		CreatedBy:    room.CreatedBy,
+		CreatedAt:    timestamppb.New(room.CreatedAt),
+		IsPrivate:    room.IsPrivate,
+		MemberCount:  uint32(room.MemberCount),
+		LastActivity: timestamppb.New(room.LastActivity),
+		ArchivedAt:   optionalTimestamp(room.ArchivedAt),
+		ArchivedBy:   room.ArchivedBy,
+		PurgeAt:      optionalTimestamp(room.PurgeAt),
+		MaxMembers:   uint32(room.MaxMembers),
+		SpaceId:      room.SpaceID,
+		Category:     room.Category,
+
+		AnnouncementOnly: room.AnnouncementOnly,
+	}
+	if room.SlowModeInterval > 0 {
+		pbRoom.SlowModeInterval = durationpb.New(room.SlowModeInterval)
+	}
+	if room.MessageRetention > 0 {
+		pbRoom.MessageRetention = durationpb.New(room.MessageRetention)
+	}
+	if room.PinnedMessage != nil {
+		pbRoom.PinnedMessage = convertToPbMessage(room.PinnedMessage)
+	}
+	return pbRoom
+}
+
+func convertToPbMessage(msg *ChatMessage) *pb.ChatMessage {
+	return &pb.ChatMessage{
+		Id:        msg.ID,
+		RoomId:    msg.RoomID,
+		UserId:    msg.UserID,
+		Content:   msg.Content,
+		Timestamp: msg.Timestamp.Format(time.RFC3339),
+	}
+}
+
+// optionalTimestamp leaves unset times out of the message instead of sending the zero time
+func optionalTimestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+// statusFromError maps the room errors to gRPC codes, unexpected errors are logged and reported as msg
+func statusFromError(err error, msg string) error {
+	var slowMode *SlowModeError
+	switch {
+	case errors.As(err, &slowMode):
+		st, detailErr := status.New(codes.ResourceExhausted, err.Error()).WithDetails(&errdetails.RetryInfo{
+			RetryDelay: durationpb.New(slowMode.RetryAfter),
+		})
+		if detailErr != nil {
+			return status.Error(codes.ResourceExhausted, err.Error())
+		}
+		return st.Err()
+	case errors.Is(err, ErrRoomNotFound), errors.Is(err, ErrInviteNotFound), errors.Is(err, ErrSpaceNotFound),
+		errors.Is(err, ErrJoinRequestNotFound), errors.Is(err, ErrMetadataNotFound), errors.Is(err, ErrTemplateNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, ErrNotRoomOwner), errors.Is(err, ErrNotRoomMember), errors.Is(err, ErrPrivateRoom), errors.Is(err, ErrInsufficientRole),
+		errors.Is(err, ErrMuted), errors.Is(err, ErrAnnouncementOnly),
+		errors.Is(err, ErrNotSpaceOwner), errors.Is(err, ErrNotSpaceMember), errors.Is(err, ErrPrivateSpace), errors.Is(err, ErrNotAdmin):
+		return status.Error(codes.PermissionDenied, err.Error())
+	case errors.Is(err, ErrRoomArchived), errors.Is(err, ErrRoomNotArchived), errors.Is(err, ErrInviteUsedUp),
+		errors.Is(err, ErrRoomFull), errors.Is(err, ErrRoomInOtherSpace), errors.Is(err, ErrRoomNotInSpace),
+		errors.Is(err, ErrJoinRequestNotNeeded), errors.Is(err, ErrMetadataFull):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, ErrInvalidMessage), errors.Is(err, ErrInvalidPageToken), errors.Is(err, ErrInvalidInvite),
+		errors.Is(err, ErrInvalidCapacity), errors.Is(err, ErrInvalidSpace), errors.Is(err, ErrInvalidRole),
+		errors.Is(err, ErrInvalidSlowMode), errors.Is(err, ErrInvalidMute), errors.Is(err, ErrInvalidOwner),
+		errors.Is(err, ErrInvalidArchive), errors.Is(err, ErrUnsupportedArchive), errors.Is(err, ErrInvalidJoinRequest),
+		errors.Is(err, ErrInvalidMetadata), errors.Is(err, ErrInvalidSearch), errors.Is(err, ErrInvalidCreator),
+		errors.Is(err, ErrInvalidTemplate), errors.Is(err, ErrInvalidRoomName):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		log.Printf("%s: %v", msg, err)
+		return status.Error(codes.Internal, msg)
+	}
+}
+
+// eventStreamError maps the reason an EventStream or a UserSubscription ended to the status returned to the client
+func eventStreamError(events interface{ Err() error }) error {
+	err := events.Err()
+	switch {
+	case errors.Is(err, ErrSlowConsumer):
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case errors.Is(err, ErrRoomDeleted):
+		return status.Error(codes.NotFound, err.Error())
+	}
+	return nil
+}
+
+func convertToPbPresenceSession(session PresenceSession) *pb.PresenceSession {
+	return &pb.PresenceSession{
+		SessionId: session.SessionID,
+		NodeId:    session.NodeID,
+		ExpiresAt: timestamppb.New(session.ExpiresAt),
+		RoomId:    session.RoomID,
+	}
+}
+
+// roomListOptionsFromRequest validates the paging, filter and order_by of a ListRooms request
+func roomListOptionsFromRequest(req *pb.ListRoomsRequest) (RoomListOptions, error) {
+	opts := RoomListOptions{
+		PageSize:   int(req.PageSize),
+		PageToken:  req.PageToken,
+		OrderBy:    OrderByCreatedAt,
+		Descending: true,
 	}
+	if req.PageSize < 0 {
+		return opts, errors.New("page_size cannot be negative")
+	}
+
+	if f := req.Filter; f != nil {
+		opts.Filter = RoomFilter{
+			NamePrefix: f.NamePrefix,
+			IsPrivate:  f.IsPrivate,
+			CreatedBy:  f.CreatedBy,
+			Archived:   f.Archived,
+			SpaceID:    f.SpaceId,
+		}
+	}
+
+	if req.OrderBy == "" {
+		return opts, nil
+	}
+
+	parts := strings.Fields(req.OrderBy)
+	if len(parts) > 2 {
+		return opts, fmt.Errorf("invalid order_by %q", req.OrderBy)
+	}
+	switch parts[0] {
+	case "created_at":
+		opts.OrderBy = OrderByCreatedAt
+	case "member_count":
+		opts.OrderBy = OrderByMemberCount
+	case "last_activity":
+		opts.OrderBy = OrderByLastActivity
+	default:
+		return opts, fmt.Errorf("unsupported order_by field %q", parts[0])
+	}
+
+	opts.Descending = false
+	if len(parts) == 2 {
+		switch strings.ToLower(parts[1]) {
+		case "asc":
+		case "desc":
+			opts.Descending = true
+		default:
+			return opts, fmt.Errorf("invalid order_by direction %q", parts[1])
+		}
+	}
+
+	return opts, nil
+}
+
+// roomUpdateFromMask picks the fields named in mask out of room
+func roomUpdateFromMask(room *pb.Room, mask *fieldmaskpb.FieldMask) (RoomUpdate, error) {
+	var update RoomUpdate
+	if mask == nil || len(mask.Paths) == 0 {
+		return update, errors.New("update_mask is required")
+	}
+
+	for _, path := range mask.Paths {
+		switch path {
+		case "name":
+			if room.Name == "" {
+				return update, errors.New("room name cannot be empty")
+			}
+			update.Name = &room.Name
+		case "topic":
+			update.Topic = &room.Topic
+		case "description":
+			update.Description = &room.Description
+		case "avatar_url":
+			update.AvatarURL = &room.AvatarUrl
+		case "is_private":
+			update.IsPrivate = &room.IsPrivate
+		case "max_members":
+			maxMembers := int(room.MaxMembers)
+			update.MaxMembers = &maxMembers
+		case "slow_mode_interval":
+			interval := room.SlowModeInterval.AsDuration()
+			update.SlowModeInterval = &interval
+		case "announcement_only":
+			update.AnnouncementOnly = &room.AnnouncementOnly
+		default:
+			return update, fmt.Errorf("unsupported update_mask path %q", path)
+		}
+	}
+
+	return update, nil
 }
@@ -0,0 +1,526 @@
+package room
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	roomsByCreatedAtKey    = "rooms:by_created_at"
+	roomsByMemberCountKey  = "rooms:by_member_count"
+	roomsByLastActivityKey = "rooms:by_last_activity"
+
+	roomListBatchSize = 100
+)
+
+// The filter indexes are sets of room IDs that ListRooms intersects with the index of the order,
+// the name index is scored 0 so its "<lowercase name>\x00<room ID>" entries sort by name
+const (
+	roomsPublicKey          = "rooms:public"
+	roomsUnarchivedKey      = "rooms:unarchived"
+	roomsByCreatorKeyFormat = "rooms:created_by:%s"
+	roomsByNameKey          = "rooms:by_name"
+	// userRoomsKeyFormat holds the rooms a user is a member of
+	userRoomsKeyFormat = "user:%s:rooms"
+
+	// roomListResultTTL bounds the life of a ListRooms intersection a failed call did not delete
+	roomListResultTTL = time.Minute
+)
+
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+var roomOrderIndexes = map[RoomOrder]string{
+	OrderByCreatedAt:    roomsByCreatedAtKey,
+	OrderByMemberCount:  roomsByMemberCountKey,
+	OrderByLastActivity: roomsByLastActivityKey,
+}
+
+// The membership scripts keep the room:<id> hash counters and the sorted-set indexes
+// in step with the member set. KEYS are the room hash, the member set, the member count
+// index, the last activity index, the waitlist, the member roles, the join times and the rooms
+// of the user; ARGV are the room ID, the user ID and the activity time as a score and as RFC3339.
+
+// addMemberScript refuses members for unknown rooms (-1) and for full rooms (-2). A room is
+// full once max_members is reached or while users wait for a slot, so nobody jumps the queue.
+// With ARGV[5] set the user is waitlisted instead and -3 - rank is returned.
+var addMemberScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+local added = 0
+if redis.call('SISMEMBER', KEYS[2], ARGV[2]) == 0 then
+	local maxMembers = tonumber(redis.call('HGET', KEYS[1], 'max_members')) or 0
+	if maxMembers > 0 and (redis.call('SCARD', KEYS[2]) >= maxMembers or redis.call('ZCARD', KEYS[5]) > 0) then
+		if ARGV[5] ~= '1' then
+			return -2
+		end
+		redis.call('ZADD', KEYS[5], 'NX', ARGV[3], ARGV[2])
+		return -3 - redis.call('ZRANK', KEYS[5], ARGV[2])
+	end
+	redis.call('SADD', KEYS[2], ARGV[2])
+	redis.call('ZADD', KEYS[7], ARGV[3], ARGV[2])
+	redis.call('SADD', KEYS[8], ARGV[1])
+	redis.call('HINCRBY', KEYS[1], 'member_count', 1)
+	redis.call('ZINCRBY', KEYS[3], 1, ARGV[1])
+	added = 1
+end
+redis.call('HSET', KEYS[1], 'last_activity', ARGV[4])
+redis.call('ZADD', KEYS[4], ARGV[3], ARGV[1])
+return added
+`)
+
+// removeMemberScript also takes the user out of the waitlist and drops their role
+var removeMemberScript = redis.NewScript(`
+redis.call('ZREM', KEYS[5], ARGV[2])
+redis.call('HDEL', KEYS[6], ARGV[2])
+redis.call('ZREM', KEYS[7], ARGV[2])
+redis.call('SREM', KEYS[8], ARGV[1])
+local removed = redis.call('SREM', KEYS[2], ARGV[2])
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return removed
+end
+if removed == 1 then
+	redis.call('HINCRBY', KEYS[1], 'member_count', -1)
+	redis.call('ZINCRBY', KEYS[3], -1, ARGV[1])
+end
+redis.call('HSET', KEYS[1], 'last_activity', ARGV[4])
+redis.call('ZADD', KEYS[4], ARGV[3], ARGV[1])
+return removed
+`)
+
+// removeAllMembersScript takes the room out of the rooms of each member, whose keys are derived
+// from the format ARGV[2] since the members are only known to the script. It has no user key.
+var removeAllMembersScript = redis.NewScript(`
+for _, userID in ipairs(redis.call('SMEMBERS', KEYS[2])) do
+	redis.call('SREM', string.format(ARGV[2], userID), ARGV[1])
+end
+redis.call('DEL', KEYS[2], KEYS[6], KEYS[7])
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	redis.call('HSET', KEYS[1], 'member_count', 0)
+	redis.call('ZADD', KEYS[3], 0, ARGV[1])
+end
+return 1
+`)
+
+// touchActivityScript takes the room hash and the last activity index as KEYS
+// and the room ID, the score and the RFC3339 time as ARGV
+var touchActivityScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+redis.call('HSET', KEYS[1], 'last_activity', ARGV[3])
+redis.call('ZADD', KEYS[2], ARGV[2], ARGV[1])
+return 1
+`)
+
+// roomCursor is the position of the last room examined by ListRooms, ties on
+// the score are broken by the room ID like Redis does. Skip is the position of the
+// room among those sharing its score, the next page starts right after it.
+type roomCursor struct {
+	Order RoomOrder `json:"o"`
+	Desc  bool      `json:"d"`
+	Score float64   `json:"s"`
+	ID    string    `json:"id"`
+	Skip  int64     `json:"k,omitempty"`
+}
+
+func addRoomToIndexes(ctx context.Context, pipe redis.Pipeliner, room *Room) {
+	createdAt := float64(room.CreatedAt.UnixMilli())
+	pipe.ZAdd(ctx, roomsByCreatedAtKey, redis.Z{Score: createdAt, Member: room.ID})
+	pipe.ZAdd(ctx, roomsByMemberCountKey, redis.Z{Score: 0, Member: room.ID})
+	pipe.ZAdd(ctx, roomsByLastActivityKey, redis.Z{Score: createdAt, Member: room.ID})
+	addRoomToFilterIndexes(ctx, pipe, room)
+	addRoomToSearchIndex(ctx, pipe, room)
+}
+
+func addRoomToFilterIndexes(ctx context.Context, pipe redis.Pipeliner, room *Room) {
+	pipe.ZAdd(ctx, roomsByNameKey, redis.Z{Member: roomNameEntry(room.Name, room.ID)})
+	pipe.SAdd(ctx, fmt.Sprintf(roomsByCreatorKeyFormat, room.CreatedBy), room.ID)
+	if !room.IsPrivate {
+		pipe.SAdd(ctx, roomsPublicKey, room.ID)
+	}
+	if !room.IsArchived() {
+		pipe.SAdd(ctx, roomsUnarchivedKey, room.ID)
+	}
+	if room.SpaceID != "" {
+		pipe.SAdd(ctx, fmt.Sprintf(spaceRoomsKeyFormat, room.SpaceID), room.ID)
+	}
+}
+
+// roomNameEntry is the entry of the room in the name index, also kept in the room hash as name_key
+// so the entry can be found again once the name changed
+func roomNameEntry(name, roomID string) string {
+	return strings.ToLower(name) + "\x00" + roomID
+}
+
+// membershipKeys are the KEYS shared by the membership scripts, the rooms of the user excepted
+func membershipKeys(roomID string) []string {
+	return []string{
+		fmt.Sprintf(roomKeyFormat, roomKey, roomID),
+		fmt.Sprintf(roomMembersKeyFormat, roomID),
+		roomsByMemberCountKey,
+		roomsByLastActivityKey,
+		fmt.Sprintf(roomWaitlistKeyFormat, roomID),
+		fmt.Sprintf(roomRolesKeyFormat, roomID),
+		fmt.Sprintf(roomJoinedKeyFormat, roomID),
+	}
+}
+
+// userMembershipKeys are the membershipKeys followed by the rooms of userID
+func userMembershipKeys(roomID, userID string) []string {
+	return append(membershipKeys(roomID), fmt.Sprintf(userRoomsKeyFormat, userID))
+}
+
+// removeRoomFromIndexes queues the removal of the room from every index on pipe, reading first
+// the fields, members and grams that tell which of the creator, name, user and search indexes hold it
+func (r *RedisRepository) removeRoomFromIndexes(ctx context.Context, pipe redis.Pipeliner, roomID string) error {
+	read := r.client.Pipeline()
+	fields := read.HMGet(ctx, fmt.Sprintf(roomKeyFormat, roomKey, roomID), "created_by", "name_key")
+	members := read.SMembers(ctx, fmt.Sprintf(roomMembersKeyFormat, roomID))
+	grams := read.SMembers(ctx, fmt.Sprintf(roomSearchGramsKeyFormat, roomID))
+	if _, err := read.Exec(ctx); err != nil {
+		return err
+	}
+
+	if createdBy, _ := fields.Val()[0].(string); createdBy != "" {
+		pipe.SRem(ctx, fmt.Sprintf(roomsByCreatorKeyFormat, createdBy), roomID)
+	}
+	if nameKey, _ := fields.Val()[1].(string); nameKey != "" {
+		pipe.ZRem(ctx, roomsByNameKey, nameKey)
+	}
+	for _, userID := range members.Val() {
+		pipe.SRem(ctx, fmt.Sprintf(userRoomsKeyFormat, userID), roomID)
+	}
+	for _, gram := range grams.Val() {
+		pipe.SRem(ctx, fmt.Sprintf(searchGramKeyFormat, gram), roomID)
+	}
+	pipe.Del(ctx, fmt.Sprintf(roomSearchGramsKeyFormat, roomID))
+
+	pipe.ZRem(ctx, roomsByCreatedAtKey, roomID)
+	pipe.ZRem(ctx, roomsByMemberCountKey, roomID)
+	pipe.ZRem(ctx, roomsByLastActivityKey, roomID)
+	pipe.SRem(ctx, roomsPublicKey, roomID)
+	pipe.SRem(ctx, roomsUnarchivedKey, roomID)
+	return nil
+}
+
+// ListRooms pages through the rooms of the index matching opts.OrderBy that are in every filter index,
+// intersected in Redis for the call, so each page is full unless it is the last one
+func (r *RedisRepository) ListRooms(ctx context.Context, opts RoomListOptions) ([]*Room, string, error) {
+	index, ok := roomOrderIndexes[opts.OrderBy]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown room order %d", opts.OrderBy)
+	}
+
+	var after *roomCursor
+	if opts.PageToken != "" {
+		cursor, err := decodeRoomCursor(opts.PageToken)
+		if err != nil || cursor.Order != opts.OrderBy || cursor.Desc != opts.Descending {
+			return nil, "", ErrInvalidPageToken
+		}
+		after = cursor
+	}
+
+	listed, err := r.filterRoomIndex(ctx, index, opts.Filter)
+	if err != nil {
+		return nil, "", err
+	}
+	defer r.client.Del(context.Background(), listed)
+
+	args := redis.ZRangeArgs{
+		Key:     listed,
+		Start:   "-inf",
+		Stop:    "+inf",
+		ByScore: true,
+		Rev:     opts.Descending,
+		Count:   roomListBatchSize,
+	}
+	// tie is the position of the current entry among those sharing its score
+	tieScore, tie := math.NaN(), int64(0)
+	if after != nil {
+		bound := strconv.FormatFloat(after.Score, 'f', -1, 64)
+		if opts.Descending {
+			args.Stop = bound
+		} else {
+			args.Start = bound
+		}
+
+		// the rooms tied with the last one are skipped in the query rather than read again
+		offset, err := r.tieOffset(ctx, listed, after)
+		if err != nil {
+			return nil, "", err
+		}
+		args.Offset = offset
+		tieScore, tie = after.Score, offset
+	}
+
+	rooms := make([]*Room, 0, opts.PageSize)
+	for {
+		batch, err := r.client.ZRangeArgsWithScores(ctx, args).Result()
+		if err != nil {
+			return nil, "", err
+		}
+		args.Offset += int64(len(batch))
+
+		candidates := make([]redis.Z, 0, len(batch))
+		ties := make([]int64, 0, len(batch))
+		for _, z := range batch {
+			if z.Score == tieScore {
+				tie++
+			} else {
+				tieScore, tie = z.Score, 1
+			}
+
+			// rooms added to the tie group since the last page may shift it back onto seen rooms
+			id := z.Member.(string)
+			if after != nil && z.Score == after.Score && !isAfterRoom(id, after.ID, opts.Descending) {
+				continue
+			}
+			candidates = append(candidates, z)
+			ties = append(ties, tie)
+		}
+
+		fetched, err := r.fetchRooms(ctx, candidates)
+		if err != nil {
+			return nil, "", err
+		}
+
+		for i, z := range candidates {
+			// a room deleted since the intersection is skipped
+			if fetched[i] == nil {
+				continue
+			}
+			rooms = append(rooms, fetched[i])
+			if len(rooms) == opts.PageSize {
+				last := &roomCursor{Order: opts.OrderBy, Desc: opts.Descending, Score: z.Score, ID: z.Member.(string), Skip: ties[i]}
+				return rooms, encodeRoomCursor(last), nil
+			}
+		}
+
+		if len(batch) < roomListBatchSize {
+			return rooms, "", nil
+		}
+	}
+}
+
+// filterRoomIndex stores the entries of index whose room is in every index the filter calls for
+// under a key of its own and returns that key. The rooms matching the name prefix and, with
+// VisibleTo, the rooms the user can see go through sets built for the call.
+func (r *RedisRepository) filterRoomIndex(ctx context.Context, index string, f RoomFilter) (string, error) {
+	prefix := "rooms:list:" + uuid.NewString()
+	listed := prefix + ":result"
+	keys := []string{index}
+	var scratch []string
+
+	pipe := r.client.TxPipeline()
+	if f.NamePrefix != "" {
+		start := strings.ToLower(f.NamePrefix)
+		entries, err := r.client.ZRangeByLex(ctx, roomsByNameKey, &redis.ZRangeBy{Min: "[" + start, Max: "[" + start + "\xff"}).Result()
+		if err != nil {
+			return "", err
+		}
+		named := prefix + ":named"
+		ids := make([]interface{}, len(entries))
+		for i, entry := range entries {
+			ids[i] = entry[strings.LastIndexByte(entry, 0)+1:]
+		}
+		if len(ids) > 0 {
+			pipe.SAdd(ctx, named, ids...)
+		}
+		keys, scratch = append(keys, named), append(scratch, named)
+	}
+	if f.IsPrivate != nil && *f.IsPrivate {
+		private := prefix + ":private"
+		pipe.SDiffStore(ctx, private, roomsKey, roomsPublicKey)
+		keys, scratch = append(keys, private), append(scratch, private)
+	} else if f.IsPrivate != nil {
+		keys = append(keys, roomsPublicKey)
+	}
+	if f.CreatedBy != "" {
+		keys = append(keys, fmt.Sprintf(roomsByCreatorKeyFormat, f.CreatedBy))
+	}
+	if f.SpaceID != "" {
+		keys = append(keys, fmt.Sprintf(spaceRoomsKeyFormat, f.SpaceID))
+	}
+	if f.Archived != nil && *f.Archived {
+		keys = append(keys, archivedRoomsKey)
+	} else {
+		keys = append(keys, roomsUnarchivedKey)
+	}
+	if f.VisibleTo != "" {
+		visible := prefix + ":visible"
+		pipe.SUnionStore(ctx, visible, roomsPublicKey,
+			fmt.Sprintf(roomsByCreatorKeyFormat, f.VisibleTo),
+			fmt.Sprintf(userRoomsKeyFormat, f.VisibleTo))
+		keys, scratch = append(keys, visible), append(scratch, visible)
+	}
+
+	// the rooms keep the score of the order index, the filter indexes weigh nothing
+	weights := make([]float64, len(keys))
+	weights[0] = 1
+	pipe.ZInterStore(ctx, listed, &redis.ZStore{Keys: keys, Weights: weights})
+	pipe.Expire(ctx, listed, roomListResultTTL)
+	if len(scratch) > 0 {
+		pipe.Del(ctx, scratch...)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return "", err
+	}
+	return listed, nil
+}
+
+// tieOffset is the number of rooms sharing the score of the cursor that come up to and including
+// its room. It is looked up again while the room keeps its score, the cursor tells otherwise.
+func (r *RedisRepository) tieOffset(ctx context.Context, index string, cursor *roomCursor) (int64, error) {
+	bound := "(" + strconv.FormatFloat(cursor.Score, 'f', -1, 64)
+
+	pipe := r.client.Pipeline()
+	score := pipe.ZScore(ctx, index, cursor.ID)
+	var rank, before *redis.IntCmd
+	if cursor.Desc {
+		rank = pipe.ZRevRank(ctx, index, cursor.ID)
+		before = pipe.ZCount(ctx, index, bound, "+inf")
+	} else {
+		rank = pipe.ZRank(ctx, index, cursor.ID)
+		before = pipe.ZCount(ctx, index, "-inf", bound)
+	}
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return 0, err
+	}
+
+	if score.Err() != nil || rank.Err() != nil || score.Val() != cursor.Score {
+		return cursor.Skip, nil
+	}
+	return rank.Val() - before.Val() + 1, nil
+}
+
+// fetchRooms loads the hashes of the given index entries in one round-trip,
+// rooms whose hash is gone come back as nil
+func (r *RedisRepository) fetchRooms(ctx context.Context, entries []redis.Z) ([]*Room, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(entries))
+	for i, z := range entries {
+		cmds[i] = pipe.HGetAll(ctx, fmt.Sprintf(roomKeyFormat, roomKey, z.Member.(string)))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	rooms := make([]*Room, len(entries))
+	for i, cmd := range cmds {
+		if fields := cmd.Val(); len(fields) > 0 {
+			rooms[i] = parseRoom(entries[i].Member.(string), fields)
+		}
+	}
+	return rooms, nil
+}
+
+func (r *RedisRepository) TouchRoomActivity(ctx context.Context, roomID string, at time.Time) error {
+	keys := []string{fmt.Sprintf(roomKeyFormat, roomKey, roomID), roomsByLastActivityKey}
+	return touchActivityScript.Run(ctx, r.client, keys, roomID, at.UnixMilli(), at.Format(time.RFC3339)).Err()
+}
+
+// RebuildRoomIndexes indexes rooms created before the sorted-set or the filter indexes existed and
+// gives a place in the join order and the rooms of the user to members without one, what is
+// already indexed is left untouched. A room without name_key predates the filter indexes,
+// one without search grams the search index.
+func (r *RedisRepository) RebuildRoomIndexes(ctx context.Context) error {
+	roomIDs, err := r.client.SMembers(ctx, roomsKey).Result()
+	if err != nil {
+		return err
+	}
+
+	for start := 0; start < len(roomIDs); start += roomListBatchSize {
+		ids := roomIDs[start:min(start+roomListBatchSize, len(roomIDs))]
+
+		pipe := r.client.Pipeline()
+		hashes := make([]*redis.MapStringStringCmd, len(ids))
+		members := make([]*redis.StringSliceCmd, len(ids))
+		searchable := make([]*redis.IntCmd, len(ids))
+		for i, id := range ids {
+			hashes[i] = pipe.HGetAll(ctx, fmt.Sprintf(roomKeyFormat, roomKey, id))
+			members[i] = pipe.SMembers(ctx, fmt.Sprintf(roomMembersKeyFormat, id))
+			searchable[i] = pipe.Exists(ctx, fmt.Sprintf(roomSearchGramsKeyFormat, id))
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+
+		pipe = r.client.Pipeline()
+		for i, id := range ids {
+			fields := hashes[i].Val()
+			createdAt, err := time.Parse(time.RFC3339, fields["created_at"])
+			if err != nil {
+				// the hash is missing or broken, nothing sensible to index
+				continue
+			}
+			key := fmt.Sprintf(roomKeyFormat, roomKey, id)
+			score := float64(createdAt.UnixMilli())
+			count := len(members[i].Val())
+			pipe.HSetNX(ctx, key, "member_count", count)
+			pipe.HSetNX(ctx, key, "last_activity", fields["created_at"])
+			pipe.ZAddNX(ctx, roomsByCreatedAtKey, redis.Z{Score: score, Member: id})
+			pipe.ZAddNX(ctx, roomsByMemberCountKey, redis.Z{Score: float64(count), Member: id})
+			pipe.ZAddNX(ctx, roomsByLastActivityKey, redis.Z{Score: score, Member: id})
+
+			// members who joined before join times were recorded are listed first, with a 0 score
+			for _, member := range members[i].Val() {
+				pipe.ZAddNX(ctx, fmt.Sprintf(roomJoinedKeyFormat, id), redis.Z{Score: 0, Member: member})
+				pipe.SAdd(ctx, fmt.Sprintf(userRoomsKeyFormat, member), id)
+			}
+
+			room := parseRoom(id, fields)
+			if _, indexed := fields["name_key"]; !indexed {
+				pipe.HSetNX(ctx, key, "name_key", roomNameEntry(room.Name, id))
+				addRoomToFilterIndexes(ctx, pipe, room)
+			}
+			if searchable[i].Val() == 0 {
+				addRoomToSearchIndex(ctx, pipe, room)
+			}
+		}
+		if _, err := pipe.Exec(ctx); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func isAfterRoom(id, lastID string, descending bool) bool {
+	if descending {
+		return id < lastID
+	}
+	return id > lastID
+}
+
+func encodeRoomCursor(cursor *roomCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeRoomCursor(token string) (*roomCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor roomCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,90 @@
+package room
+
+import (
+	"context"
+	"time"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *RoomHandler) CreateInviteLink(ctx context.Context, req *pb.CreateInviteLinkRequest) (*pb.InviteLink, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	var expiresAt time.Time
+	if req.ExpiresAt != nil {
+		expiresAt = req.ExpiresAt.AsTime()
+	}
+
+	invite, err := h.service.CreateInviteLink(ctx, req.RoomId, userID.String(), int(req.MaxUses), expiresAt)
+	if err != nil {
+		return nil, statusFromError(err, "failed to create invite link")
+	}
+
+	return convertToPbInviteLink(invite), nil
+}
+
+func (h *RoomHandler) RevokeInviteLink(ctx context.Context, req *pb.RevokeInviteLinkRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if err := h.service.RevokeInviteLink(ctx, req.Code, userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to revoke invite link")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *RoomHandler) ListInviteLinks(ctx context.Context, req *pb.ListInviteLinksRequest) (*pb.ListInviteLinksResponse, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	invites, err := h.service.ListInviteLinks(ctx, req.RoomId, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to list invite links")
+	}
+
+	links := make([]*pb.InviteLink, len(invites))
+	for i, invite := range invites {
+		links[i] = convertToPbInviteLink(invite)
+	}
+
+	return &pb.ListInviteLinksResponse{Links: links}, nil
+}
+
+func (h *RoomHandler) JoinByInviteCode(ctx context.Context, req *pb.JoinByInviteCodeRequest) (*pb.Room, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	room, err := h.service.JoinByInviteCode(ctx, req.Code, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to join room")
+	}
+
+	return convertToPbRoom(room), nil
+}
+
+func convertToPbInviteLink(invite *InviteLink) *pb.InviteLink {
+	return &pb.InviteLink{
+		Code:      invite.Code,
+		RoomId:    invite.RoomID,
+		CreatedBy: invite.CreatedBy,
+		CreatedAt: timestamppb.New(invite.CreatedAt),
+		ExpiresAt: optionalTimestamp(invite.ExpiresAt),
+		MaxUses:   uint32(invite.MaxUses),
+		Uses:      uint32(invite.Uses),
+	}
+}
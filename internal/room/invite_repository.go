@@ -0,0 +1,205 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// An invite lives in its own invite:<code> hash, expiring with the link,
+// and is referenced from the room:<id>:invites set
+const (
+	inviteKeyFormat      = "invite:%s"
+	roomInvitesKeyFormat = "room:%s:invites"
+)
+
+var (
+	ErrInviteNotFound = errors.New("invite link not found or expired")
+	ErrInviteUsedUp   = errors.New("invite link has reached its maximum number of uses")
+)
+
+// joinByInviteScript adds ARGV[2] to the room ARGV[1] of the invite KEYS[1] and counts a use of the
+// invite, KEYS[2..] being the userMembershipKeys of the room. It returns 1 once joined, 0 for a member,
+// -1 for a missing (revoked or expired) invite, -2 for a missing room, -3 for an archived one,
+// -4 when max_uses is reached and -5 when the room is full.
+var joinByInviteScript = redis.NewScript(`
+if redis.call('HGET', KEYS[1], 'room_id') ~= ARGV[1] then
+	return -1
+end
+if redis.call('EXISTS', KEYS[2]) == 0 then
+	return -2
+end
+if redis.call('SISMEMBER', KEYS[3], ARGV[2]) == 1 then
+	return 0
+end
+if redis.call('HEXISTS', KEYS[2], 'archived_at') == 1 then
+	return -3
+end
+local maxUses = tonumber(redis.call('HGET', KEYS[1], 'max_uses')) or 0
+local uses = tonumber(redis.call('HGET', KEYS[1], 'uses')) or 0
+if maxUses > 0 and uses >= maxUses then
+	return -4
+end
+local maxMembers = tonumber(redis.call('HGET', KEYS[2], 'max_members')) or 0
+if maxMembers > 0 and (redis.call('SCARD', KEYS[3]) >= maxMembers or redis.call('ZCARD', KEYS[6]) > 0) then
+	return -5
+end
+
+redis.call('HINCRBY', KEYS[1], 'uses', 1)
+redis.call('SADD', KEYS[3], ARGV[2])
+redis.call('ZADD', KEYS[8], ARGV[3], ARGV[2])
+redis.call('SADD', KEYS[9], ARGV[1])
+redis.call('HINCRBY', KEYS[2], 'member_count', 1)
+redis.call('ZINCRBY', KEYS[4], 1, ARGV[1])
+redis.call('HSET', KEYS[2], 'last_activity', ARGV[4])
+redis.call('ZADD', KEYS[5], ARGV[3], ARGV[1])
+return 1
+`)
+
+func (r *RedisRepository) CreateInvite(ctx context.Context, invite *InviteLink) error {
+	key := fmt.Sprintf(inviteKeyFormat, invite.Code)
+	expiresAt := ""
+	if !invite.ExpiresAt.IsZero() {
+		expiresAt = invite.ExpiresAt.Format(time.RFC3339)
+	}
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, key,
+		"room_id", invite.RoomID,
+		"created_by", invite.CreatedBy,
+		"created_at", invite.CreatedAt.Format(time.RFC3339),
+		"expires_at", expiresAt,
+		"max_uses", invite.MaxUses,
+		"uses", invite.Uses,
+	)
+	if !invite.ExpiresAt.IsZero() {
+		pipe.ExpireAt(ctx, key, invite.ExpiresAt)
+	}
+	pipe.SAdd(ctx, fmt.Sprintf(roomInvitesKeyFormat, invite.RoomID), invite.Code)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisRepository) GetInvite(ctx context.Context, code string) (*InviteLink, error) {
+	fields, err := r.client.HGetAll(ctx, fmt.Sprintf(inviteKeyFormat, code)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrInviteNotFound
+	}
+	return parseInvite(code, fields), nil
+}
+
+// ListInvites returns the live invites of a room and forgets the expired ones
+func (r *RedisRepository) ListInvites(ctx context.Context, roomID string) ([]*InviteLink, error) {
+	setKey := fmt.Sprintf(roomInvitesKeyFormat, roomID)
+	codes, err := r.client.SMembers(ctx, setKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(codes) == 0 {
+		return nil, nil
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(codes))
+	for i, code := range codes {
+		cmds[i] = pipe.HGetAll(ctx, fmt.Sprintf(inviteKeyFormat, code))
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	invites := make([]*InviteLink, 0, len(codes))
+	var expired []interface{}
+	for i, cmd := range cmds {
+		if len(cmd.Val()) == 0 {
+			expired = append(expired, codes[i])
+			continue
+		}
+		invites = append(invites, parseInvite(codes[i], cmd.Val()))
+	}
+
+	if len(expired) > 0 {
+		if err := r.client.SRem(ctx, setKey, expired...).Err(); err != nil {
+			return nil, err
+		}
+	}
+	return invites, nil
+}
+
+func (r *RedisRepository) JoinByInvite(ctx context.Context, code, userID string) (bool, error) {
+	roomID, err := r.client.HGet(ctx, fmt.Sprintf(inviteKeyFormat, code), "room_id").Result()
+	if errors.Is(err, redis.Nil) {
+		return false, ErrInviteNotFound
+	}
+	if err != nil {
+		return false, err
+	}
+
+	now := time.Now()
+	keys := append([]string{fmt.Sprintf(inviteKeyFormat, code)}, userMembershipKeys(roomID, userID)...)
+	result, err := joinByInviteScript.Run(ctx, r.client, keys, roomID, userID, now.UnixMilli(), now.Format(time.RFC3339)).Int()
+	if err != nil {
+		return false, err
+	}
+
+	switch result {
+	case -1:
+		return false, ErrInviteNotFound
+	case -2:
+		return false, ErrRoomNotFound
+	case -3:
+		return false, ErrRoomArchived
+	case -4:
+		return false, ErrInviteUsedUp
+	case -5:
+		return false, ErrRoomFull
+	}
+	return result == 1, nil
+}
+
+func (r *RedisRepository) DeleteInvite(ctx context.Context, roomID, code string) error {
+	pipe := r.client.TxPipeline()
+	pipe.Del(ctx, fmt.Sprintf(inviteKeyFormat, code))
+	pipe.SRem(ctx, fmt.Sprintf(roomInvitesKeyFormat, roomID), code)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// deleteRoomInvites queues the removal of every invite of the room on pipe
+func (r *RedisRepository) deleteRoomInvites(ctx context.Context, pipe redis.Pipeliner, roomID string) error {
+	setKey := fmt.Sprintf(roomInvitesKeyFormat, roomID)
+	codes, err := r.client.SMembers(ctx, setKey).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, code := range codes {
+		pipe.Del(ctx, fmt.Sprintf(inviteKeyFormat, code))
+	}
+	pipe.Del(ctx, setKey)
+	return nil
+}
+
+func parseInvite(code string, fields map[string]string) *InviteLink {
+	createdAt, _ := time.Parse(time.RFC3339, fields["created_at"])
+	expiresAt, _ := time.Parse(time.RFC3339, fields["expires_at"])
+	maxUses, _ := strconv.Atoi(fields["max_uses"])
+	uses, _ := strconv.Atoi(fields["uses"])
+
+	return &InviteLink{
+		Code:      code,
+		RoomID:    fields["room_id"],
+		CreatedBy: fields["created_by"],
+		CreatedAt: createdAt,
+		ExpiresAt: expiresAt,
+		MaxUses:   maxUses,
+		Uses:      uses,
+	}
+}
@@ -0,0 +1,160 @@
+package room
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+)
+
+// inviteCodeBytes of randomness make codes unguessable
+const inviteCodeBytes = 16
+
+var ErrInvalidInvite = errors.New("invalid invite link settings")
+
+// CreateInviteLink lets a member of the room hand out a link to it, maxUses 0 means unlimited
+// and a zero expiresAt means the link stays valid until revoked
+func (s *RoomService) CreateInviteLink(ctx context.Context, roomID, userID string, maxUses int, expiresAt time.Time) (*InviteLink, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if room.IsArchived() {
+		return nil, ErrRoomArchived
+	}
+	if err := s.checkRoomMember(ctx, room, userID); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	if maxUses < 0 || (!expiresAt.IsZero() && !expiresAt.After(now)) {
+		return nil, ErrInvalidInvite
+	}
+
+	code, err := newInviteCode()
+	if err != nil {
+		return nil, err
+	}
+
+	invite := &InviteLink{
+		Code:      code,
+		RoomID:    roomID,
+		CreatedBy: userID,
+		CreatedAt: now,
+		ExpiresAt: expiresAt,
+		MaxUses:   maxUses,
+	}
+	if err := s.repo.CreateInvite(ctx, invite); err != nil {
+		return nil, err
+	}
+	return invite, nil
+}
+
+// RevokeInviteLink can be done by the creator of the link or the room owner
+func (s *RoomService) RevokeInviteLink(ctx context.Context, code, userID string) error {
+	invite, err := s.repo.GetInvite(ctx, code)
+	if err != nil {
+		return err
+	}
+
+	if invite.CreatedBy != userID {
+		room, err := s.repo.GetRoom(ctx, invite.RoomID)
+		if err != nil {
+			return err
+		}
+		if room.CreatedBy != userID {
+			return ErrNotRoomOwner
+		}
+	}
+
+	return s.repo.DeleteInvite(ctx, invite.RoomID, code)
+}
+
+// ListInviteLinks returns every live link of the room to its owner and only their own links to members
+func (s *RoomService) ListInviteLinks(ctx context.Context, roomID, userID string) ([]*InviteLink, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.checkRoomMember(ctx, room, userID); err != nil {
+		return nil, err
+	}
+
+	invites, err := s.repo.ListInvites(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.CreatedBy == userID {
+		return invites, nil
+	}
+
+	own := make([]*InviteLink, 0, len(invites))
+	for _, invite := range invites {
+		if invite.CreatedBy == userID {
+			own = append(own, invite)
+		}
+	}
+	return own, nil
+}
+
+// JoinByInviteCode adds the caller to the room of the invite, private rooms included.
+// Members redeeming a link again do not use it up.
+func (s *RoomService) JoinByInviteCode(ctx context.Context, code, userID string) (*Room, error) {
+	invite, err := s.repo.GetInvite(ctx, code)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := s.repo.GetRoom(ctx, invite.RoomID)
+	if err != nil {
+		return nil, err
+	}
+
+	// the use is counted together with the membership, a join that fails uses nothing
+	joined, err := s.repo.JoinByInvite(ctx, code, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !joined {
+		return room, nil
+	}
+	room.MemberCount++
+	s.publishMemberDelta(room, userID, 1)
+	s.notifyUser(userID, UserEvent{Type: UserRoomAdded, RoomID: room.ID})
+
+	// notifies other users
+	s.broadcastRoomEvent(room.ID, RoomEvent{
+		Type:   EventUserJoined,
+		UserID: userID,
+		RoomID: room.ID,
+	})
+
+	return room, nil
+}
+
+// checkRoomMember lets the owner and the members of the room through
+func (s *RoomService) checkRoomMember(ctx context.Context, room *Room, userID string) error {
+	if room.CreatedBy == userID {
+		return nil
+	}
+
+	isMember, err := s.repo.IsRoomMember(ctx, room.ID, userID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrNotRoomMember
+	}
+	return nil
+}
+
+func newInviteCode() (string, error) {
+	raw := make([]byte, inviteCodeBytes)
+	if _, err := rand.Read(raw); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(raw), nil
+}
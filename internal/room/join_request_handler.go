@@ -0,0 +1,134 @@
+package room
+
+import (
+	"context"
+	"log"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *RoomHandler) RequestToJoin(req *pb.RequestToJoinRequest, stream pb.RoomGrpcService_RequestToJoinServer) error {
+	userID, ok := stream.Context().Value("user_id").(uuid.UUID)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	request, events, err := h.service.RequestToJoin(stream.Context(), req.RoomId, userID.String(), req.Message)
+	if err != nil {
+		return statusFromError(err, "failed to request to join room")
+	}
+
+	pbRequest := convertToPbJoinRequest(request)
+	if err := stream.Send(&pb.JoinRequestUpdate{Request: pbRequest, State: pb.JoinRequestState_JOIN_REQUEST_PENDING}); err != nil {
+		return err
+	}
+
+	for event := range events.Events() {
+		if event.Type != EventJoinRequestResolved {
+			continue
+		}
+
+		var decision JoinRequestDecision
+		if err := event.DecodePayload(&decision); err != nil {
+			log.Printf("Failed to decode join request decision: %v", err)
+			continue
+		}
+		state := pb.JoinRequestState_JOIN_REQUEST_REJECTED
+		if decision.Approved {
+			state = pb.JoinRequestState_JOIN_REQUEST_APPROVED
+		}
+		return stream.Send(&pb.JoinRequestUpdate{Request: pbRequest, State: state, ReviewedBy: decision.ReviewedBy})
+	}
+
+	return eventStreamError(events)
+}
+
+func (h *RoomHandler) ListJoinRequests(ctx context.Context, req *pb.ListJoinRequestsRequest) (*pb.ListJoinRequestsResponse, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	requests, err := h.service.ListJoinRequests(ctx, req.RoomId, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to list join requests")
+	}
+
+	pbRequests := make([]*pb.JoinRequest, len(requests))
+	for i, request := range requests {
+		pbRequests[i] = convertToPbJoinRequest(request)
+	}
+
+	return &pb.ListJoinRequestsResponse{Requests: pbRequests}, nil
+}
+
+func (h *RoomHandler) ApproveJoinRequest(ctx context.Context, req *pb.JoinRequestDecisionRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if err := h.service.ApproveJoinRequest(ctx, req.RoomId, req.UserId, userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to approve join request")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *RoomHandler) RejectJoinRequest(ctx context.Context, req *pb.JoinRequestDecisionRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if err := h.service.RejectJoinRequest(ctx, req.RoomId, req.UserId, userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to reject join request")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+// convertToPbJoinRequestEvent turns a join request event of a member stream into its RoomEvent
+func convertToPbJoinRequestEvent(event RoomEvent) *pb.RoomEvent {
+	switch event.Type {
+	case EventJoinRequested:
+		var request JoinRequest
+		if err := event.DecodePayload(&request); err != nil {
+			log.Printf("Failed to decode join request: %v", err)
+			return nil
+		}
+		return &pb.RoomEvent{
+			Event: &pb.RoomEvent_JoinRequested{JoinRequested: convertToPbJoinRequest(&request)},
+		}
+	case EventJoinRequestResolved:
+		var decision JoinRequestDecision
+		if err := event.DecodePayload(&decision); err != nil {
+			log.Printf("Failed to decode join request decision: %v", err)
+			return nil
+		}
+		return &pb.RoomEvent{
+			Event: &pb.RoomEvent_JoinRequestResolved{
+				JoinRequestResolved: &pb.JoinRequestResolved{
+					UserId:     event.UserID,
+					Approved:   decision.Approved,
+					ReviewedBy: decision.ReviewedBy,
+				},
+			},
+		}
+	}
+	return nil
+}
+
+func convertToPbJoinRequest(request *JoinRequest) *pb.JoinRequest {
+	return &pb.JoinRequest{
+		RoomId:      request.RoomID,
+		UserId:      request.UserID,
+		Message:     request.Message,
+		RequestedAt: timestamppb.New(request.RequestedAt),
+	}
+}
@@ -0,0 +1,78 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Pending join requests of a room are kept in the room:<id>:join_requests hash,
+// keyed by requester with the JSON-encoded request as value
+const roomJoinRequestsKeyFormat = "room:%s:join_requests"
+
+var ErrJoinRequestNotFound = errors.New("join request not found")
+
+// AddJoinRequest stores the request unless the user already has one pending in the room,
+// it returns the pending request and whether it was just created
+func (r *RedisRepository) AddJoinRequest(ctx context.Context, request *JoinRequest) (*JoinRequest, bool, error) {
+	key := fmt.Sprintf(roomJoinRequestsKeyFormat, request.RoomID)
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal join request: %w", err)
+	}
+
+	pipe := r.client.TxPipeline()
+	created := pipe.HSetNX(ctx, key, request.UserID, payload)
+	stored := pipe.HGet(ctx, key, request.UserID)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, false, err
+	}
+
+	var pending JoinRequest
+	if err := json.Unmarshal([]byte(stored.Val()), &pending); err != nil {
+		return nil, false, err
+	}
+	return &pending, created.Val(), nil
+}
+
+func (r *RedisRepository) ListJoinRequests(ctx context.Context, roomID string) ([]*JoinRequest, error) {
+	entries, err := r.client.HVals(ctx, fmt.Sprintf(roomJoinRequestsKeyFormat, roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	requests := make([]*JoinRequest, 0, len(entries))
+	for _, entry := range entries {
+		var request JoinRequest
+		if err := json.Unmarshal([]byte(entry), &request); err != nil {
+			return nil, err
+		}
+		requests = append(requests, &request)
+	}
+	return requests, nil
+}
+
+// TakeJoinRequest removes the pending request of the user and returns it, so a request
+// is only ever approved or rejected once
+func (r *RedisRepository) TakeJoinRequest(ctx context.Context, roomID, userID string) (*JoinRequest, error) {
+	key := fmt.Sprintf(roomJoinRequestsKeyFormat, roomID)
+
+	pipe := r.client.TxPipeline()
+	stored := pipe.HGet(ctx, key, userID)
+	pipe.HDel(ctx, key, userID)
+	if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	if errors.Is(stored.Err(), redis.Nil) {
+		return nil, ErrJoinRequestNotFound
+	}
+
+	var request JoinRequest
+	if err := json.Unmarshal([]byte(stored.Val()), &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
@@ -0,0 +1,190 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+const maxJoinRequestMessageLength = 500
+
+var (
+	ErrJoinRequestNotNeeded = errors.New("room can be joined directly, no join request is needed")
+	ErrInvalidJoinRequest   = errors.New("join request message cannot exceed 500 characters")
+)
+
+// RequestToJoin asks the owner and admins of a private room to let the user in. The request
+// stays pending until reviewed, asking again returns the pending request. The returned stream
+// follows the request: it gets the decision once made, or the room deletion.
+func (s *RoomService) RequestToJoin(ctx context.Context, roomID, userID, message string) (*JoinRequest, *EventStream, error) {
+	if utf8.RuneCountInString(message) > maxJoinRequestMessageLength {
+		return nil, nil, ErrInvalidJoinRequest
+	}
+
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if room.IsArchived() {
+		return nil, nil, ErrRoomArchived
+	}
+
+	isMember, err := s.repo.IsRoomMember(ctx, roomID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	canJoin, err := s.canJoinRoom(ctx, room, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if isMember || canJoin {
+		return nil, nil, ErrJoinRequestNotNeeded
+	}
+
+	// following the room before storing the request, so the decision cannot be missed
+	accept := func(event RoomEvent) bool {
+		switch event.Type {
+		case EventJoinRequestResolved:
+			return event.UserID == userID
+		case EventRoomDeleted:
+			return true
+		}
+		return false
+	}
+	stream := s.hub.subscribe(ctx, roomID, accept)
+
+	request, created, err := s.repo.AddJoinRequest(ctx, &JoinRequest{
+		RoomID:      roomID,
+		UserID:      userID,
+		Message:     message,
+		RequestedAt: time.Now(),
+	})
+	if err != nil {
+		stream.close(nil)
+		return nil, nil, err
+	}
+
+	if created {
+		event, err := NewRoomEvent(EventJoinRequested, roomID, userID, request)
+		if err != nil {
+			log.Printf("Failed to build join request event: %v", err)
+		} else {
+			s.broadcastRoomEvent(roomID, event)
+		}
+	}
+	return request, stream, nil
+}
+
+// ListJoinRequests returns the pending requests of the room, oldest first, to its owner and admins
+func (s *RoomService) ListJoinRequests(ctx context.Context, roomID, userID string) ([]*JoinRequest, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkJoinRequestReviewer(ctx, room, userID); err != nil {
+		return nil, err
+	}
+
+	requests, err := s.repo.ListJoinRequests(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(requests, func(i, j int) bool {
+		a, b := requests[i].RequestedAt, requests[j].RequestedAt
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return requests[i].UserID < requests[j].UserID
+	})
+	return requests, nil
+}
+
+// ApproveJoinRequest adds the requester to the room. A full room keeps the request pending.
+func (s *RoomService) ApproveJoinRequest(ctx context.Context, roomID, requesterID, userID string) error {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if room.IsArchived() {
+		return ErrRoomArchived
+	}
+	if err := s.checkJoinRequestReviewer(ctx, room, userID); err != nil {
+		return err
+	}
+
+	request, err := s.repo.TakeJoinRequest(ctx, roomID, requesterID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.AddRoomMember(ctx, roomID, requesterID); err != nil {
+		if _, _, restoreErr := s.repo.AddJoinRequest(context.Background(), request); restoreErr != nil {
+			log.Printf("Failed to restore join request of %s: %v", requesterID, restoreErr)
+		}
+		return err
+	}
+
+	s.broadcastJoinRequestDecision(roomID, requesterID, JoinRequestDecision{Approved: true, ReviewedBy: userID})
+	s.broadcastRoomEvent(roomID, RoomEvent{
+		Type:   EventUserJoined,
+		UserID: requesterID,
+		RoomID: roomID,
+	})
+	s.publishMemberDelta(room, requesterID, 1)
+	s.notifyUser(requesterID, UserEvent{Type: UserRoomAdded, RoomID: roomID})
+	return nil
+}
+
+// RejectJoinRequest turns the request down, the user may ask again later
+func (s *RoomService) RejectJoinRequest(ctx context.Context, roomID, requesterID, userID string) error {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if err := s.checkJoinRequestReviewer(ctx, room, userID); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.TakeJoinRequest(ctx, roomID, requesterID); err != nil {
+		return err
+	}
+	s.broadcastJoinRequestDecision(roomID, requesterID, JoinRequestDecision{Approved: false, ReviewedBy: userID})
+	return nil
+}
+
+// ReviewsJoinRequests tells whether the user is an owner or admin of the room,
+// member streams only pass join request events on to them
+func (s *RoomService) ReviewsJoinRequests(ctx context.Context, roomID, userID string) (bool, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return false, err
+	}
+	role, err := s.memberRole(ctx, room, userID)
+	if err != nil {
+		return false, err
+	}
+	return role >= RoleAdmin, nil
+}
+
+func (s *RoomService) checkJoinRequestReviewer(ctx context.Context, room *Room, userID string) error {
+	role, err := s.memberRole(ctx, room, userID)
+	if err != nil {
+		return err
+	}
+	if role < RoleAdmin {
+		return ErrInsufficientRole
+	}
+	return nil
+}
+
+func (s *RoomService) broadcastJoinRequestDecision(roomID, requesterID string, decision JoinRequestDecision) {
+	event, err := NewRoomEvent(EventJoinRequestResolved, roomID, requesterID, decision)
+	if err != nil {
+		log.Printf("Failed to build join request decision event: %v", err)
+		return
+	}
+	s.broadcastRoomEvent(roomID, event)
+	s.notifyUser(requesterID, UserEvent{Type: UserJoinRequestResolved, RoomID: roomID, Decision: &decision})
+}
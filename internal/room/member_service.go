@@ -0,0 +1,211 @@
+package room
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+const (
+	defaultMemberPageSize = 50
+	maxMemberPageSize     = 200
+)
+
+// memberCursor is the last member of a ListRoomMembers page, members joined at the
+// same time are ordered by user ID
+type memberCursor struct {
+	JoinedAt int64  `json:"j"`
+	ID       string `json:"id"`
+}
+
+// ListRoomMembers pages through the members of the room in join order with their profile,
+// role, mute and presence. The members of a private room are only listed to its members.
+func (s *RoomService) ListRoomMembers(ctx context.Context, roomID, userID string, opts MemberListOptions) ([]MemberInfo, string, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, "", err
+	}
+	if room.IsPrivate {
+		isMember, err := s.repo.IsRoomMember(ctx, roomID, userID)
+		if err != nil {
+			return nil, "", err
+		}
+		if !isMember {
+			return nil, "", ErrNotRoomMember
+		}
+	}
+
+	pageSize := opts.PageSize
+	if pageSize <= 0 {
+		pageSize = defaultMemberPageSize
+	}
+	pageSize = min(pageSize, maxMemberPageSize)
+
+	var after *memberCursor
+	if opts.PageToken != "" {
+		cursor, err := decodeMemberCursor(opts.PageToken)
+		if err != nil {
+			return nil, "", ErrInvalidPageToken
+		}
+		after = cursor
+	}
+
+	// the store pages through the members, filters may need a few pages to fill this one
+	infos := make([]MemberInfo, 0, pageSize+1)
+	for len(infos) <= pageSize {
+		page, err := s.repo.ListRoomMembersPage(ctx, roomID, after, pageSize+1)
+		if err != nil {
+			return nil, "", err
+		}
+		if len(page) == 0 {
+			break
+		}
+		if err := s.resolveMemberStates(ctx, room, page); err != nil {
+			return nil, "", err
+		}
+
+		for _, info := range page {
+			if opts.matches(info) {
+				infos = append(infos, info)
+			}
+		}
+		if len(page) <= pageSize {
+			break
+		}
+		after = newMemberCursor(page[len(page)-1])
+	}
+
+	var nextPageToken string
+	if len(infos) > pageSize {
+		infos = infos[:pageSize]
+		nextPageToken = encodeMemberCursor(newMemberCursor(infos[pageSize-1]))
+	}
+
+	if err := s.resolveProfiles(ctx, infos); err != nil {
+		return nil, "", err
+	}
+	return infos, nextPageToken, nil
+}
+
+// resolveMemberStates sets the owner role, the mutes and the presence of a page of members
+func (s *RoomService) resolveMemberStates(ctx context.Context, room *Room, infos []MemberInfo) error {
+	userIDs := make([]string, len(infos))
+	for i, info := range infos {
+		userIDs[i] = info.UserID
+	}
+
+	mutes, err := s.repo.GetMemberMutes(ctx, room.ID, userIDs)
+	if err != nil {
+		return err
+	}
+	sessions, err := s.repo.GetMembersPresence(ctx, room.ID, userIDs)
+	if err != nil {
+		return err
+	}
+	online := onlineUsers(sessions)
+
+	for i := range infos {
+		if infos[i].UserID == room.CreatedBy {
+			infos[i].Role = RoleOwner
+		}
+		infos[i].MutedUntil, infos[i].Muted = mutes[infos[i].UserID]
+		_, infos[i].Online = online[infos[i].UserID]
+	}
+	return nil
+}
+
+// resolveProfiles fills the username and display name of the members in one lookup,
+// the display name falls back to the username
+func (s *RoomService) resolveProfiles(ctx context.Context, infos []MemberInfo) error {
+	if s.profiles == nil || len(infos) == 0 {
+		return nil
+	}
+
+	userIDs := make([]string, len(infos))
+	for i, info := range infos {
+		userIDs[i] = info.UserID
+	}
+	profiles, err := s.profiles.GetUserProfiles(ctx, userIDs)
+	if err != nil {
+		return err
+	}
+
+	for i := range infos {
+		profile, ok := profiles[infos[i].UserID]
+		if !ok {
+			continue
+		}
+		infos[i].Username = profile.Username
+		infos[i].DisplayName = profile.DisplayName
+		if infos[i].DisplayName == "" {
+			infos[i].DisplayName = profile.Username
+		}
+	}
+	return nil
+}
+
+func (o MemberListOptions) matches(info MemberInfo) bool {
+	if o.Role != nil && info.Role != *o.Role {
+		return false
+	}
+	if o.Status != nil && info.Status() != *o.Status {
+		return false
+	}
+	return true
+}
+
+// sortByJoinTime orders members from the longest-standing one, ties broken by user ID
+func sortByJoinTime(members []string, joined map[string]time.Time) {
+	sort.Slice(members, func(i, j int) bool {
+		a, b := joined[members[i]], joined[members[j]]
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return members[i] < members[j]
+	})
+}
+
+// newMemberCursor points right after the member, a member without join time is encoded with 0
+func newMemberCursor(info MemberInfo) *memberCursor {
+	cursor := &memberCursor{ID: info.UserID}
+	if !info.JoinedAt.IsZero() {
+		cursor.JoinedAt = info.JoinedAt.UnixNano()
+	}
+	return cursor
+}
+
+func (c *memberCursor) joinedAt() time.Time {
+	if c.JoinedAt == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, c.JoinedAt)
+}
+
+// isBefore tells whether the member comes after the cursor in join order
+func (c *memberCursor) isBefore(info MemberInfo) bool {
+	other := newMemberCursor(info)
+	if other.JoinedAt != c.JoinedAt {
+		return other.JoinedAt > c.JoinedAt
+	}
+	return other.ID > c.ID
+}
+
+func encodeMemberCursor(cursor *memberCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeMemberCursor(token string) (*memberCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor memberCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
@@ -0,0 +1,125 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// memoryBroker carries room events between the streams of a single process,
+// it stands in for Redis pub/sub when the server runs alone
+type memoryBroker struct {
+	mu   sync.Mutex
+	subs map[string]map[*memorySubscription]struct{}
+}
+
+func newMemoryBroker() *memoryBroker {
+	return &memoryBroker{subs: make(map[string]map[*memorySubscription]struct{})}
+}
+
+func (b *memoryBroker) subscribe(roomID string) *memorySubscription {
+	sub := &memorySubscription{
+		broker: b,
+		roomID: roomID,
+		notify: make(chan struct{}, 1),
+		done:   make(chan struct{}),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.subs[roomID] == nil {
+		b.subs[roomID] = make(map[*memorySubscription]struct{})
+	}
+	b.subs[roomID][sub] = struct{}{}
+	return sub
+}
+
+// publish never blocks, every subscription queues the payloads it has not received yet
+func (b *memoryBroker) publish(roomID string, event interface{}) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for sub := range b.subs[roomID] {
+		sub.push(payload)
+	}
+	return nil
+}
+
+func (b *memoryBroker) unsubscribe(sub *memorySubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	delete(b.subs[sub.roomID], sub)
+	if len(b.subs[sub.roomID]) == 0 {
+		delete(b.subs, sub.roomID)
+	}
+}
+
+type memorySubscription struct {
+	broker *memoryBroker
+	roomID string
+	notify chan struct{}
+	done   chan struct{}
+
+	mu     sync.Mutex
+	queue  [][]byte
+	closed bool
+}
+
+func (s *memorySubscription) push(payload []byte) {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.queue = append(s.queue, payload)
+	s.mu.Unlock()
+
+	select {
+	case s.notify <- struct{}{}:
+	default:
+	}
+}
+
+func (s *memorySubscription) Receive(ctx context.Context) ([]byte, error) {
+	for {
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			return nil, ErrSubscriptionClosed
+		}
+		if len(s.queue) > 0 {
+			payload := s.queue[0]
+			s.queue = s.queue[1:]
+			s.mu.Unlock()
+			return payload, nil
+		}
+		s.mu.Unlock()
+
+		select {
+		case <-s.notify:
+		case <-s.done:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+func (s *memorySubscription) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.queue = nil
+	close(s.done)
+	s.mu.Unlock()
+
+	s.broker.unsubscribe(s)
+	return nil
+}
@@ -0,0 +1,1234 @@
+package room
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryRepository keeps everything in the memory of the process and carries the room events
+// through an in-process broker. It mirrors RedisRepository for a single node, which makes it
+// fit for local development and for running RoomService without any external service.
+type MemoryRepository struct {
+	broker *memoryBroker
+
+	mu    sync.Mutex
+	rooms map[string]*Room
+	// purgeSchedule holds the archived rooms by the time they are due, or claimed again for those
+	// already returned by ClaimRoomsToPurge
+	purgeSchedule map[string]time.Time
+	members       map[string]map[string]struct{}
+	joined        map[string]map[string]time.Time
+	// waitlists are kept in queue order
+	waitlists map[string][]string
+	roles     map[string]map[string]MemberRole
+	// a zero mute time stands for a mute without expiry
+	mutes    map[string]map[string]time.Time
+	slowMode map[string]map[string]time.Time
+	// messages hold the history of each room, oldest first
+	messages map[string][]*ChatMessage
+
+	invites     map[string]*InviteLink
+	roomInvites map[string]map[string]struct{}
+	// joinRequests are keyed by room, then by requester
+	joinRequests map[string]map[string]*JoinRequest
+	// metadata is keyed by room, then by metadataField
+	metadata         map[string]map[string]string
+	metadataPolicies map[string]map[string]MetadataPolicy
+	templates        map[string]*RoomTemplate
+
+	spaces          map[string]*Space
+	spaceMembers    map[string]map[string]struct{}
+	spaceCategories map[string][]string
+	spaceRooms      map[string]map[string][]string
+
+	// presence is keyed by room, then by roomPresenceEntry
+	presence map[string]map[string]PresenceSession
+}
+
+func NewMemoryRepository() *MemoryRepository {
+	return &MemoryRepository{
+		broker:           newMemoryBroker(),
+		rooms:            make(map[string]*Room),
+		purgeSchedule:    make(map[string]time.Time),
+		members:          make(map[string]map[string]struct{}),
+		joined:           make(map[string]map[string]time.Time),
+		waitlists:        make(map[string][]string),
+		roles:            make(map[string]map[string]MemberRole),
+		mutes:            make(map[string]map[string]time.Time),
+		slowMode:         make(map[string]map[string]time.Time),
+		messages:         make(map[string][]*ChatMessage),
+		invites:          make(map[string]*InviteLink),
+		roomInvites:      make(map[string]map[string]struct{}),
+		joinRequests:     make(map[string]map[string]*JoinRequest),
+		metadata:         make(map[string]map[string]string),
+		metadataPolicies: make(map[string]map[string]MetadataPolicy),
+		templates:        make(map[string]*RoomTemplate),
+		spaces:           make(map[string]*Space),
+		spaceMembers:     make(map[string]map[string]struct{}),
+		spaceCategories:  make(map[string][]string),
+		spaceRooms:       make(map[string]map[string][]string),
+		presence:         make(map[string]map[string]PresenceSession),
+	}
+}
+
+func (r *MemoryRepository) CreateRoom(ctx context.Context, room *Room) error {
+	stored := *room
+	if stored.LastActivity.IsZero() {
+		stored.LastActivity = stored.CreatedAt
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.rooms[room.ID] = &stored
+	return nil
+}
+
+func (r *MemoryRepository) GetRoom(ctx context.Context, roomID string) (*Room, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.rooms[roomID]
+	if !ok {
+		return nil, ErrRoomNotFound
+	}
+	copied := *room
+	return &copied, nil
+}
+
+// UpdateRoom only writes the fields a RoomUpdate can change
+func (r *MemoryRepository) UpdateRoom(ctx context.Context, room *Room) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored, ok := r.rooms[room.ID]
+	if !ok {
+		return ErrRoomNotFound
+	}
+	stored.Name = room.Name
+	stored.Topic = room.Topic
+	stored.Description = room.Description
+	stored.AvatarURL = room.AvatarURL
+	stored.IsPrivate = room.IsPrivate
+	stored.MaxMembers = room.MaxMembers
+	stored.SlowModeInterval = room.SlowModeInterval
+	stored.AnnouncementOnly = room.AnnouncementOnly
+	return nil
+}
+
+func (r *MemoryRepository) DeleteRoom(ctx context.Context, roomID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deleteRoom(roomID)
+	return nil
+}
+
+// deleteRoom drops the room and everything kept for it, r.mu must be held
+func (r *MemoryRepository) deleteRoom(roomID string) {
+	if room, ok := r.rooms[roomID]; ok && room.SpaceID != "" {
+		if categories := r.spaceRooms[room.SpaceID]; categories != nil {
+			categories[room.Category] = slices.DeleteFunc(categories[room.Category], func(id string) bool {
+				return id == roomID
+			})
+		}
+	}
+	for code := range r.roomInvites[roomID] {
+		delete(r.invites, code)
+	}
+
+	delete(r.rooms, roomID)
+	delete(r.purgeSchedule, roomID)
+	delete(r.members, roomID)
+	delete(r.joined, roomID)
+	delete(r.waitlists, roomID)
+	delete(r.roles, roomID)
+	delete(r.mutes, roomID)
+	delete(r.slowMode, roomID)
+	delete(r.messages, roomID)
+	delete(r.roomInvites, roomID)
+	delete(r.joinRequests, roomID)
+	delete(r.metadata, roomID)
+	delete(r.metadataPolicies, roomID)
+	delete(r.presence, roomID)
+}
+
+func (r *MemoryRepository) RoomExists(ctx context.Context, roomID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.rooms[roomID]
+	return ok, nil
+}
+
+func (r *MemoryRepository) ListRoomIDs(ctx context.Context) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roomIDs := make([]string, 0, len(r.rooms))
+	for id := range r.rooms {
+		roomIDs = append(roomIDs, id)
+	}
+	return roomIDs, nil
+}
+
+func (r *MemoryRepository) SearchRoomsPage(ctx context.Context, query, userID string, after *searchCursor, limit int) ([]RoomMatch, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	rooms := make([]*Room, 0, len(r.rooms))
+	for id, room := range r.rooms {
+		if _, isMember := r.members[id][userID]; room.IsPrivate && room.CreatedBy != userID && !isMember {
+			continue
+		}
+		copied := *room
+		rooms = append(rooms, &copied)
+	}
+	return pageRoomMatches(query, rooms, after, limit), nil
+}
+
+// ListRooms sorts the matching rooms on the same scores as the Redis indexes,
+// ties are broken by the room ID
+func (r *MemoryRepository) ListRooms(ctx context.Context, opts RoomListOptions) ([]*Room, string, error) {
+	if _, ok := roomOrderIndexes[opts.OrderBy]; !ok {
+		return nil, "", fmt.Errorf("unknown room order %d", opts.OrderBy)
+	}
+
+	var after *roomCursor
+	if opts.PageToken != "" {
+		cursor, err := decodeRoomCursor(opts.PageToken)
+		if err != nil || cursor.Order != opts.OrderBy || cursor.Desc != opts.Descending {
+			return nil, "", ErrInvalidPageToken
+		}
+		after = cursor
+	}
+
+	r.mu.Lock()
+	rooms := make([]*Room, 0, len(r.rooms))
+	for _, room := range r.rooms {
+		if !opts.Filter.matches(room) || !r.isVisible(room, opts.Filter.VisibleTo) {
+			continue
+		}
+		copied := *room
+		rooms = append(rooms, &copied)
+	}
+	r.mu.Unlock()
+
+	// before tells whether a comes first in the listing
+	before := func(a, b *Room) bool {
+		scoreA, scoreB := roomScore(a, opts.OrderBy), roomScore(b, opts.OrderBy)
+		if scoreA != scoreB {
+			return (scoreA < scoreB) != opts.Descending
+		}
+		return isAfterRoom(b.ID, a.ID, opts.Descending)
+	}
+	sort.Slice(rooms, func(i, j int) bool {
+		return before(rooms[i], rooms[j])
+	})
+
+	if after != nil {
+		start := sort.Search(len(rooms), func(i int) bool {
+			score := roomScore(rooms[i], opts.OrderBy)
+			if score != after.Score {
+				return (score > after.Score) != opts.Descending
+			}
+			return isAfterRoom(rooms[i].ID, after.ID, opts.Descending)
+		})
+		rooms = rooms[start:]
+	}
+
+	if len(rooms) <= opts.PageSize {
+		return rooms, "", nil
+	}
+	rooms = rooms[:opts.PageSize]
+
+	last := rooms[len(rooms)-1]
+	cursor := &roomCursor{Order: opts.OrderBy, Desc: opts.Descending, Score: roomScore(last, opts.OrderBy), ID: last.ID}
+	return rooms, encodeRoomCursor(cursor), nil
+}
+
+// isVisible tells whether userID, when set, can see the room, r.mu being held
+func (r *MemoryRepository) isVisible(room *Room, userID string) bool {
+	if userID == "" || !room.IsPrivate || room.CreatedBy == userID {
+		return true
+	}
+	_, ok := r.members[room.ID][userID]
+	return ok
+}
+
+func (r *MemoryRepository) ArchiveRoom(ctx context.Context, roomID, userID string, archivedAt, purgeAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.rooms[roomID]
+	if !ok {
+		return ErrRoomNotFound
+	}
+	room.ArchivedAt = archivedAt
+	room.ArchivedBy = userID
+	room.PurgeAt = purgeAt
+	r.purgeSchedule[roomID] = purgeAt
+	return nil
+}
+
+func (r *MemoryRepository) UnarchiveRoom(ctx context.Context, roomID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if room, ok := r.rooms[roomID]; ok {
+		room.ArchivedAt = time.Time{}
+		room.ArchivedBy = ""
+		room.PurgeAt = time.Time{}
+	}
+	delete(r.purgeSchedule, roomID)
+	return nil
+}
+
+// ClaimRoomsToPurge returns the archived rooms due before now and reschedules them to until
+func (r *MemoryRepository) ClaimRoomsToPurge(ctx context.Context, now, until time.Time, limit int) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var due []string
+	for roomID, purgeAt := range r.purgeSchedule {
+		if !purgeAt.After(now) {
+			due = append(due, roomID)
+		}
+	}
+	sort.Slice(due, func(i, j int) bool {
+		return r.purgeSchedule[due[i]].Before(r.purgeSchedule[due[j]])
+	})
+
+	due = due[:min(limit, len(due))]
+	for _, roomID := range due {
+		r.purgeSchedule[roomID] = until
+	}
+	return due, nil
+}
+
+func (r *MemoryRepository) PurgeArchivedRoom(ctx context.Context, roomID string, now time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.rooms[roomID]
+	if !ok || !room.IsArchived() || room.PurgeAt.After(now) {
+		return false, nil
+	}
+	r.deleteRoom(roomID)
+	return true, nil
+}
+
+// AddRoomMember returns ErrRoomFull when the room is at capacity or has a waitlist
+func (r *MemoryRepository) AddRoomMember(ctx context.Context, roomID, userID string) error {
+	_, err := r.addRoomMember(roomID, userID, false)
+	return err
+}
+
+// QueueRoomMember adds the user to the room, or to the end of its waitlist when the room is full.
+// It returns the position of the user in the waitlist, 0 when the user is a member.
+func (r *MemoryRepository) QueueRoomMember(ctx context.Context, roomID, userID string) (int, error) {
+	return r.addRoomMember(roomID, userID, true)
+}
+
+func (r *MemoryRepository) addRoomMember(roomID, userID string, queue bool) (int, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.rooms[roomID]
+	if !ok {
+		return 0, ErrRoomNotFound
+	}
+
+	if _, isMember := r.members[roomID][userID]; !isMember {
+		waitlist := r.waitlists[roomID]
+		if room.MaxMembers > 0 && (room.MemberCount >= room.MaxMembers || len(waitlist) > 0) {
+			if !queue {
+				return 0, ErrRoomFull
+			}
+			position := slices.Index(waitlist, userID)
+			if position < 0 {
+				r.waitlists[roomID] = append(waitlist, userID)
+				position = len(waitlist)
+			}
+			return position + 1, nil
+		}
+		r.addMember(room, userID)
+	}
+	room.LastActivity = time.Now()
+	return 0, nil
+}
+
+// RemoveRoomMember also takes the user out of the waitlist and drops their role
+func (r *MemoryRepository) RemoveRoomMember(ctx context.Context, roomID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.removeFromWaitlist(roomID, userID)
+	delete(r.roles[roomID], userID)
+
+	_, isMember := r.members[roomID][userID]
+	delete(r.members[roomID], userID)
+	delete(r.joined[roomID], userID)
+
+	if room, ok := r.rooms[roomID]; ok {
+		if isMember {
+			room.MemberCount--
+		}
+		room.LastActivity = time.Now()
+	}
+	return nil
+}
+
+// PromoteWaitlist fills the free slots of the room from its waitlist, in queue order
+func (r *MemoryRepository) PromoteWaitlist(ctx context.Context, roomID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.rooms[roomID]
+	if !ok {
+		return nil, nil
+	}
+
+	var promoted []string
+	for len(r.waitlists[roomID]) > 0 && (room.MaxMembers == 0 || room.MemberCount < room.MaxMembers) {
+		userID := r.waitlists[roomID][0]
+		r.waitlists[roomID] = r.waitlists[roomID][1:]
+		if _, isMember := r.members[roomID][userID]; !isMember {
+			r.addMember(room, userID)
+			promoted = append(promoted, userID)
+		}
+	}
+	if len(r.waitlists[roomID]) == 0 {
+		delete(r.waitlists, roomID)
+	}
+	if len(promoted) > 0 {
+		room.LastActivity = time.Now()
+	}
+	return promoted, nil
+}
+
+func (r *MemoryRepository) RemoveFromWaitlist(ctx context.Context, roomID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.removeFromWaitlist(roomID, userID)
+	return nil
+}
+
+func (r *MemoryRepository) GetRoomMembers(ctx context.Context, roomID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	members := make([]string, 0, len(r.members[roomID]))
+	for userID := range r.members[roomID] {
+		members = append(members, userID)
+	}
+	return members, nil
+}
+
+func (r *MemoryRepository) IsRoomMember(ctx context.Context, roomID, userID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.members[roomID][userID]
+	return ok, nil
+}
+
+func (r *MemoryRepository) ListUserRooms(ctx context.Context, userID string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var rooms []string
+	for roomID, members := range r.members {
+		if _, ok := members[userID]; ok {
+			rooms = append(rooms, roomID)
+		}
+	}
+	return rooms, nil
+}
+
+func (r *MemoryRepository) RemoveAllMembers(ctx context.Context, roomID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.members, roomID)
+	delete(r.joined, roomID)
+	delete(r.roles, roomID)
+	if room, ok := r.rooms[roomID]; ok {
+		room.MemberCount = 0
+	}
+	return nil
+}
+
+func (r *MemoryRepository) TouchRoomActivity(ctx context.Context, roomID string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if room, ok := r.rooms[roomID]; ok {
+		room.LastActivity = at
+	}
+	return nil
+}
+
+func (r *MemoryRepository) SetMemberRole(ctx context.Context, roomID, userID string, role MemberRole) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if role == RoleMember {
+		delete(r.roles[roomID], userID)
+		return nil
+	}
+	if r.roles[roomID] == nil {
+		r.roles[roomID] = make(map[string]MemberRole)
+	}
+	r.roles[roomID][userID] = role
+	return nil
+}
+
+func (r *MemoryRepository) GetMemberRole(ctx context.Context, roomID, userID string) (MemberRole, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.roles[roomID][userID], nil
+}
+
+// GetMemberRoles returns the members holding a role above RoleMember
+func (r *MemoryRepository) GetMemberRoles(ctx context.Context, roomID string) (map[string]MemberRole, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	roles := make(map[string]MemberRole, len(r.roles[roomID]))
+	for userID, role := range r.roles[roomID] {
+		roles[userID] = role
+	}
+	return roles, nil
+}
+
+// GetMemberJoinTimes returns when each member joined the room
+func (r *MemoryRepository) GetMemberJoinTimes(ctx context.Context, roomID string) (map[string]time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	joined := make(map[string]time.Time, len(r.joined[roomID]))
+	for userID, at := range r.joined[roomID] {
+		joined[userID] = at
+	}
+	return joined, nil
+}
+
+// ListRoomMembersPage returns up to limit members in join order after the cursor, ties broken
+// by user ID, with their role. Owners are listed with their stored role.
+func (r *MemoryRepository) ListRoomMembersPage(ctx context.Context, roomID string, after *memberCursor, limit int) ([]MemberInfo, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var members []MemberInfo
+	for userID := range r.members[roomID] {
+		member := MemberInfo{UserID: userID, JoinedAt: r.joined[roomID][userID], Role: r.roles[roomID][userID]}
+		if after == nil || after.isBefore(member) {
+			members = append(members, member)
+		}
+	}
+	sort.Slice(members, func(i, j int) bool {
+		return newMemberCursor(members[i]).isBefore(members[j])
+	})
+	return members[:min(limit, len(members))], nil
+}
+
+// TransferOwnership makes the member newOwnerID the owner of the room in place of ownerID,
+// who stays on as an admin when they are still a member
+func (r *MemoryRepository) TransferOwnership(ctx context.Context, roomID, ownerID, newOwnerID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.rooms[roomID]
+	if !ok {
+		return ErrRoomNotFound
+	}
+	if room.CreatedBy != ownerID {
+		return ErrNotRoomOwner
+	}
+	if _, ok := r.members[roomID][newOwnerID]; !ok {
+		return ErrNotRoomMember
+	}
+
+	room.CreatedBy = newOwnerID
+	if r.roles[roomID] == nil {
+		r.roles[roomID] = make(map[string]MemberRole)
+	}
+	delete(r.roles[roomID], newOwnerID)
+	if _, ok := r.members[roomID][ownerID]; ok {
+		r.roles[roomID][ownerID] = RoleAdmin
+	}
+	return nil
+}
+
+// MuteMember mutes the user until the given time, forever when it is zero
+func (r *MemoryRepository) MuteMember(ctx context.Context, roomID, userID string, until time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.mutes[roomID] == nil {
+		r.mutes[roomID] = make(map[string]time.Time)
+	}
+	r.mutes[roomID][userID] = until
+	return nil
+}
+
+func (r *MemoryRepository) UnmuteMember(ctx context.Context, roomID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.mutes[roomID], userID)
+	return nil
+}
+
+func (r *MemoryRepository) IsMuted(ctx context.Context, roomID, userID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	until, ok := r.mutes[roomID][userID]
+	return ok && (until.IsZero() || until.After(time.Now())), nil
+}
+
+// GetRoomMutes returns the live mutes of the room by user, pruning the expired ones
+func (r *MemoryRepository) GetRoomMutes(ctx context.Context, roomID string) (map[string]time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	mutes := make(map[string]time.Time, len(r.mutes[roomID]))
+	for userID, until := range r.mutes[roomID] {
+		if !until.IsZero() && until.Before(now) {
+			delete(r.mutes[roomID], userID)
+			continue
+		}
+		mutes[userID] = until
+	}
+	return mutes, nil
+}
+
+// GetMemberMutes returns the live mutes of the given users by user
+func (r *MemoryRepository) GetMemberMutes(ctx context.Context, roomID string, userIDs []string) (map[string]time.Time, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	mutes := make(map[string]time.Time)
+	for _, userID := range userIDs {
+		until, ok := r.mutes[roomID][userID]
+		if ok && (until.IsZero() || !until.Before(now)) {
+			mutes[userID] = until
+		}
+	}
+	return mutes, nil
+}
+
+// TakePostSlot claims the right to post for the slow mode interval.
+// It returns how long the user still has to wait, 0 when the post is allowed.
+func (r *MemoryRepository) TakePostSlot(ctx context.Context, roomID, userID string, interval time.Duration) (time.Duration, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := time.Now()
+	if until := r.slowMode[roomID][userID]; until.After(now) {
+		return until.Sub(now), nil
+	}
+	if r.slowMode[roomID] == nil {
+		r.slowMode[roomID] = make(map[string]time.Time)
+	}
+	r.slowMode[roomID][userID] = now.Add(interval)
+	return 0, nil
+}
+
+// ReleasePostSlot frees the slot taken for a post that failed
+func (r *MemoryRepository) ReleasePostSlot(ctx context.Context, roomID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.slowMode[roomID], userID)
+	return nil
+}
+
+// AppendMessages adds the messages at the end of the room history and keeps its last limit entries
+func (r *MemoryRepository) AppendMessages(ctx context.Context, roomID string, messages []*ChatMessage, limit int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	history := r.messages[roomID]
+	for _, msg := range messages {
+		copied := *msg
+		history = append(history, &copied)
+	}
+	if limit > 0 && len(history) > limit {
+		history = slices.Clone(history[len(history)-limit:])
+	}
+	r.messages[roomID] = history
+	return nil
+}
+
+// GetMessages returns the history of the room, oldest first
+func (r *MemoryRepository) GetMessages(ctx context.Context, roomID string) ([]*ChatMessage, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	messages := make([]*ChatMessage, len(r.messages[roomID]))
+	for i, msg := range r.messages[roomID] {
+		copied := *msg
+		messages[i] = &copied
+	}
+	return messages, nil
+}
+
+// DropMessagesBefore removes the messages sent before the given time from the start of the room history
+func (r *MemoryRepository) DropMessagesBefore(ctx context.Context, roomID string, before time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	history := r.messages[roomID]
+	expired := 0
+	for expired < len(history) && history[expired].Timestamp.Before(before) {
+		expired++
+	}
+	if expired > 0 {
+		r.messages[roomID] = slices.Clone(history[expired:])
+	}
+	return nil
+}
+
+func (r *MemoryRepository) AddJoinRequest(ctx context.Context, request *JoinRequest) (*JoinRequest, bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if pending, ok := r.joinRequests[request.RoomID][request.UserID]; ok {
+		copied := *pending
+		return &copied, false, nil
+	}
+
+	stored := *request
+	if r.joinRequests[request.RoomID] == nil {
+		r.joinRequests[request.RoomID] = make(map[string]*JoinRequest)
+	}
+	r.joinRequests[request.RoomID][request.UserID] = &stored
+	copied := stored
+	return &copied, true, nil
+}
+
+func (r *MemoryRepository) ListJoinRequests(ctx context.Context, roomID string) ([]*JoinRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	requests := make([]*JoinRequest, 0, len(r.joinRequests[roomID]))
+	for _, request := range r.joinRequests[roomID] {
+		copied := *request
+		requests = append(requests, &copied)
+	}
+	return requests, nil
+}
+
+func (r *MemoryRepository) TakeJoinRequest(ctx context.Context, roomID, userID string) (*JoinRequest, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	request, ok := r.joinRequests[roomID][userID]
+	if !ok {
+		return nil, ErrJoinRequestNotFound
+	}
+	delete(r.joinRequests[roomID], userID)
+	if len(r.joinRequests[roomID]) == 0 {
+		delete(r.joinRequests, roomID)
+	}
+	return request, nil
+}
+
+func (r *MemoryRepository) SetRoomMetadata(ctx context.Context, roomID, namespace, key, value string, maxKeys int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	field := metadataField(namespace, key)
+	if _, ok := r.metadata[roomID][field]; !ok && len(r.metadata[roomID]) >= maxKeys {
+		return ErrMetadataFull
+	}
+	if r.metadata[roomID] == nil {
+		r.metadata[roomID] = make(map[string]string)
+	}
+	r.metadata[roomID][field] = value
+	return nil
+}
+
+func (r *MemoryRepository) GetRoomMetadata(ctx context.Context, roomID, namespace string) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	prefix := namespace + metadataFieldSeparator
+	values := make(map[string]string)
+	for field, value := range r.metadata[roomID] {
+		if key, ok := strings.CutPrefix(field, prefix); ok {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+func (r *MemoryRepository) DeleteRoomMetadata(ctx context.Context, roomID, namespace, key string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	field := metadataField(namespace, key)
+	if _, ok := r.metadata[roomID][field]; !ok {
+		return false, nil
+	}
+	delete(r.metadata[roomID], field)
+	if len(r.metadata[roomID]) == 0 {
+		delete(r.metadata, roomID)
+	}
+	return true, nil
+}
+
+func (r *MemoryRepository) SetMetadataPolicy(ctx context.Context, roomID, namespace string, policy MetadataPolicy) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.metadataPolicies[roomID] == nil {
+		r.metadataPolicies[roomID] = make(map[string]MetadataPolicy)
+	}
+	r.metadataPolicies[roomID][namespace] = policy
+	return nil
+}
+
+func (r *MemoryRepository) GetMetadataPolicy(ctx context.Context, roomID, namespace string) (*MetadataPolicy, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	policy, ok := r.metadataPolicies[roomID][namespace]
+	if !ok {
+		return nil, nil
+	}
+	return &policy, nil
+}
+
+func (r *MemoryRepository) CreateInvite(ctx context.Context, invite *InviteLink) error {
+	stored := *invite
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.invites[invite.Code] = &stored
+	if r.roomInvites[invite.RoomID] == nil {
+		r.roomInvites[invite.RoomID] = make(map[string]struct{})
+	}
+	r.roomInvites[invite.RoomID][invite.Code] = struct{}{}
+	return nil
+}
+
+func (r *MemoryRepository) GetInvite(ctx context.Context, code string) (*InviteLink, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invite, ok := r.liveInvite(code)
+	if !ok {
+		return nil, ErrInviteNotFound
+	}
+	copied := *invite
+	return &copied, nil
+}
+
+// ListInvites returns the live invites of a room and forgets the expired ones
+func (r *MemoryRepository) ListInvites(ctx context.Context, roomID string) ([]*InviteLink, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invites := make([]*InviteLink, 0, len(r.roomInvites[roomID]))
+	for code := range r.roomInvites[roomID] {
+		if invite, ok := r.liveInvite(code); ok {
+			copied := *invite
+			invites = append(invites, &copied)
+		}
+	}
+	return invites, nil
+}
+
+func (r *MemoryRepository) JoinByInvite(ctx context.Context, code, userID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	invite, ok := r.liveInvite(code)
+	if !ok {
+		return false, ErrInviteNotFound
+	}
+	room, ok := r.rooms[invite.RoomID]
+	if !ok {
+		return false, ErrRoomNotFound
+	}
+	if _, isMember := r.members[room.ID][userID]; isMember {
+		return false, nil
+	}
+	if room.IsArchived() {
+		return false, ErrRoomArchived
+	}
+	if invite.MaxUses > 0 && invite.Uses >= invite.MaxUses {
+		return false, ErrInviteUsedUp
+	}
+	if room.MaxMembers > 0 && (room.MemberCount >= room.MaxMembers || len(r.waitlists[room.ID]) > 0) {
+		return false, ErrRoomFull
+	}
+
+	invite.Uses++
+	r.addMember(room, userID)
+	room.LastActivity = time.Now()
+	return true, nil
+}
+
+func (r *MemoryRepository) DeleteInvite(ctx context.Context, roomID, code string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.invites, code)
+	delete(r.roomInvites[roomID], code)
+	return nil
+}
+
+func (r *MemoryRepository) CreateRoomTemplate(ctx context.Context, template *RoomTemplate) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	stored := *template
+	r.templates[template.ID] = &stored
+	return nil
+}
+
+func (r *MemoryRepository) GetRoomTemplate(ctx context.Context, templateID string) (*RoomTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	template, ok := r.templates[templateID]
+	if !ok {
+		return nil, ErrTemplateNotFound
+	}
+	copied := *template
+	return &copied, nil
+}
+
+func (r *MemoryRepository) ListRoomTemplates(ctx context.Context) ([]*RoomTemplate, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	templates := make([]*RoomTemplate, 0, len(r.templates))
+	for _, template := range r.templates {
+		copied := *template
+		templates = append(templates, &copied)
+	}
+	return templates, nil
+}
+
+// CreateRoomWithSetup stores the room with its members, metadata and messages under a single lock
+func (r *MemoryRepository) CreateRoomWithSetup(ctx context.Context, room *Room, setup RoomSetup) error {
+	stored := *room
+	if stored.LastActivity.IsZero() {
+		stored.LastActivity = stored.CreatedAt
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.rooms[room.ID] = &stored
+	for userID, role := range setup.Members {
+		r.addMember(&stored, userID)
+		if role > RoleMember {
+			if r.roles[room.ID] == nil {
+				r.roles[room.ID] = make(map[string]MemberRole)
+			}
+			r.roles[room.ID][userID] = role
+		}
+	}
+	for namespace, values := range setup.Metadata {
+		for key, value := range values {
+			if r.metadata[room.ID] == nil {
+				r.metadata[room.ID] = make(map[string]string)
+			}
+			r.metadata[room.ID][metadataField(namespace, key)] = value
+		}
+	}
+	for _, msg := range setup.Messages {
+		copied := *msg
+		r.messages[room.ID] = append(r.messages[room.ID], &copied)
+	}
+	return nil
+}
+
+func (r *MemoryRepository) CreateSpace(ctx context.Context, space *Space, categories []string) error {
+	stored := *space
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.spaces[space.ID] = &stored
+	r.spaceMembers[space.ID] = map[string]struct{}{space.CreatedBy: {}}
+	r.spaceCategories[space.ID] = slices.Clone(categories)
+	r.spaceRooms[space.ID] = make(map[string][]string)
+	return nil
+}
+
+func (r *MemoryRepository) GetSpace(ctx context.Context, spaceID string) (*Space, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	space, ok := r.spaces[spaceID]
+	if !ok {
+		return nil, ErrSpaceNotFound
+	}
+	copied := *space
+	return &copied, nil
+}
+
+func (r *MemoryRepository) AddSpaceMember(ctx context.Context, spaceID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.spaceMembers[spaceID] == nil {
+		r.spaceMembers[spaceID] = make(map[string]struct{})
+	}
+	r.spaceMembers[spaceID][userID] = struct{}{}
+	return nil
+}
+
+func (r *MemoryRepository) IsSpaceMember(ctx context.Context, spaceID, userID string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	_, ok := r.spaceMembers[spaceID][userID]
+	return ok, nil
+}
+
+// PlaceRoomInSpace adds the room to a category of the space or moves it within the space,
+// the category is created at the end of the space when it does not exist yet
+func (r *MemoryRepository) PlaceRoomInSpace(ctx context.Context, spaceID, roomID, category string, position int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	room, ok := r.rooms[roomID]
+	if !ok {
+		return ErrRoomNotFound
+	}
+	if _, ok := r.spaces[spaceID]; !ok {
+		return ErrSpaceNotFound
+	}
+	if room.SpaceID != "" && room.SpaceID != spaceID {
+		return ErrRoomInOtherSpace
+	}
+
+	layout := r.spaceRooms[spaceID]
+	if room.SpaceID == spaceID {
+		layout[room.Category] = slices.DeleteFunc(layout[room.Category], func(id string) bool {
+			return id == roomID
+		})
+	}
+	if !slices.Contains(r.spaceCategories[spaceID], category) {
+		r.spaceCategories[spaceID] = append(r.spaceCategories[spaceID], category)
+	}
+
+	target := layout[category]
+	if position < 0 || position >= len(target) {
+		position = len(target)
+	}
+	layout[category] = slices.Insert(target, position, roomID)
+
+	room.SpaceID = spaceID
+	room.Category = category
+	return nil
+}
+
+// ListSpaceRooms returns the categories of the space in order with their rooms,
+// categories without rooms are kept
+func (r *MemoryRepository) ListSpaceRooms(ctx context.Context, spaceID string) ([]SpaceCategory, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	names := r.spaceCategories[spaceID]
+	categories := make([]SpaceCategory, len(names))
+	for i, name := range names {
+		roomIDs := r.spaceRooms[spaceID][name]
+		categories[i] = SpaceCategory{Name: name, Rooms: make([]*Room, 0, len(roomIDs))}
+		for _, roomID := range roomIDs {
+			if room, ok := r.rooms[roomID]; ok {
+				copied := *room
+				categories[i].Rooms = append(categories[i].Rooms, &copied)
+			}
+		}
+	}
+	return categories, nil
+}
+
+func (r *MemoryRepository) AddPresence(ctx context.Context, session PresenceSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.presence[session.RoomID] == nil {
+		r.presence[session.RoomID] = make(map[string]PresenceSession)
+	}
+	r.presence[session.RoomID][roomPresenceEntry(session)] = session
+	return nil
+}
+
+// RefreshPresence pushes back the expiry of sessions that are still registered
+func (r *MemoryRepository) RefreshPresence(ctx context.Context, sessions []PresenceSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, session := range sessions {
+		entry := roomPresenceEntry(session)
+		if _, ok := r.presence[session.RoomID][entry]; ok {
+			r.presence[session.RoomID][entry] = session
+		}
+	}
+	return nil
+}
+
+func (r *MemoryRepository) RemovePresence(ctx context.Context, session PresenceSession) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.presence[session.RoomID], roomPresenceEntry(session))
+	return nil
+}
+
+func (r *MemoryRepository) RemoveUserPresence(ctx context.Context, roomID, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for entry, session := range r.presence[roomID] {
+		if session.UserID == userID {
+			delete(r.presence[roomID], entry)
+		}
+	}
+	return nil
+}
+
+// GetRoomPresence returns the live sessions of a room, pruning the expired ones
+func (r *MemoryRepository) GetRoomPresence(ctx context.Context, roomID string) ([]PresenceSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.livePresence(roomID, func(PresenceSession) bool { return true }), nil
+}
+
+// GetMembersPresence returns the live sessions of the given users in the room
+func (r *MemoryRepository) GetMembersPresence(ctx context.Context, roomID string, userIDs []string) ([]PresenceSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wanted := make(map[string]struct{}, len(userIDs))
+	for _, userID := range userIDs {
+		wanted[userID] = struct{}{}
+	}
+	return r.livePresence(roomID, func(session PresenceSession) bool {
+		_, ok := wanted[session.UserID]
+		return ok
+	}), nil
+}
+
+// GetUserPresence returns the live sessions of a user across all rooms
+func (r *MemoryRepository) GetUserPresence(ctx context.Context, userID string) ([]PresenceSession, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var sessions []PresenceSession
+	for roomID := range r.presence {
+		sessions = append(sessions, r.livePresence(roomID, func(session PresenceSession) bool {
+			return session.UserID == userID
+		})...)
+	}
+	return sessions, nil
+}
+
+func (r *MemoryRepository) SubscribeToRoom(ctx context.Context, roomID string) Subscription {
+	return r.broker.subscribe(roomID)
+}
+
+func (r *MemoryRepository) PublishRoomEvent(ctx context.Context, roomID string, event interface{}) error {
+	return r.broker.publish(roomID, event)
+}
+
+func (r *MemoryRepository) SubscribeToDirectory(ctx context.Context) Subscription {
+	return r.broker.subscribe(roomDirectoryChannel)
+}
+
+func (r *MemoryRepository) PublishDirectoryEvent(ctx context.Context, event interface{}) error {
+	return r.broker.publish(roomDirectoryChannel, event)
+}
+
+func (r *MemoryRepository) SubscribeToUser(ctx context.Context, userID string) Subscription {
+	return r.broker.subscribe(fmt.Sprintf(userEventsChannelFormat, userID))
+}
+
+func (r *MemoryRepository) PublishUserEvent(ctx context.Context, userID string, event interface{}) error {
+	return r.broker.publish(fmt.Sprintf(userEventsChannelFormat, userID), event)
+}
+
+func (r *MemoryRepository) addMember(room *Room, userID string) {
+	if r.members[room.ID] == nil {
+		r.members[room.ID] = make(map[string]struct{})
+	}
+	r.members[room.ID][userID] = struct{}{}
+	if r.joined[room.ID] == nil {
+		r.joined[room.ID] = make(map[string]time.Time)
+	}
+	r.joined[room.ID][userID] = time.Now()
+	room.MemberCount++
+}
+
+func (r *MemoryRepository) removeFromWaitlist(roomID, userID string) {
+	r.waitlists[roomID] = slices.DeleteFunc(r.waitlists[roomID], func(id string) bool {
+		return id == userID
+	})
+	if len(r.waitlists[roomID]) == 0 {
+		delete(r.waitlists, roomID)
+	}
+}
+
+// liveInvite returns the invite unless it is missing or expired, expired invites are forgotten
+func (r *MemoryRepository) liveInvite(code string) (*InviteLink, bool) {
+	invite, ok := r.invites[code]
+	if !ok {
+		return nil, false
+	}
+	if !invite.ExpiresAt.IsZero() && !invite.ExpiresAt.After(time.Now()) {
+		delete(r.invites, code)
+		delete(r.roomInvites[invite.RoomID], code)
+		return nil, false
+	}
+	return invite, true
+}
+
+// FindRoomInconsistencies finds nothing, the memory repository updates rooms and memberships under one lock
+func (r *MemoryRepository) FindRoomInconsistencies(ctx context.Context) ([]RoomInconsistency, error) {
+	return nil, nil
+}
+
+func (r *MemoryRepository) RepairRoomInconsistency(ctx context.Context, inconsistency RoomInconsistency) error {
+	return nil
+}
+
+// livePresence returns the live sessions of the room matching keep, pruning the expired ones
+func (r *MemoryRepository) livePresence(roomID string, keep func(PresenceSession) bool) []PresenceSession {
+	now := time.Now()
+	var sessions []PresenceSession
+	for entry, session := range r.presence[roomID] {
+		if session.ExpiresAt.Before(now) {
+			delete(r.presence[roomID], entry)
+			continue
+		}
+		if keep(session) {
+			sessions = append(sessions, session)
+		}
+	}
+	if len(r.presence[roomID]) == 0 {
+		delete(r.presence, roomID)
+	}
+	return sessions
+}
+
+// roomScore is the score of the room in the Redis index of the given order
+func roomScore(room *Room, order RoomOrder) float64 {
+	switch order {
+	case OrderByMemberCount:
+		return float64(room.MemberCount)
+	case OrderByLastActivity:
+		return float64(room.LastActivity.UnixMilli())
+	}
+	return float64(room.CreatedAt.UnixMilli())
+}
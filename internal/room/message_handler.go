@@ -0,0 +1,39 @@
+package room
+
+import (
+	"context"
+	"time"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type MessageHandler struct {
+	pb.UnimplementedMessageGrpcServiceServer
+	service *RoomService
+}
+
+func NewMessageGRPCHandler(service *RoomService) *MessageHandler {
+	return &MessageHandler{service: service}
+}
+
+// SendMessage is the send path slow mode applies to, a user posting again too early gets
+// ResourceExhausted with the delay to wait in a RetryInfo detail
+func (h *MessageHandler) SendMessage(ctx context.Context, req *pb.SendMessageRequest) (*pb.MessageAck, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	msg, err := h.service.SendMessage(ctx, req.RoomId, userID.String(), req.Content)
+	if err != nil {
+		return nil, statusFromError(err, "failed to send message")
+	}
+
+	return &pb.MessageAck{
+		MessageId: msg.ID,
+		Timestamp: msg.Timestamp.Format(time.RFC3339),
+	}, nil
+}
@@ -0,0 +1,88 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// The recent messages of a room are kept as JSON in the room:<id>:messages list,
+// oldest first and trimmed to the configured history size
+const roomMessagesKeyFormat = "room:%s:messages"
+
+// AppendMessages adds the messages at the end of the room history and keeps its last limit entries
+func (r *RedisRepository) AppendMessages(ctx context.Context, roomID string, messages []*ChatMessage, limit int) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	values := make([]interface{}, len(messages))
+	for i, msg := range messages {
+		raw, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal message: %w", err)
+		}
+		values[i] = raw
+	}
+
+	key := fmt.Sprintf(roomMessagesKeyFormat, roomID)
+	pipe := r.client.TxPipeline()
+	pipe.RPush(ctx, key, values...)
+	pipe.LTrim(ctx, key, int64(-limit), -1)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// GetMessages returns the history of the room, oldest first
+func (r *RedisRepository) GetMessages(ctx context.Context, roomID string) ([]*ChatMessage, error) {
+	values, err := r.client.LRange(ctx, fmt.Sprintf(roomMessagesKeyFormat, roomID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	messages := make([]*ChatMessage, 0, len(values))
+	for _, value := range values {
+		var msg ChatMessage
+		if err := json.Unmarshal([]byte(value), &msg); err != nil {
+			return nil, fmt.Errorf("invalid message in room %s: %w", roomID, err)
+		}
+		messages = append(messages, &msg)
+	}
+	return messages, nil
+}
+
+// DropMessagesBefore removes the messages sent before the given time from the start of the room history
+func (r *RedisRepository) DropMessagesBefore(ctx context.Context, roomID string, before time.Time) error {
+	key := fmt.Sprintf(roomMessagesKeyFormat, roomID)
+	return r.client.Watch(ctx, func(tx *redis.Tx) error {
+		values, err := tx.LRange(ctx, key, 0, -1).Result()
+		if err != nil {
+			return err
+		}
+
+		expired := 0
+		for _, value := range values {
+			var msg ChatMessage
+			if err := json.Unmarshal([]byte(value), &msg); err != nil {
+				return fmt.Errorf("invalid message in room %s: %w", roomID, err)
+			}
+			if !msg.Timestamp.Before(before) {
+				break
+			}
+			expired++
+		}
+		if expired == 0 {
+			return nil
+		}
+
+		// messages pushed meanwhile fail the transaction rather than shift what is trimmed
+		_, err = tx.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+			pipe.LTrim(ctx, key, int64(expired), -1)
+			return nil
+		})
+		return err
+	}, key)
+}
@@ -0,0 +1,144 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const maxMessageLength = 4000
+
+var ErrInvalidMessage = errors.New("message content must be between 1 and 4000 characters")
+
+// SlowModeError rejects a message posted before the slow mode interval of the room is over
+type SlowModeError struct {
+	RetryAfter time.Duration
+}
+
+func (e *SlowModeError) Error() string {
+	return fmt.Sprintf("slow mode is on, retry in %s", e.RetryAfter.Round(time.Second))
+}
+
+// SendMessage publishes a chat message to the room, adds it to the room history
+// and records it as room activity
+func (s *RoomService) SendMessage(ctx context.Context, roomID, userID, content string) (*ChatMessage, error) {
+	if content == "" || utf8.RuneCountInString(content) > maxMessageLength {
+		return nil, ErrInvalidMessage
+	}
+
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.IsArchived() {
+		return nil, ErrRoomArchived
+	}
+
+	isMember, err := s.repo.IsRoomMember(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isMember {
+		return nil, ErrNotRoomMember
+	}
+
+	slotTaken, err := s.checkCanPost(ctx, room, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	msg := &ChatMessage{
+		ID:        uuid.New().String(),
+		RoomID:    roomID,
+		UserID:    userID,
+		Content:   content,
+		Timestamp: time.Now(),
+	}
+
+	event, err := NewRoomEvent(EventMessage, roomID, userID, msg)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.AppendMessages(ctx, roomID, []*ChatMessage{msg}, s.messageHistorySize); err != nil {
+		// the message was never posted, it does not hold the user to the slow mode
+		if slotTaken {
+			if err := s.repo.ReleasePostSlot(ctx, roomID, userID); err != nil {
+				log.Printf("Failed to release the post slot of %s in room %s: %v", userID, roomID, err)
+			}
+		}
+		return nil, err
+	}
+	if err := s.repo.PublishRoomEvent(ctx, roomID, event); err != nil {
+		return nil, err
+	}
+
+	// the message is already out, a stale activity timestamp is not worth failing the call
+	if err := s.repo.TouchRoomActivity(ctx, roomID, msg.Timestamp); err != nil {
+		log.Printf("Failed to record activity of room %s: %v", roomID, err)
+	}
+	if room.MessageRetention > 0 {
+		if err := s.repo.DropMessagesBefore(ctx, roomID, msg.Timestamp.Add(-room.MessageRetention)); err != nil {
+			log.Printf("Failed to drop expired messages of room %s: %v", roomID, err)
+		}
+	}
+
+	return msg, nil
+}
+
+// retainedMessages leaves out the messages past the retention of the room, the history only
+// drops them when the next message comes in
+func retainedMessages(room *Room, messages []*ChatMessage) []*ChatMessage {
+	if room.MessageRetention <= 0 {
+		return messages
+	}
+	cutoff := time.Now().Add(-room.MessageRetention)
+	for i, msg := range messages {
+		if !msg.Timestamp.Before(cutoff) {
+			return messages[i:]
+		}
+	}
+	return nil
+}
+
+// checkCanPost enforces the posting rules of the room on every path sending messages:
+// announcement-only rooms take posts from owners and admins, muted members cannot post
+// and members below moderator are held to the slow mode interval. It reports whether the post
+// took a slow mode slot, to be released when the message cannot be stored.
+func (s *RoomService) checkCanPost(ctx context.Context, room *Room, userID string) (bool, error) {
+	role, err := s.memberRole(ctx, room, userID)
+	if err != nil {
+		return false, err
+	}
+
+	if room.AnnouncementOnly && role < RoleAdmin {
+		return false, ErrAnnouncementOnly
+	}
+
+	if role < RoleOwner {
+		muted, err := s.repo.IsMuted(ctx, room.ID, userID)
+		if err != nil {
+			return false, err
+		}
+		if muted {
+			return false, ErrMuted
+		}
+	}
+
+	if room.SlowModeInterval <= 0 || role >= RoleModerator {
+		return false, nil
+	}
+
+	wait, err := s.repo.TakePostSlot(ctx, room.ID, userID, room.SlowModeInterval)
+	if err != nil {
+		return false, err
+	}
+	if wait > 0 {
+		return false, &SlowModeError{RetryAfter: wait}
+	}
+	return true, nil
+}
@@ -0,0 +1,65 @@
+package room
+
+import (
+	"context"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *RoomHandler) SetRoomMetadata(ctx context.Context, req *pb.SetRoomMetadataRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if err := h.service.SetRoomMetadata(ctx, req.RoomId, req.Namespace, req.Key, req.Value, userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to set room metadata")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *RoomHandler) GetRoomMetadata(ctx context.Context, req *pb.GetRoomMetadataRequest) (*pb.RoomMetadata, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	values, err := h.service.GetRoomMetadata(ctx, req.RoomId, req.Namespace, req.Keys, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to get room metadata")
+	}
+
+	return &pb.RoomMetadata{RoomId: req.RoomId, Namespace: req.Namespace, Values: values}, nil
+}
+
+func (h *RoomHandler) DeleteRoomMetadata(ctx context.Context, req *pb.DeleteRoomMetadataRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if err := h.service.DeleteRoomMetadata(ctx, req.RoomId, req.Namespace, req.Key, userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to delete room metadata")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *RoomHandler) SetRoomMetadataPolicy(ctx context.Context, req *pb.SetRoomMetadataPolicyRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	policy := MetadataPolicy{ReadRole: MemberRole(req.ReadRole), WriteRole: MemberRole(req.WriteRole)}
+	if err := h.service.SetMetadataPolicy(ctx, req.RoomId, req.Namespace, policy, userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to set room metadata policy")
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,97 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Room metadata sits next to the room:<id> hash: room:<id>:metadata holds the values under
+// "<namespace>:<key>" fields and room:<id>:metadata_policies the JSON-encoded policy of
+// each namespace that has one
+const (
+	roomMetadataKeyFormat         = "room:%s:metadata"
+	roomMetadataPoliciesKeyFormat = "room:%s:metadata_policies"
+	metadataFieldSeparator        = ":"
+)
+
+var ErrMetadataFull = errors.New("room has reached its maximum number of metadata keys")
+
+// setMetadataScript writes ARGV[2] under the field ARGV[1] of KEYS[1] unless that would
+// take the hash past ARGV[3] fields, it returns 0 when the room is full
+var setMetadataScript = redis.NewScript(`
+if redis.call('HEXISTS', KEYS[1], ARGV[1]) == 0 and redis.call('HLEN', KEYS[1]) >= tonumber(ARGV[3]) then
+	return 0
+end
+redis.call('HSET', KEYS[1], ARGV[1], ARGV[2])
+return 1
+`)
+
+// SetRoomMetadata stores the value, a room holds at most maxKeys keys across its namespaces
+func (r *RedisRepository) SetRoomMetadata(ctx context.Context, roomID, namespace, key, value string, maxKeys int) error {
+	keys := []string{fmt.Sprintf(roomMetadataKeyFormat, roomID)}
+	stored, err := setMetadataScript.Run(ctx, r.client, keys, metadataField(namespace, key), value, maxKeys).Int()
+	if err != nil {
+		return err
+	}
+	if stored == 0 {
+		return ErrMetadataFull
+	}
+	return nil
+}
+
+// GetRoomMetadata returns the values of a namespace keyed by their key
+func (r *RedisRepository) GetRoomMetadata(ctx context.Context, roomID, namespace string) (map[string]string, error) {
+	fields, err := r.client.HGetAll(ctx, fmt.Sprintf(roomMetadataKeyFormat, roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := namespace + metadataFieldSeparator
+	values := make(map[string]string)
+	for field, value := range fields {
+		if key, ok := strings.CutPrefix(field, prefix); ok {
+			values[key] = value
+		}
+	}
+	return values, nil
+}
+
+// DeleteRoomMetadata removes the value and tells whether there was one
+func (r *RedisRepository) DeleteRoomMetadata(ctx context.Context, roomID, namespace, key string) (bool, error) {
+	removed, err := r.client.HDel(ctx, fmt.Sprintf(roomMetadataKeyFormat, roomID), metadataField(namespace, key)).Result()
+	return removed > 0, err
+}
+
+func (r *RedisRepository) SetMetadataPolicy(ctx context.Context, roomID, namespace string, policy MetadataPolicy) error {
+	payload, err := json.Marshal(policy)
+	if err != nil {
+		return fmt.Errorf("failed to marshal metadata policy: %w", err)
+	}
+	return r.client.HSet(ctx, fmt.Sprintf(roomMetadataPoliciesKeyFormat, roomID), namespace, payload).Err()
+}
+
+// GetMetadataPolicy returns the policy of the namespace, nil when it has none
+func (r *RedisRepository) GetMetadataPolicy(ctx context.Context, roomID, namespace string) (*MetadataPolicy, error) {
+	payload, err := r.client.HGet(ctx, fmt.Sprintf(roomMetadataPoliciesKeyFormat, roomID), namespace).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var policy MetadataPolicy
+	if err := json.Unmarshal([]byte(payload), &policy); err != nil {
+		return nil, err
+	}
+	return &policy, nil
+}
+
+func metadataField(namespace, key string) string {
+	return namespace + metadataFieldSeparator + key
+}
@@ -0,0 +1,197 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"log"
+	"regexp"
+)
+
+const (
+	maxMetadataKeysPerRoom = 256
+	maxMetadataKeyLength   = 128
+	maxMetadataValueSize   = 4096
+)
+
+// metadataNamespacePattern keeps namespaces free of the field separator, e.g. "github" or "ci.deploy"
+var metadataNamespacePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,63}$`)
+
+// defaultMetadataPolicy applies to namespaces without a policy: members read, admins write
+var defaultMetadataPolicy = MetadataPolicy{ReadRole: RoleMember, WriteRole: RoleAdmin}
+
+var (
+	ErrInvalidMetadata  = errors.New("metadata namespace must be 1-64 of a-z, 0-9, '.', '_', '-', keys 1-128 bytes and values up to 4096 bytes")
+	ErrMetadataNotFound = errors.New("metadata key not found")
+)
+
+// SetRoomMetadata stores value under key in the namespace of the room and tells the members
+// who can read the namespace
+func (s *RoomService) SetRoomMetadata(ctx context.Context, roomID, namespace, key, value, userID string) error {
+	if err := validateMetadataKey(namespace, key); err != nil {
+		return err
+	}
+	if len(value) > maxMetadataValueSize {
+		return ErrInvalidMetadata
+	}
+
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if room.IsArchived() {
+		return ErrRoomArchived
+	}
+	if err := s.checkMetadataAccess(ctx, room, namespace, userID, true); err != nil {
+		return err
+	}
+
+	if err := s.repo.SetRoomMetadata(ctx, roomID, namespace, key, value, maxMetadataKeysPerRoom); err != nil {
+		return err
+	}
+	s.broadcastMetadataChange(roomID, userID, MetadataChange{Namespace: namespace, Key: key, Value: value})
+	return nil
+}
+
+// GetRoomMetadata returns the values of the namespace, only those of keys when some are given
+func (s *RoomService) GetRoomMetadata(ctx context.Context, roomID, namespace string, keys []string, userID string) (map[string]string, error) {
+	if !metadataNamespacePattern.MatchString(namespace) {
+		return nil, ErrInvalidMetadata
+	}
+
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkMetadataAccess(ctx, room, namespace, userID, false); err != nil {
+		return nil, err
+	}
+
+	values, err := s.repo.GetRoomMetadata(ctx, roomID, namespace)
+	if err != nil || len(keys) == 0 {
+		return values, err
+	}
+
+	selected := make(map[string]string, len(keys))
+	for _, key := range keys {
+		if value, ok := values[key]; ok {
+			selected[key] = value
+		}
+	}
+	return selected, nil
+}
+
+// DeleteRoomMetadata removes key from the namespace of the room
+func (s *RoomService) DeleteRoomMetadata(ctx context.Context, roomID, namespace, key, userID string) error {
+	if err := validateMetadataKey(namespace, key); err != nil {
+		return err
+	}
+
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if room.IsArchived() {
+		return ErrRoomArchived
+	}
+	if err := s.checkMetadataAccess(ctx, room, namespace, userID, true); err != nil {
+		return err
+	}
+
+	deleted, err := s.repo.DeleteRoomMetadata(ctx, roomID, namespace, key)
+	if err != nil {
+		return err
+	}
+	if !deleted {
+		return ErrMetadataNotFound
+	}
+	s.broadcastMetadataChange(roomID, userID, MetadataChange{Namespace: namespace, Key: key, Deleted: true})
+	return nil
+}
+
+// SetMetadataPolicy sets who can read and write a namespace, only the owner of the room can do it
+func (s *RoomService) SetMetadataPolicy(ctx context.Context, roomID, namespace string, policy MetadataPolicy, userID string) error {
+	if !metadataNamespacePattern.MatchString(namespace) {
+		return ErrInvalidMetadata
+	}
+	for _, role := range []MemberRole{policy.ReadRole, policy.WriteRole} {
+		if role < RoleMember || role > RoleOwner {
+			return ErrInvalidRole
+		}
+	}
+
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if room.CreatedBy != userID {
+		return ErrNotRoomOwner
+	}
+
+	return s.repo.SetMetadataPolicy(ctx, roomID, namespace, policy)
+}
+
+// CanReadMetadata tells whether the user may read the namespace of the room,
+// member streams only pass the metadata changes of such namespaces on
+func (s *RoomService) CanReadMetadata(ctx context.Context, roomID, namespace, userID string) (bool, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return false, err
+	}
+
+	err = s.checkMetadataAccess(ctx, room, namespace, userID, false)
+	if errors.Is(err, ErrNotRoomMember) || errors.Is(err, ErrInsufficientRole) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// checkMetadataAccess lets members of the room through whose role reaches the one the
+// namespace policy asks for reading, or writing when write is set
+func (s *RoomService) checkMetadataAccess(ctx context.Context, room *Room, namespace, userID string, write bool) error {
+	if room.CreatedBy != userID {
+		isMember, err := s.repo.IsRoomMember(ctx, room.ID, userID)
+		if err != nil {
+			return err
+		}
+		if !isMember {
+			return ErrNotRoomMember
+		}
+	}
+
+	policy, err := s.repo.GetMetadataPolicy(ctx, room.ID, namespace)
+	if err != nil {
+		return err
+	}
+	if policy == nil {
+		policy = &defaultMetadataPolicy
+	}
+
+	role, err := s.memberRole(ctx, room, userID)
+	if err != nil {
+		return err
+	}
+	required := policy.ReadRole
+	if write {
+		required = policy.WriteRole
+	}
+	if role < required {
+		return ErrInsufficientRole
+	}
+	return nil
+}
+
+func (s *RoomService) broadcastMetadataChange(roomID, userID string, change MetadataChange) {
+	event, err := NewRoomEvent(EventMetadataChanged, roomID, userID, change)
+	if err != nil {
+		log.Printf("Failed to build metadata change event: %v", err)
+		return
+	}
+	s.broadcastRoomEvent(roomID, event)
+}
+
+func validateMetadataKey(namespace, key string) error {
+	if !metadataNamespacePattern.MatchString(namespace) || key == "" || len(key) > maxMetadataKeyLength {
+		return ErrInvalidMetadata
+	}
+	return nil
+}
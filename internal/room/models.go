@@ -1,22 +1,221 @@
 package room
 
 import (
+	"encoding/json"
+	"slices"
+	"strings"
 	"time"
 )
 
 type Room struct {
-	ID        string
-	Name      string
-	CreatedAt time.Time
+	ID          string
+	Name        string
+	Topic       string
+	Description string
+	AvatarURL   string
+	CreatedAt   time.Time
+	// CreatedBy is the owner of the room, its creator until ownership is transferred
 	CreatedBy string
 	IsPrivate bool
+	// MaxMembers caps the member count, 0 means unlimited. Joins past it wait in the waitlist.
+	MaxMembers int
+	// SpaceID and Category are set once the room is placed in a space
+	SpaceID  string
+	Category string
+	// SlowModeInterval is the minimum time between two messages of a member, 0 disables it
+	SlowModeInterval time.Duration
+	// AnnouncementOnly lets only owners and admins post
+	AnnouncementOnly bool
+	// MessageRetention drops the messages older than it from the history, 0 keeps them
+	MessageRetention time.Duration
+	// PinnedMessage stays with the room whatever happens to the history, nil when none is pinned
+	PinnedMessage *ChatMessage
+	// maintained by the repository on join, leave and message
+	MemberCount  int
+	LastActivity time.Time
+	// ArchivedAt is zero for active rooms, an archived room is deleted for good at PurgeAt
+	ArchivedAt time.Time
+	ArchivedBy string
+	PurgeAt    time.Time
+}
+
+func (r *Room) IsArchived() bool {
+	return !r.ArchivedAt.IsZero()
+}
+
+// RoomUpdate holds the fields selected by an UpdateRoom field mask,
+// a nil field is left untouched
+type RoomUpdate struct {
+	Name             *string
+	Topic            *string
+	Description      *string
+	AvatarURL        *string
+	IsPrivate        *bool
+	MaxMembers       *int
+	SlowModeInterval *time.Duration
+	AnnouncementOnly *bool
+}
+
+// RoomTemplate presets the rooms created from it, every one of them comes out set up the same way
+type RoomTemplate struct {
+	ID   string
+	Name string
+	// NamePattern names the rooms, {name} stands for the name given at creation and {date} for the UTC date
+	NamePattern string
+	IsPrivate   bool
+	// DefaultRoles are the users made members of each room, with their role from RoleMember to RoleAdmin
+	DefaultRoles map[string]MemberRole
+	// WelcomeMessage is posted and pinned in each room on behalf of its creator, none when empty
+	WelcomeMessage   string
+	MessageRetention time.Duration
+	// Metadata holds the values set in each room, by namespace then key
+	Metadata  map[string]map[string]string
+	CreatedBy string
+	CreatedAt time.Time
+}
+
+// RoomSetup is what a room created from a template starts with
+type RoomSetup struct {
+	// Members are the users made members of the room with their role, RoleMember for none
+	Members map[string]MemberRole
+	// Metadata holds the values set in the room, by namespace then key
+	Metadata map[string]map[string]string
+	Messages []*ChatMessage
+}
+
+// MemberRole ranks what a member may do in a room, the owner is the one in Room.CreatedBy
+type MemberRole int
+
+const (
+	RoleMember MemberRole = iota
+	RoleModerator
+	RoleAdmin
+	RoleOwner
+)
+
+// MemberInfo is what a room tells about one of its members
+type MemberInfo struct {
+	UserID      string
+	Username    string
+	DisplayName string
+	Role        MemberRole
+	JoinedAt    time.Time
+	Muted       bool
+	// MutedUntil is zero for a mute without expiry
+	MutedUntil time.Time
+	Online     bool
+}
+
+func (m MemberInfo) Status() MemberStatus {
+	if m.Online {
+		return MemberOnline
+	}
+	return MemberAway
+}
+
+// UserProfile is the public part of an account
+type UserProfile struct {
+	UserID      string
+	Username    string
+	DisplayName string
+}
+
+// MemberStatus tells whether a member has a live session in the room
+type MemberStatus int
+
+const (
+	MemberAway MemberStatus = iota
+	MemberOnline
+)
+
+// MemberListOptions pages through the members of a room in join order, the nil filters match everyone
+type MemberListOptions struct {
+	PageSize  int
+	PageToken string
+	Role      *MemberRole
+	Status    *MemberStatus
+}
+
+// RoomOrder is the index ListRooms walks through
+type RoomOrder int
+
+const (
+	OrderByCreatedAt RoomOrder = iota
+	OrderByMemberCount
+	OrderByLastActivity
+)
+
+type RoomFilter struct {
+	NamePrefix string
+	IsPrivate  *bool
+	CreatedBy  string
+	// Archived nil hides archived rooms like false does
+	Archived *bool
+	SpaceID  string
+	// VisibleTo leaves out the private rooms this user neither created nor joined
+	VisibleTo string
+}
+
+func (f RoomFilter) matches(room *Room) bool {
+	if f.NamePrefix != "" && !strings.HasPrefix(strings.ToLower(room.Name), strings.ToLower(f.NamePrefix)) {
+		return false
+	}
+	if f.IsPrivate != nil && room.IsPrivate != *f.IsPrivate {
+		return false
+	}
+	if f.CreatedBy != "" && room.CreatedBy != f.CreatedBy {
+		return false
+	}
+	if f.SpaceID != "" && room.SpaceID != f.SpaceID {
+		return false
+	}
+	if room.IsArchived() != (f.Archived != nil && *f.Archived) {
+		return false
+	}
+	return true
+}
+
+// RoomMatch is a room found by SearchRooms, Relevance goes from 0 to 1
+type RoomMatch struct {
+	Room      *Room
+	Relevance float64
+}
+
+type RoomListOptions struct {
+	PageSize   int
+	PageToken  string
+	Filter     RoomFilter
+	OrderBy    RoomOrder
+	Descending bool
 }
 
 type RoomEvent struct {
 	Type    EventType
 	UserID  string
 	RoomID  string
-	Payload interface{}
+	Payload json.RawMessage `json:",omitempty"`
+}
+
+// NewRoomEvent builds an event whose payload is JSON-encoded so it survives the pub/sub round-trip
+func NewRoomEvent(eventType EventType, roomID, userID string, payload interface{}) (RoomEvent, error) {
+	event := RoomEvent{
+		Type:   eventType,
+		UserID: userID,
+		RoomID: roomID,
+	}
+	if payload != nil {
+		raw, err := json.Marshal(payload)
+		if err != nil {
+			return event, err
+		}
+		event.Payload = raw
+	}
+	return event, nil
+}
+
+// DecodePayload unmarshals the event payload into v
+func (e RoomEvent) DecodePayload(v interface{}) error {
+	return json.Unmarshal(e.Payload, v)
 }
 
 type EventType int
@@ -24,11 +223,99 @@ type EventType int
 const (
 	EventUserJoined EventType = iota
 	EventUserLeft
+	// EventRoomDeleted is the last event of a room, its payload is a RoomDeletion
 	EventRoomDeleted
 	EventMessage
 	EventRoomUpdated
+	// EventWaitlistPromoted tells a waitlisted user a slot freed up and they are now a member
+	EventWaitlistPromoted
+	// EventOwnershipTransferred names the new owner, its payload is an OwnershipTransfer
+	EventOwnershipTransferred
+	// EventJoinRequested carries a new JoinRequest, only owners and admins get it
+	EventJoinRequested
+	// EventJoinRequestResolved carries a JoinRequestDecision for the requester and the reviewers
+	EventJoinRequestResolved
+	// EventMetadataChanged carries a MetadataChange, only members allowed to read the namespace get it
+	EventMetadataChanged
+)
+
+// DirectoryEvent is a change to the room directory, every room publishes on the same channel
+type DirectoryEvent struct {
+	Type   DirectoryEventType
+	RoomID string
+	// IsPrivate limits the event to the members of the room
+	IsPrivate bool `json:",omitempty"`
+	// UserID is who made the change, empty for changes made by the server
+	UserID string `json:",omitempty"`
+	// Room is the state of the room after a creation or an update
+	Room *Room `json:",omitempty"`
+	// Deletion is set for DirectoryRoomDeleted
+	Deletion *RoomDeletion `json:",omitempty"`
+	// MemberDelta is set for DirectoryMemberCountChanged
+	MemberDelta int `json:",omitempty"`
+
+	// Rooms and SnapshotDone make up DirectorySnapshot, which is never published
+	Rooms        []*Room `json:"-"`
+	SnapshotDone bool    `json:"-"`
+}
+
+type DirectoryEventType int
+
+const (
+	DirectoryRoomCreated DirectoryEventType = iota
+	DirectoryRoomUpdated
+	DirectoryRoomDeleted
+	DirectoryMemberCountChanged
+	// DirectoryRoomHidden tells a watcher a room it saw is now out of its sight, e.g. it turned private
+	DirectoryRoomHidden
+	// DirectorySnapshot holds a page of the rooms a watcher sees when it starts
+	DirectorySnapshot
 )
 
+// UserEvent is what a multiplexed subscription delivers to a user. UserRoomEvent is built
+// locally, the other types are published on the channel of the user.
+type UserEvent struct {
+	Type   UserEventType
+	RoomID string
+	// Room is the room the user now follows, for UserRoomAdded
+	Room *Room `json:"-"`
+	// Event is the room event of a UserRoomEvent, and the EventRoomDeleted behind a
+	// UserRoomRemoved when the room was deleted
+	Event *RoomEvent `json:"-"`
+	// Decision is set for UserJoinRequestResolved
+	Decision *JoinRequestDecision `json:",omitempty"`
+}
+
+type UserEventType int
+
+const (
+	// UserRoomAdded tells the user became a member of the room, its events follow
+	UserRoomAdded UserEventType = iota
+	// UserRoomRemoved tells the user left the room or the room was deleted, no event of it follows
+	UserRoomRemoved
+	// UserRoomEvent carries an event of one of the rooms of the user
+	UserRoomEvent
+	// UserJoinRequestResolved answers a join request of the user
+	UserJoinRequestResolved
+)
+
+// RoomEventFilter picks the events of a room a multiplexed subscription delivers,
+// the zero value delivers them all
+type RoomEventFilter struct {
+	// Types keeps only the listed types when not empty
+	Types []EventType
+	// Muted drops every event of the room, the room is still followed
+	Muted bool
+}
+
+// Accepts tells whether the filter lets events of the type through
+func (f RoomEventFilter) Accepts(eventType EventType) bool {
+	if f.Muted {
+		return false
+	}
+	return len(f.Types) == 0 || slices.Contains(f.Types, eventType)
+}
+
 type ChatMessage struct {
 	ID        string
 	RoomID    string
@@ -37,8 +324,120 @@ type ChatMessage struct {
 	Timestamp time.Time
 }
 
+// Space groups rooms into ordered categories, members of a space can join its private rooms
+type Space struct {
+	ID        string
+	Name      string
+	IsPrivate bool
+	CreatedAt time.Time
+	CreatedBy string
+}
+
+// SpaceCategory is a named, ordered group of rooms inside a space
+type SpaceCategory struct {
+	Name  string
+	Rooms []*Room
+}
+
+// InviteLink lets anyone holding Code join the room, MaxUses 0 means unlimited
+// and a zero ExpiresAt means the link never expires
+type InviteLink struct {
+	Code      string
+	RoomID    string
+	CreatedBy string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+	MaxUses   int
+	Uses      int
+}
+
+// JoinRequest is a user asking to be let into a private room, it stays pending until
+// an owner or admin approves or rejects it
+type JoinRequest struct {
+	RoomID      string
+	UserID      string
+	Message     string
+	RequestedAt time.Time
+}
+
+// JoinRequestDecision is the payload of EventJoinRequestResolved, the event UserID being the requester
+type JoinRequestDecision struct {
+	Approved   bool
+	ReviewedBy string
+}
+
+// MetadataPolicy sets the minimum roles to read and to write the metadata of a namespace
+type MetadataPolicy struct {
+	ReadRole  MemberRole
+	WriteRole MemberRole
+}
+
+// MetadataChange is the payload of EventMetadataChanged, the event UserID being who made it
+type MetadataChange struct {
+	Namespace string
+	Key       string
+	Value     string `json:",omitempty"`
+	Deleted   bool   `json:",omitempty"`
+}
+
+// PresenceSession is one live connection of a user to a room, held by a given server node
+type PresenceSession struct {
+	RoomID    string
+	UserID    string
+	SessionID string
+	NodeID    string
+	ExpiresAt time.Time
+}
+
+// RoomPreview is what anyone may see of a public room without joining it
+type RoomPreview struct {
+	Room *Room
+	// Messages are the last messages of the room, oldest first
+	Messages []*ChatMessage
+}
+
 type RoomStats struct {
 	Room          *Room
 	TotalMembers  int
 	ActiveMembers int
+	// Delivery only covers the streams served by this node
+	Delivery DeliveryStats
+}
+
+// RoomInconsistency is a piece of room state left behind by a crash or a partial write
+type RoomInconsistency struct {
+	Kind   InconsistencyKind
+	RoomID string
+	// UserIDs are the members concerned, for InconsistencyMembersWithoutSession
+	UserIDs []string
+	// Repaired is set once the reconciler fixed it, it stays false in a dry run
+	Repaired bool
+}
+
+type InconsistencyKind int
+
+const (
+	// InconsistencyOrphanedMembers is a member set for a room missing from the rooms set
+	InconsistencyOrphanedMembers InconsistencyKind = iota
+	// InconsistencyMissingRoomHash is a room listed in the rooms set whose hash is gone
+	InconsistencyMissingRoomHash
+	// InconsistencyMemberCountDrift is a room whose member_count disagrees with its member set
+	InconsistencyMemberCountDrift
+	// InconsistencyMembersWithoutSession lists members with no live session in the room,
+	// it is only reported since membership is meant to outlive sessions
+	InconsistencyMembersWithoutSession
+	// InconsistencyOrphanedRoomKeys are Redis keys of a room the store no longer has
+	InconsistencyOrphanedRoomKeys
+)
+
+// Repairable tells whether the reconciler fixes this kind of inconsistency itself
+func (k InconsistencyKind) Repairable() bool {
+	return k != InconsistencyMembersWithoutSession
+}
+
+// ReconcileReport is the outcome of one pass of the reconciler
+type ReconcileReport struct {
+	DryRun          bool
+	RoomsChecked    int
+	Inconsistencies []RoomInconsistency
 }
@@ -0,0 +1,43 @@
+package room
+
+import (
+	"context"
+	"time"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func (h *RoomHandler) MuteMember(ctx context.Context, req *pb.MuteMemberRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	var until time.Time
+	if req.ExpiresAt != nil {
+		until = req.ExpiresAt.AsTime()
+	}
+
+	if err := h.service.MuteMember(ctx, req.RoomId, req.UserId, until, userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to mute member")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *RoomHandler) UnmuteMember(ctx context.Context, req *pb.UnmuteMemberRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if err := h.service.UnmuteMember(ctx, req.RoomId, req.UserId, userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to unmute member")
+	}
+
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,89 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Mutes are kept in the room:<id>:mutes sorted set scored by expiry in ms, +inf for
+// mutes without expiry. They outlive membership so leaving does not lift a mute.
+const roomMutesKeyFormat = "room:%s:mutes"
+
+// MuteMember mutes the user until the given time, forever when it is zero
+func (r *RedisRepository) MuteMember(ctx context.Context, roomID, userID string, until time.Time) error {
+	score := math.Inf(1)
+	if !until.IsZero() {
+		score = float64(until.UnixMilli())
+	}
+	return r.client.ZAdd(ctx, fmt.Sprintf(roomMutesKeyFormat, roomID), redis.Z{Score: score, Member: userID}).Err()
+}
+
+func (r *RedisRepository) UnmuteMember(ctx context.Context, roomID, userID string) error {
+	return r.client.ZRem(ctx, fmt.Sprintf(roomMutesKeyFormat, roomID), userID).Err()
+}
+
+func (r *RedisRepository) IsMuted(ctx context.Context, roomID, userID string) (bool, error) {
+	until, err := r.client.ZScore(ctx, fmt.Sprintf(roomMutesKeyFormat, roomID), userID).Result()
+	if errors.Is(err, redis.Nil) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return until > float64(time.Now().UnixMilli()), nil
+}
+
+// GetRoomMutes returns the live mutes of the room by user, pruning the expired ones,
+// a zero time stands for a mute without expiry
+func (r *RedisRepository) GetRoomMutes(ctx context.Context, roomID string) (map[string]time.Time, error) {
+	key := fmt.Sprintf(roomMutesKeyFormat, roomID)
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	pipe := r.client.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, key, "-inf", "("+now)
+	live := pipe.ZRangeWithScores(ctx, key, 0, -1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	mutes := make(map[string]time.Time, len(live.Val()))
+	for _, z := range live.Val() {
+		var until time.Time
+		if !math.IsInf(z.Score, 1) {
+			until = time.UnixMilli(int64(z.Score))
+		}
+		mutes[z.Member.(string)] = until
+	}
+	return mutes, nil
+}
+
+// GetMemberMutes returns the live mutes of the given users by user, a zero time stands
+// for a mute without expiry
+func (r *RedisRepository) GetMemberMutes(ctx context.Context, roomID string, userIDs []string) (map[string]time.Time, error) {
+	mutes := make(map[string]time.Time)
+	if len(userIDs) == 0 {
+		return mutes, nil
+	}
+
+	scores, err := r.client.ZMScore(ctx, fmt.Sprintf(roomMutesKeyFormat, roomID), userIDs...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	now := float64(time.Now().UnixMilli())
+	for i, score := range scores {
+		switch {
+		case math.IsInf(score, 1):
+			mutes[userIDs[i]] = time.Time{}
+		case score >= now:
+			mutes[userIDs[i]] = time.UnixMilli(int64(score))
+		}
+	}
+	return mutes, nil
+}
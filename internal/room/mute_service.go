@@ -0,0 +1,62 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+var (
+	ErrMuted            = errors.New("you are muted in this room")
+	ErrAnnouncementOnly = errors.New("only owners and admins can post in this room")
+	ErrInvalidMute      = errors.New("mute expiry must be in the future")
+)
+
+// MuteMember keeps a member from posting until the given time, forever when it is zero.
+// Moderators and above can mute members ranked below them.
+func (s *RoomService) MuteMember(ctx context.Context, roomID, memberID string, until time.Time, userID string) error {
+	if !until.IsZero() && !until.After(time.Now()) {
+		return ErrInvalidMute
+	}
+
+	if err := s.checkCanModerate(ctx, roomID, memberID, userID); err != nil {
+		return err
+	}
+	return s.repo.MuteMember(ctx, roomID, memberID, until)
+}
+
+func (s *RoomService) UnmuteMember(ctx context.Context, roomID, memberID, userID string) error {
+	if err := s.checkCanModerate(ctx, roomID, memberID, userID); err != nil {
+		return err
+	}
+	return s.repo.UnmuteMember(ctx, roomID, memberID)
+}
+
+// checkCanModerate lets moderators and above act on members ranked below them
+func (s *RoomService) checkCanModerate(ctx context.Context, roomID, memberID, userID string) error {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	isMember, err := s.repo.IsRoomMember(ctx, roomID, memberID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrNotRoomMember
+	}
+
+	callerRole, err := s.memberRole(ctx, room, userID)
+	if err != nil {
+		return err
+	}
+	memberRole, err := s.memberRole(ctx, room, memberID)
+	if err != nil {
+		return err
+	}
+	if callerRole < RoleModerator || memberRole >= callerRole {
+		return ErrInsufficientRole
+	}
+	return nil
+}
@@ -0,0 +1,24 @@
+package room
+
+import (
+	"context"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *RoomHandler) TransferOwnership(ctx context.Context, req *pb.TransferOwnershipRequest) (*pb.Room, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	room, err := h.service.TransferOwnership(ctx, req.RoomId, req.NewOwnerId, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to transfer ownership")
+	}
+
+	return convertToPbRoom(room), nil
+}
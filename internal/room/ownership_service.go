@@ -0,0 +1,84 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+)
+
+var ErrInvalidOwner = errors.New("the new owner must be another member of the room")
+
+// OwnershipTransfer is the payload of EventOwnershipTransferred, the event UserID being the new owner
+type OwnershipTransfer struct {
+	PreviousOwnerID string
+}
+
+// TransferOwnership hands the room over to one of its members, the previous owner stays on as an admin
+func (s *RoomService) TransferOwnership(ctx context.Context, roomID, newOwnerID, userID string) (*Room, error) {
+	if newOwnerID == "" || newOwnerID == userID {
+		return nil, ErrInvalidOwner
+	}
+
+	if err := s.repo.TransferOwnership(ctx, roomID, userID, newOwnerID); err != nil {
+		return nil, err
+	}
+	s.broadcastOwnershipTransfer(roomID, userID, newOwnerID)
+
+	return s.repo.GetRoom(ctx, roomID)
+}
+
+// handOverOwnership passes the room from its owner to the admin who joined first,
+// the room keeps its owner when it has no admin
+func (s *RoomService) handOverOwnership(ctx context.Context, room *Room) error {
+	roles, err := s.repo.GetMemberRoles(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+	joined, err := s.repo.GetMemberJoinTimes(ctx, room.ID)
+	if err != nil {
+		return err
+	}
+
+	var admins []string
+	for userID, role := range roles {
+		if role == RoleAdmin && userID != room.CreatedBy {
+			admins = append(admins, userID)
+		}
+	}
+	if len(admins) == 0 {
+		log.Printf("Room %s has no admin to take over from its owner %s", room.ID, room.CreatedBy)
+		return nil
+	}
+
+	// admins without a recorded join time joined before times were recorded, so they come first
+	sort.Slice(admins, func(i, j int) bool {
+		a, b := joined[admins[i]], joined[admins[j]]
+		if !a.Equal(b) {
+			return a.Before(b)
+		}
+		return admins[i] < admins[j]
+	})
+
+	if err := s.repo.TransferOwnership(ctx, room.ID, room.CreatedBy, admins[0]); err != nil {
+		return err
+	}
+	s.broadcastOwnershipTransfer(room.ID, room.CreatedBy, admins[0])
+	return nil
+}
+
+func (s *RoomService) broadcastOwnershipTransfer(roomID, previousOwnerID, newOwnerID string) {
+	event, err := NewRoomEvent(EventOwnershipTransferred, roomID, newOwnerID, OwnershipTransfer{PreviousOwnerID: previousOwnerID})
+	if err != nil {
+		log.Printf("Failed to build ownership transfer event: %v", err)
+		return
+	}
+	s.broadcastRoomEvent(roomID, event)
+
+	room, err := s.repo.GetRoom(context.Background(), roomID)
+	if err != nil {
+		log.Printf("Failed to load room %s after its ownership transfer: %v", roomID, err)
+		return
+	}
+	s.publishRoomChange(DirectoryRoomUpdated, room, newOwnerID)
+}
@@ -0,0 +1,906 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// roomColumns are read by scanRoom, in order
+const roomColumns = `
+	id::text, name, topic, description, avatar_url, created_at, created_by::text,
+	is_private, max_members, slow_mode_interval_ms, announcement_only, space_id, category,
+	member_count, last_activity, archived_at, COALESCE(archived_by::text, ''), purge_at,
+	message_retention_ms, pinned_message`
+
+// inviteColumns are read by scanInvite, in order
+const inviteColumns = `code, room_id::text, created_by::text, created_at, expires_at, max_uses, uses`
+
+// liveInviteCondition leaves out the expired invites
+const liveInviteCondition = `(expires_at IS NULL OR expires_at > NOW())`
+
+// roomTemplateColumns are read by scanRoomTemplate, in order
+const roomTemplateColumns = `
+	id::text, name, name_pattern, is_private, default_roles, welcome_message,
+	message_retention_ms, metadata, COALESCE(created_by::text, ''), created_at`
+
+var roomOrderColumns = map[RoomOrder]string{
+	OrderByCreatedAt:    "created_at",
+	OrderByMemberCount:  "member_count",
+	OrderByLastActivity: "last_activity",
+}
+
+// PostgresRepository keeps rooms, their members and waitlists in Postgres,
+// it is the RoomStore behind CachedRepository
+type PostgresRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresRepository(db *pgxpool.Pool) *PostgresRepository {
+	return &PostgresRepository{db: db}
+}
+
+func (r *PostgresRepository) CreateRoom(ctx context.Context, room *Room) error {
+	return r.CreateRoomWithMembers(ctx, room, nil)
+}
+
+func (r *PostgresRepository) CreateRoomWithMembers(ctx context.Context, room *Room, members map[string]MemberRole) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `
+			INSERT INTO rooms (id, name, topic, description, avatar_url, created_at, created_by,
+				is_private, max_members, slow_mode_interval_ms, announcement_only, last_activity,
+				message_retention_ms, pinned_message)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $6, $12, $13)
+		`
+
+		_, err := tx.Exec(ctx, query,
+			room.ID,
+			room.Name,
+			room.Topic,
+			room.Description,
+			room.AvatarURL,
+			room.CreatedAt,
+			room.CreatedBy,
+			room.IsPrivate,
+			room.MaxMembers,
+			room.SlowModeInterval.Milliseconds(),
+			room.AnnouncementOnly,
+			room.MessageRetention.Milliseconds(),
+			room.PinnedMessage,
+		)
+		if err != nil || len(members) == 0 {
+			return err
+		}
+
+		userIDs := make([]string, 0, len(members))
+		roles := make([]int16, 0, len(members))
+		for userID, role := range members {
+			userIDs = append(userIDs, userID)
+			roles = append(roles, int16(role))
+		}
+		_, err = tx.Exec(ctx, `
+			INSERT INTO room_members (room_id, user_id, role)
+			SELECT $1, unnest($2::uuid[]), unnest($3::smallint[])
+		`, room.ID, userIDs, roles)
+		if err != nil {
+			return err
+		}
+		return updateMemberCount(ctx, tx, room.ID, len(members))
+	})
+}
+
+func (r *PostgresRepository) GetRoom(ctx context.Context, roomID string) (*Room, error) {
+	// room IDs are UUIDs, anything else cannot name a room
+	if _, err := uuid.Parse(roomID); err != nil {
+		return nil, ErrRoomNotFound
+	}
+
+	query := `SELECT ` + roomColumns + ` FROM rooms WHERE id = $1`
+
+	room, err := scanRoom(r.db.QueryRow(ctx, query, roomID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrRoomNotFound
+	}
+	return room, err
+}
+
+func (r *PostgresRepository) UpdateRoom(ctx context.Context, room *Room) error {
+	query := `
+		UPDATE rooms
+		SET name = $2, topic = $3, description = $4, avatar_url = $5, is_private = $6,
+			max_members = $7, slow_mode_interval_ms = $8, announcement_only = $9
+		WHERE id = $1
+	`
+
+	tag, err := r.db.Exec(ctx, query,
+		room.ID,
+		room.Name,
+		room.Topic,
+		room.Description,
+		room.AvatarURL,
+		room.IsPrivate,
+		room.MaxMembers,
+		room.SlowModeInterval.Milliseconds(),
+		room.AnnouncementOnly,
+	)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRoomNotFound
+	}
+	return nil
+}
+
+// DeleteRoom removes the room, its members and waitlist go with it
+func (r *PostgresRepository) DeleteRoom(ctx context.Context, roomID string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM rooms WHERE id = $1`, roomID)
+	return err
+}
+
+func (r *PostgresRepository) ListRoomIDs(ctx context.Context) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT id::text FROM rooms`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+// ListRooms pages through the rooms with a keyset on the order column and the room ID,
+// the filter is applied by the query so every page is full until the last one
+func (r *PostgresRepository) ListRooms(ctx context.Context, opts RoomListOptions) ([]*Room, string, error) {
+	column, ok := roomOrderColumns[opts.OrderBy]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown room order %d", opts.OrderBy)
+	}
+
+	var conditions []string
+	var args []interface{}
+	arg := func(value interface{}) string {
+		args = append(args, value)
+		return "$" + strconv.Itoa(len(args))
+	}
+
+	f := opts.Filter
+	if f.NamePrefix != "" {
+		conditions = append(conditions, "lower(name) LIKE "+arg(likePrefix(strings.ToLower(f.NamePrefix))))
+	}
+	if f.IsPrivate != nil {
+		conditions = append(conditions, "is_private = "+arg(*f.IsPrivate))
+	}
+	if f.CreatedBy != "" {
+		conditions = append(conditions, "created_by = "+arg(f.CreatedBy))
+	}
+	if f.SpaceID != "" {
+		conditions = append(conditions, "space_id = "+arg(f.SpaceID))
+	}
+	if f.Archived != nil && *f.Archived {
+		conditions = append(conditions, "archived_at IS NOT NULL")
+	} else {
+		conditions = append(conditions, "archived_at IS NULL")
+	}
+	if f.VisibleTo != "" {
+		viewer := arg(f.VisibleTo)
+		conditions = append(conditions, fmt.Sprintf(`(NOT is_private OR created_by = %s OR EXISTS (
+			SELECT 1 FROM room_members WHERE room_id = rooms.id AND user_id = %s))`, viewer, viewer))
+	}
+
+	direction, comparison := "ASC", ">"
+	if opts.Descending {
+		direction, comparison = "DESC", "<"
+	}
+
+	if opts.PageToken != "" {
+		cursor, err := decodeRoomCursor(opts.PageToken)
+		if err != nil || cursor.Order != opts.OrderBy || cursor.Desc != opts.Descending {
+			return nil, "", ErrInvalidPageToken
+		}
+		var after interface{} = int(cursor.Score)
+		if opts.OrderBy != OrderByMemberCount {
+			after = time.UnixMicro(int64(cursor.Score))
+		}
+		conditions = append(conditions, fmt.Sprintf("(%s, id) %s (%s, %s)", column, comparison, arg(after), arg(cursor.ID)))
+	}
+
+	query := fmt.Sprintf(`SELECT %s FROM rooms WHERE %s ORDER BY %s %s, id %s LIMIT %s`,
+		roomColumns, strings.Join(conditions, " AND "), column, direction, direction, arg(opts.PageSize+1))
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, "", err
+	}
+	rooms, err := pgx.CollectRows(rows, func(row pgx.CollectableRow) (*Room, error) {
+		return scanRoom(row)
+	})
+	if err != nil {
+		return nil, "", err
+	}
+
+	if len(rooms) <= opts.PageSize {
+		return rooms, "", nil
+	}
+	rooms = rooms[:opts.PageSize]
+
+	// times are kept with microsecond precision, so that is what the cursor holds
+	last := rooms[len(rooms)-1]
+	cursor := &roomCursor{Order: opts.OrderBy, Desc: opts.Descending, ID: last.ID}
+	switch opts.OrderBy {
+	case OrderByCreatedAt:
+		cursor.Score = float64(last.CreatedAt.UnixMicro())
+	case OrderByMemberCount:
+		cursor.Score = float64(last.MemberCount)
+	case OrderByLastActivity:
+		cursor.Score = float64(last.LastActivity.UnixMicro())
+	}
+	return rooms, encodeRoomCursor(cursor), nil
+}
+
+func (r *PostgresRepository) ArchiveRoom(ctx context.Context, roomID, userID string, archivedAt, purgeAt time.Time) error {
+	query := `UPDATE rooms SET archived_at = $2, archived_by = $3, purge_at = $4, purge_claimed_until = NULL WHERE id = $1`
+
+	tag, err := r.db.Exec(ctx, query, roomID, archivedAt, userID, purgeAt)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRoomNotFound
+	}
+	return nil
+}
+
+func (r *PostgresRepository) UnarchiveRoom(ctx context.Context, roomID string) error {
+	query := `UPDATE rooms SET archived_at = NULL, archived_by = NULL, purge_at = NULL, purge_claimed_until = NULL WHERE id = $1`
+
+	_, err := r.db.Exec(ctx, query, roomID)
+	return err
+}
+
+// ClaimRoomsToPurge returns the archived rooms due before now and holds them until then, rows locked
+// by another node are skipped so each room is purged by a single one
+func (r *PostgresRepository) ClaimRoomsToPurge(ctx context.Context, now, until time.Time, limit int) ([]string, error) {
+	query := `
+		UPDATE rooms SET purge_claimed_until = $2
+		WHERE id IN (
+			SELECT id FROM rooms
+			WHERE purge_at <= $1 AND (purge_claimed_until IS NULL OR purge_claimed_until <= $1)
+			ORDER BY purge_at
+			LIMIT $3
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id::text
+	`
+
+	rows, err := r.db.Query(ctx, query, now, until, limit)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) PurgeArchivedRoom(ctx context.Context, roomID string, now time.Time) (bool, error) {
+	query := `DELETE FROM rooms WHERE id = $1 AND archived_at IS NOT NULL AND purge_at <= $2`
+
+	tag, err := r.db.Exec(ctx, query, roomID, now)
+	if err != nil {
+		return false, err
+	}
+	return tag.RowsAffected() > 0, nil
+}
+
+func (r *PostgresRepository) CreateInvite(ctx context.Context, invite *InviteLink) error {
+	var expiresAt *time.Time
+	if !invite.ExpiresAt.IsZero() {
+		expiresAt = &invite.ExpiresAt
+	}
+
+	query := `
+		INSERT INTO room_invites (code, room_id, created_by, created_at, expires_at, max_uses, uses)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		invite.Code,
+		invite.RoomID,
+		invite.CreatedBy,
+		invite.CreatedAt,
+		expiresAt,
+		invite.MaxUses,
+		invite.Uses,
+	)
+	return err
+}
+
+func (r *PostgresRepository) GetInvite(ctx context.Context, code string) (*InviteLink, error) {
+	query := `SELECT ` + inviteColumns + ` FROM room_invites WHERE code = $1 AND ` + liveInviteCondition
+
+	invite, err := scanInvite(r.db.QueryRow(ctx, query, code))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrInviteNotFound
+	}
+	return invite, err
+}
+
+// ListInvites returns the live invites of a room, expired ones are left for the room deletion to drop
+func (r *PostgresRepository) ListInvites(ctx context.Context, roomID string) ([]*InviteLink, error) {
+	query := `SELECT ` + inviteColumns + ` FROM room_invites WHERE room_id = $1 AND ` + liveInviteCondition
+
+	rows, err := r.db.Query(ctx, query, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*InviteLink, error) {
+		return scanInvite(row)
+	})
+}
+
+func (r *PostgresRepository) DeleteInvite(ctx context.Context, roomID, code string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM room_invites WHERE room_id = $1 AND code = $2`, roomID, code)
+	return err
+}
+
+// JoinByInvite locks the invite row and then the room, so concurrent joins on the last use
+// are decided one after the other
+func (r *PostgresRepository) JoinByInvite(ctx context.Context, code, userID string) (bool, error) {
+	joined := false
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		query := `SELECT ` + inviteColumns + ` FROM room_invites WHERE code = $1 AND ` + liveInviteCondition + ` FOR UPDATE`
+		invite, err := scanInvite(tx.QueryRow(ctx, query, code))
+		if errors.Is(err, pgx.ErrNoRows) {
+			return ErrInviteNotFound
+		}
+		if err != nil {
+			return err
+		}
+
+		room, err := lockRoom(ctx, tx, invite.RoomID)
+		if err != nil {
+			return err
+		}
+
+		var isMember, hasWaitlist bool
+		err = tx.QueryRow(ctx, `
+			SELECT
+				EXISTS (SELECT 1 FROM room_members WHERE room_id = $1 AND user_id = $2),
+				EXISTS (SELECT 1 FROM room_waitlist WHERE room_id = $1)
+		`, room.ID, userID).Scan(&isMember, &hasWaitlist)
+		if err != nil || isMember {
+			return err
+		}
+
+		switch {
+		case room.IsArchived():
+			return ErrRoomArchived
+		case invite.MaxUses > 0 && invite.Uses >= invite.MaxUses:
+			return ErrInviteUsedUp
+		case room.MaxMembers > 0 && (room.MemberCount >= room.MaxMembers || hasWaitlist):
+			return ErrRoomFull
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE room_invites SET uses = uses + 1 WHERE code = $1`, code); err != nil {
+			return err
+		}
+		if _, err := tx.Exec(ctx, `INSERT INTO room_members (room_id, user_id) VALUES ($1, $2)`, room.ID, userID); err != nil {
+			return err
+		}
+		joined = true
+		return updateMemberCount(ctx, tx, room.ID, 1)
+	})
+	return joined, err
+}
+
+// AddRoomMember returns ErrRoomFull when the room is at capacity or has a waitlist
+func (r *PostgresRepository) AddRoomMember(ctx context.Context, roomID, userID string) error {
+	_, err := r.addRoomMember(ctx, roomID, userID, false)
+	return err
+}
+
+// SearchRoomsPage relies on the trigram indexes of name and topic to find the candidates, a misspelt
+// word of either is found when its word similarity with the query reaches minSearchSimilarity.
+// room_text_relevance then ranks them the way searchRelevance does, so the cursor pages them here.
+func (r *PostgresRepository) SearchRoomsPage(ctx context.Context, query, userID string, after *searchCursor, limit int) ([]RoomMatch, error) {
+	keyset := ""
+	args := []any{query, likeSubstring(query), userID, minSearchSimilarity}
+	if after != nil {
+		if _, err := uuid.Parse(after.ID); err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		// relevance and activity are ranked from the highest, ties go by ascending ID
+		keyset = ` AND (relevance < $5 OR (relevance = $5 AND (activity_ms < $6 OR (activity_ms = $6 AND id > $7::uuid))))`
+		args = append(args, after.Relevance, after.LastActivity, after.ID)
+	}
+	args = append(args, limit)
+
+	var matches []RoomMatch
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, fmt.Sprintf(`SET LOCAL pg_trgm.word_similarity_threshold = %g`, minSearchSimilarity)); err != nil {
+			return err
+		}
+
+		rows, err := tx.Query(ctx, fmt.Sprintf(`
+			SELECT %s, relevance FROM (
+				SELECT *,
+					round(GREATEST(room_text_relevance($1, name, $4), room_text_relevance($1, topic, $4) * %g)::numeric, 2)::float8 AS relevance,
+					floor(extract(epoch FROM last_activity) * 1000)::bigint AS activity_ms
+				FROM rooms
+				WHERE archived_at IS NULL
+					AND (lower(name) LIKE $2 OR lower(topic) LIKE $2 OR $1 <%% lower(name) OR $1 <%% lower(topic))
+					AND (NOT is_private OR created_by = $3::uuid
+						OR EXISTS (SELECT 1 FROM room_members WHERE room_id = rooms.id AND user_id = $3::uuid))
+			) candidates
+			WHERE relevance > 0%s
+			ORDER BY relevance DESC, activity_ms DESC, id
+			LIMIT $%d
+		`, roomColumns, topicWeight, keyset, len(args)), args...)
+		if err != nil {
+			return err
+		}
+		matches, err = pgx.CollectRows(rows, func(row pgx.CollectableRow) (RoomMatch, error) {
+			var match RoomMatch
+			var err error
+			match.Room, err = scanRoom(row, &match.Relevance)
+			return match, err
+		})
+		return err
+	})
+	return matches, err
+}
+
+func (r *PostgresRepository) CreateRoomTemplate(ctx context.Context, template *RoomTemplate) error {
+	query := `
+		INSERT INTO room_templates (id, name, name_pattern, is_private, default_roles, welcome_message,
+			message_retention_ms, metadata, created_by, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+	`
+
+	_, err := r.db.Exec(ctx, query,
+		template.ID,
+		template.Name,
+		template.NamePattern,
+		template.IsPrivate,
+		template.DefaultRoles,
+		template.WelcomeMessage,
+		template.MessageRetention.Milliseconds(),
+		template.Metadata,
+		template.CreatedBy,
+		template.CreatedAt,
+	)
+	return err
+}
+
+func (r *PostgresRepository) GetRoomTemplate(ctx context.Context, templateID string) (*RoomTemplate, error) {
+	if _, err := uuid.Parse(templateID); err != nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	query := `SELECT ` + roomTemplateColumns + ` FROM room_templates WHERE id = $1`
+
+	template, err := scanRoomTemplate(r.db.QueryRow(ctx, query, templateID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrTemplateNotFound
+	}
+	return template, err
+}
+
+func (r *PostgresRepository) ListRoomTemplates(ctx context.Context) ([]*RoomTemplate, error) {
+	rows, err := r.db.Query(ctx, `SELECT `+roomTemplateColumns+` FROM room_templates`)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (*RoomTemplate, error) {
+		return scanRoomTemplate(row)
+	})
+}
+
+// QueueRoomMember adds the user to the room, or to the end of its waitlist when the room is full.
+// It returns the position of the user in the waitlist, 0 when the user is a member.
+func (r *PostgresRepository) QueueRoomMember(ctx context.Context, roomID, userID string) (int, error) {
+	return r.addRoomMember(ctx, roomID, userID, true)
+}
+
+// addRoomMember locks the room row so the capacity check and the insert cannot race
+func (r *PostgresRepository) addRoomMember(ctx context.Context, roomID, userID string, queue bool) (int, error) {
+	position := 0
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		room, err := lockRoom(ctx, tx, roomID)
+		if err != nil {
+			return err
+		}
+
+		var isMember, hasWaitlist bool
+		err = tx.QueryRow(ctx, `
+			SELECT
+				EXISTS (SELECT 1 FROM room_members WHERE room_id = $1 AND user_id = $2),
+				EXISTS (SELECT 1 FROM room_waitlist WHERE room_id = $1)
+		`, roomID, userID).Scan(&isMember, &hasWaitlist)
+		if err != nil {
+			return err
+		}
+
+		if !isMember && room.MaxMembers > 0 && (room.MemberCount >= room.MaxMembers || hasWaitlist) {
+			if !queue {
+				return ErrRoomFull
+			}
+			position, err = enqueueMember(ctx, tx, roomID, userID)
+			return err
+		}
+
+		if !isMember {
+			if _, err := tx.Exec(ctx, `INSERT INTO room_members (room_id, user_id) VALUES ($1, $2)`, roomID, userID); err != nil {
+				return err
+			}
+		}
+		return updateMemberCount(ctx, tx, roomID, boolToInt(!isMember))
+	})
+	return position, err
+}
+
+func (r *PostgresRepository) RemoveRoomMember(ctx context.Context, roomID, userID string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM room_waitlist WHERE room_id = $1 AND user_id = $2`, roomID, userID); err != nil {
+			return err
+		}
+
+		tag, err := tx.Exec(ctx, `DELETE FROM room_members WHERE room_id = $1 AND user_id = $2`, roomID, userID)
+		if err != nil {
+			return err
+		}
+		return updateMemberCount(ctx, tx, roomID, -int(tag.RowsAffected()))
+	})
+}
+
+// PromoteWaitlist fills the free slots of the room from its waitlist, in queue order
+func (r *PostgresRepository) PromoteWaitlist(ctx context.Context, roomID string) ([]string, error) {
+	var promoted []string
+	err := pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		room, err := lockRoom(ctx, tx, roomID)
+		if errors.Is(err, ErrRoomNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		// a NULL limit takes the whole waitlist for uncapped rooms
+		var free interface{}
+		if room.MaxMembers > 0 {
+			free = max(room.MaxMembers-room.MemberCount, 0)
+		}
+
+		rows, err := tx.Query(ctx, `
+			SELECT user_id::text FROM room_waitlist
+			WHERE room_id = $1
+			ORDER BY queued_at, user_id
+			LIMIT $2
+			FOR UPDATE
+		`, roomID, free)
+		if err != nil {
+			return err
+		}
+		userIDs, err := pgx.CollectRows(rows, pgx.RowTo[string])
+		if err != nil || len(userIDs) == 0 {
+			return err
+		}
+
+		if _, err := tx.Exec(ctx, `DELETE FROM room_waitlist WHERE room_id = $1 AND user_id::text = ANY($2)`, roomID, userIDs); err != nil {
+			return err
+		}
+
+		for _, userID := range userIDs {
+			tag, err := tx.Exec(ctx, `INSERT INTO room_members (room_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, roomID, userID)
+			if err != nil {
+				return err
+			}
+			if tag.RowsAffected() == 1 {
+				promoted = append(promoted, userID)
+			}
+		}
+		return updateMemberCount(ctx, tx, roomID, len(promoted))
+	})
+	return promoted, err
+}
+
+func (r *PostgresRepository) RemoveFromWaitlist(ctx context.Context, roomID, userID string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM room_waitlist WHERE room_id = $1 AND user_id = $2`, roomID, userID)
+	return err
+}
+
+func (r *PostgresRepository) GetRoomMembers(ctx context.Context, roomID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT user_id::text FROM room_members WHERE room_id = $1`, roomID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) ListUserRooms(ctx context.Context, userID string) ([]string, error) {
+	rows, err := r.db.Query(ctx, `SELECT room_id::text FROM room_members WHERE user_id = $1`, userID)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, pgx.RowTo[string])
+}
+
+func (r *PostgresRepository) RemoveAllMembers(ctx context.Context, roomID string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		if _, err := tx.Exec(ctx, `DELETE FROM room_members WHERE room_id = $1`, roomID); err != nil {
+			return err
+		}
+		_, err := tx.Exec(ctx, `UPDATE rooms SET member_count = 0 WHERE id = $1`, roomID)
+		return err
+	})
+}
+
+func (r *PostgresRepository) TouchRoomActivity(ctx context.Context, roomID string, at time.Time) error {
+	_, err := r.db.Exec(ctx, `UPDATE rooms SET last_activity = GREATEST(last_activity, $2) WHERE id = $1`, roomID, at)
+	return err
+}
+
+// SetMemberRole only applies to current members
+func (r *PostgresRepository) SetMemberRole(ctx context.Context, roomID, userID string, role MemberRole) error {
+	query := `UPDATE room_members SET role = $3 WHERE room_id = $1 AND user_id = $2`
+
+	tag, err := r.db.Exec(ctx, query, roomID, userID, int(role))
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrNotRoomMember
+	}
+	return nil
+}
+
+// GetMemberRoles returns the members holding a role above RoleMember
+func (r *PostgresRepository) GetMemberRoles(ctx context.Context, roomID string) (map[string]MemberRole, error) {
+	rows, err := r.db.Query(ctx, `SELECT user_id::text, role FROM room_members WHERE room_id = $1 AND role > 0`, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	roles := make(map[string]MemberRole)
+	for rows.Next() {
+		var userID string
+		var role int
+		if err := rows.Scan(&userID, &role); err != nil {
+			return nil, err
+		}
+		roles[userID] = MemberRole(role)
+	}
+	return roles, rows.Err()
+}
+
+// TransferOwnership makes the member newOwnerID the owner of the room in place of ownerID,
+// who stays on as an admin when they are still a member
+func (r *PostgresRepository) TransferOwnership(ctx context.Context, roomID, ownerID, newOwnerID string) error {
+	return pgx.BeginFunc(ctx, r.db, func(tx pgx.Tx) error {
+		room, err := lockRoom(ctx, tx, roomID)
+		if err != nil {
+			return err
+		}
+		if room.CreatedBy != ownerID {
+			return ErrNotRoomOwner
+		}
+
+		tag, err := tx.Exec(ctx, `UPDATE room_members SET role = $3 WHERE room_id = $1 AND user_id = $2`,
+			roomID, newOwnerID, int(RoleMember))
+		if err != nil {
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			return ErrNotRoomMember
+		}
+
+		if _, err := tx.Exec(ctx, `UPDATE room_members SET role = $3 WHERE room_id = $1 AND user_id = $2`,
+			roomID, ownerID, int(RoleAdmin)); err != nil {
+			return err
+		}
+		_, err = tx.Exec(ctx, `UPDATE rooms SET created_by = $2 WHERE id = $1`, roomID, newOwnerID)
+		return err
+	})
+}
+
+// GetMemberJoinTimes returns when each member joined the room
+func (r *PostgresRepository) GetMemberJoinTimes(ctx context.Context, roomID string) (map[string]time.Time, error) {
+	rows, err := r.db.Query(ctx, `SELECT user_id::text, joined_at FROM room_members WHERE room_id = $1`, roomID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	joined := make(map[string]time.Time)
+	for rows.Next() {
+		var userID string
+		var joinedAt time.Time
+		if err := rows.Scan(&userID, &joinedAt); err != nil {
+			return nil, err
+		}
+		joined[userID] = joinedAt
+	}
+	return joined, rows.Err()
+}
+
+// ListRoomMembersPage returns up to limit members in join order after the cursor, ties broken
+// by user ID, with their role. Owners are listed with their stored role.
+func (r *PostgresRepository) ListRoomMembersPage(ctx context.Context, roomID string, after *memberCursor, limit int) ([]MemberInfo, error) {
+	query := `SELECT user_id::text, joined_at, role FROM room_members WHERE room_id = $1`
+	args := []any{roomID}
+	if after != nil {
+		if _, err := uuid.Parse(after.ID); err != nil {
+			return nil, ErrInvalidPageToken
+		}
+		query += ` AND (joined_at, user_id) > ($2, $3::uuid)`
+		args = append(args, after.joinedAt(), after.ID)
+	}
+	query += fmt.Sprintf(` ORDER BY joined_at, user_id LIMIT $%d`, len(args)+1)
+	args = append(args, limit)
+
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	return pgx.CollectRows(rows, func(row pgx.CollectableRow) (MemberInfo, error) {
+		var member MemberInfo
+		var role int
+		err := row.Scan(&member.UserID, &member.JoinedAt, &role)
+		member.Role = MemberRole(role)
+		return member, err
+	})
+}
+
+// SetRoomSpace records the space and category of the room, their layout is kept by the cache
+func (r *PostgresRepository) SetRoomSpace(ctx context.Context, roomID, spaceID, category string) error {
+	tag, err := r.db.Exec(ctx, `UPDATE rooms SET space_id = $2, category = $3 WHERE id = $1`, roomID, spaceID, category)
+	if err != nil {
+		return err
+	}
+	if tag.RowsAffected() == 0 {
+		return ErrRoomNotFound
+	}
+	return nil
+}
+
+func lockRoom(ctx context.Context, tx pgx.Tx, roomID string) (*Room, error) {
+	query := `SELECT ` + roomColumns + ` FROM rooms WHERE id = $1 FOR UPDATE`
+
+	room, err := scanRoom(tx.QueryRow(ctx, query, roomID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, ErrRoomNotFound
+	}
+	return room, err
+}
+
+// enqueueMember adds the user to the waitlist once and returns their position in it
+func enqueueMember(ctx context.Context, tx pgx.Tx, roomID, userID string) (int, error) {
+	_, err := tx.Exec(ctx, `INSERT INTO room_waitlist (room_id, user_id) VALUES ($1, $2) ON CONFLICT DO NOTHING`, roomID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	var position int
+	err = tx.QueryRow(ctx, `
+		SELECT COUNT(*) FROM room_waitlist w, room_waitlist me
+		WHERE me.room_id = $1 AND me.user_id = $2 AND w.room_id = $1
+			AND (w.queued_at, w.user_id) <= (me.queued_at, me.user_id)
+	`, roomID, userID).Scan(&position)
+	return position, err
+}
+
+// updateMemberCount moves the member count by delta and records the change as room activity
+func updateMemberCount(ctx context.Context, tx pgx.Tx, roomID string, delta int) error {
+	query := `UPDATE rooms SET member_count = member_count + $2, last_activity = NOW() WHERE id = $1`
+
+	_, err := tx.Exec(ctx, query, roomID, delta)
+	return err
+}
+
+// scanRoom reads the roomColumns, then the extra columns selected after them into extra
+func scanRoom(row pgx.Row, extra ...any) (*Room, error) {
+	var room Room
+	var slowModeMs, retentionMs int64
+	var archivedAt, purgeAt *time.Time
+	err := row.Scan(append([]any{
+		&room.ID,
+		&room.Name,
+		&room.Topic,
+		&room.Description,
+		&room.AvatarURL,
+		&room.CreatedAt,
+		&room.CreatedBy,
+		&room.IsPrivate,
+		&room.MaxMembers,
+		&slowModeMs,
+		&room.AnnouncementOnly,
+		&room.SpaceID,
+		&room.Category,
+		&room.MemberCount,
+		&room.LastActivity,
+		&archivedAt,
+		&room.ArchivedBy,
+		&purgeAt,
+		&retentionMs,
+		&room.PinnedMessage,
+	}, extra...)...)
+	if err != nil {
+		return nil, err
+	}
+
+	room.SlowModeInterval = time.Duration(slowModeMs) * time.Millisecond
+	room.MessageRetention = time.Duration(retentionMs) * time.Millisecond
+	if archivedAt != nil {
+		room.ArchivedAt = *archivedAt
+	}
+	if purgeAt != nil {
+		room.PurgeAt = *purgeAt
+	}
+	return &room, nil
+}
+
+func scanInvite(row pgx.Row) (*InviteLink, error) {
+	var invite InviteLink
+	var expiresAt *time.Time
+	err := row.Scan(
+		&invite.Code,
+		&invite.RoomID,
+		&invite.CreatedBy,
+		&invite.CreatedAt,
+		&expiresAt,
+		&invite.MaxUses,
+		&invite.Uses,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if expiresAt != nil {
+		invite.ExpiresAt = *expiresAt
+	}
+	return &invite, nil
+}
+
+func scanRoomTemplate(row pgx.Row) (*RoomTemplate, error) {
+	var template RoomTemplate
+	var retentionMs int64
+	err := row.Scan(
+		&template.ID,
+		&template.Name,
+		&template.NamePattern,
+		&template.IsPrivate,
+		&template.DefaultRoles,
+		&template.WelcomeMessage,
+		&retentionMs,
+		&template.Metadata,
+		&template.CreatedBy,
+		&template.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	template.MessageRetention = time.Duration(retentionMs) * time.Millisecond
+	return &template, nil
+}
+
+// likePrefix escapes the LIKE wildcards of prefix and matches anything after it
+func likePrefix(prefix string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+	return replacer.Replace(prefix) + "%"
+}
+
+// likeSubstring escapes the LIKE wildcards of substring and matches it anywhere
+func likeSubstring(substring string) string {
+	return "%" + likePrefix(substring)
+}
+
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,213 @@
+package room
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Presence is kept in two sorted sets scored by expiry time: one per room holding
+// "user|session|node" entries and one per user holding "room|session|node" entries.
+// Entries whose score is in the past belong to sessions that stopped heartbeating.
+const (
+	roomPresenceKeyFormat = "room:%s:presence"
+	userPresenceKeyFormat = "user:%s:presence"
+	presenceSeparator     = "|"
+	// presenceKeyGrace keeps a set around a bit longer than its newest entry to absorb clock skew between nodes
+	presenceKeyGrace = time.Minute
+)
+
+// removeUserPresenceScript drops every session of ARGV[2] from the room presence set
+// KEYS[1] and the matching entries from the user presence set KEYS[2]
+var removeUserPresenceScript = redis.NewScript(`
+local prefix = ARGV[2] .. '|'
+local removed = 0
+for _, entry in ipairs(redis.call('ZRANGE', KEYS[1], 0, -1)) do
+	if string.sub(entry, 1, #prefix) == prefix then
+		redis.call('ZREM', KEYS[1], entry)
+		redis.call('ZREM', KEYS[2], ARGV[1] .. '|' .. string.sub(entry, #prefix + 1))
+		removed = removed + 1
+	end
+end
+return removed
+`)
+
+func (r *RedisRepository) AddPresence(ctx context.Context, session PresenceSession) error {
+	pipe := r.client.TxPipeline()
+	addPresence(ctx, pipe, session, false)
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// RefreshPresence pushes back the expiry of sessions that are still registered,
+// sessions removed in the meantime (e.g. by LeaveRoom on another node) are not brought back
+func (r *RedisRepository) RefreshPresence(ctx context.Context, sessions []PresenceSession) error {
+	if len(sessions) == 0 {
+		return nil
+	}
+
+	pipe := r.client.Pipeline()
+	for _, session := range sessions {
+		addPresence(ctx, pipe, session, true)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisRepository) RemovePresence(ctx context.Context, session PresenceSession) error {
+	pipe := r.client.TxPipeline()
+	pipe.ZRem(ctx, fmt.Sprintf(roomPresenceKeyFormat, session.RoomID), roomPresenceEntry(session))
+	pipe.ZRem(ctx, fmt.Sprintf(userPresenceKeyFormat, session.UserID), userPresenceEntry(session))
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisRepository) RemoveUserPresence(ctx context.Context, roomID, userID string) error {
+	keys := []string{
+		fmt.Sprintf(roomPresenceKeyFormat, roomID),
+		fmt.Sprintf(userPresenceKeyFormat, userID),
+	}
+	return removeUserPresenceScript.Run(ctx, r.client, keys, roomID, userID).Err()
+}
+
+// GetRoomPresence returns the live sessions of a room, pruning the expired ones
+func (r *RedisRepository) GetRoomPresence(ctx context.Context, roomID string) ([]PresenceSession, error) {
+	entries, err := r.livePresence(ctx, fmt.Sprintf(roomPresenceKeyFormat, roomID))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]PresenceSession, 0, len(entries))
+	for _, z := range entries {
+		parts := strings.SplitN(z.Member.(string), presenceSeparator, 3)
+		if len(parts) != 3 {
+			continue
+		}
+		sessions = append(sessions, PresenceSession{
+			RoomID:    roomID,
+			UserID:    parts[0],
+			SessionID: parts[1],
+			NodeID:    parts[2],
+			ExpiresAt: time.UnixMilli(int64(z.Score)),
+		})
+	}
+	return sessions, nil
+}
+
+// GetUserPresence returns the live sessions of a user across all rooms
+func (r *RedisRepository) GetUserPresence(ctx context.Context, userID string) ([]PresenceSession, error) {
+	entries, err := r.livePresence(ctx, fmt.Sprintf(userPresenceKeyFormat, userID))
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]PresenceSession, 0, len(entries))
+	for _, z := range entries {
+		parts := strings.SplitN(z.Member.(string), presenceSeparator, 3)
+		if len(parts) != 3 {
+			continue
+		}
+		sessions = append(sessions, PresenceSession{
+			RoomID:    parts[0],
+			UserID:    userID,
+			SessionID: parts[1],
+			NodeID:    parts[2],
+			ExpiresAt: time.UnixMilli(int64(z.Score)),
+		})
+	}
+	return sessions, nil
+}
+
+// GetMembersPresence returns the live sessions of the given users in the room, read from
+// the presence set of each user
+func (r *RedisRepository) GetMembersPresence(ctx context.Context, roomID string, userIDs []string) ([]PresenceSession, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	pipe := r.client.Pipeline()
+	entries := make([]*redis.ZSliceCmd, len(userIDs))
+	for i, userID := range userIDs {
+		entries[i] = pipe.ZRangeByScoreWithScores(ctx, fmt.Sprintf(userPresenceKeyFormat, userID), &redis.ZRangeBy{Min: now, Max: "+inf"})
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	var sessions []PresenceSession
+	for i, userID := range userIDs {
+		for _, z := range entries[i].Val() {
+			parts := strings.SplitN(z.Member.(string), presenceSeparator, 3)
+			if len(parts) != 3 || parts[0] != roomID {
+				continue
+			}
+			sessions = append(sessions, PresenceSession{
+				RoomID:    roomID,
+				UserID:    userID,
+				SessionID: parts[1],
+				NodeID:    parts[2],
+				ExpiresAt: time.UnixMilli(int64(z.Score)),
+			})
+		}
+	}
+	return sessions, nil
+}
+
+func (r *RedisRepository) livePresence(ctx context.Context, key string) ([]redis.Z, error) {
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	pipe := r.client.TxPipeline()
+	pipe.ZRemRangeByScore(ctx, key, "-inf", "("+now)
+	live := pipe.ZRangeWithScores(ctx, key, 0, -1)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+	return live.Val(), nil
+}
+
+func addPresence(ctx context.Context, pipe redis.Pipeliner, session PresenceSession, onlyExisting bool) {
+	roomKey := fmt.Sprintf(roomPresenceKeyFormat, session.RoomID)
+	userKey := fmt.Sprintf(userPresenceKeyFormat, session.UserID)
+	score := float64(session.ExpiresAt.UnixMilli())
+
+	add := pipe.ZAdd
+	if onlyExisting {
+		add = pipe.ZAddXX
+	}
+	add(ctx, roomKey, redis.Z{Score: score, Member: roomPresenceEntry(session)})
+	add(ctx, userKey, redis.Z{Score: score, Member: userPresenceEntry(session)})
+
+	// the sets vanish on their own once every session in them has stopped heartbeating
+	pipe.PExpireAt(ctx, roomKey, session.ExpiresAt.Add(presenceKeyGrace))
+	pipe.PExpireAt(ctx, userKey, session.ExpiresAt.Add(presenceKeyGrace))
+}
+
+func roomPresenceEntry(session PresenceSession) string {
+	return strings.Join([]string{session.UserID, session.SessionID, session.NodeID}, presenceSeparator)
+}
+
+func userPresenceEntry(session PresenceSession) string {
+	return strings.Join([]string{session.RoomID, session.SessionID, session.NodeID}, presenceSeparator)
+}
+
+// deleteRoomPresence queues the removal of every session of the room on pipe, from both sides
+func (r *RedisRepository) deleteRoomPresence(ctx context.Context, pipe redis.Pipeliner, roomID string) error {
+	roomKey := fmt.Sprintf(roomPresenceKeyFormat, roomID)
+	entries, err := r.client.ZRange(ctx, roomKey, 0, -1).Result()
+	if err != nil {
+		return err
+	}
+
+	for _, entry := range entries {
+		parts := strings.SplitN(entry, presenceSeparator, 3)
+		if len(parts) != 3 {
+			continue
+		}
+		session := PresenceSession{RoomID: roomID, UserID: parts[0], SessionID: parts[1], NodeID: parts[2]}
+		pipe.ZRem(ctx, fmt.Sprintf(userPresenceKeyFormat, session.UserID), userPresenceEntry(session))
+	}
+	pipe.Del(ctx, roomKey)
+	return nil
+}
@@ -0,0 +1,149 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// startSession registers a presence session held by this node
+func (s *RoomService) startSession(ctx context.Context, roomID, userID string) (PresenceSession, error) {
+	session := PresenceSession{
+		RoomID:    roomID,
+		UserID:    userID,
+		SessionID: uuid.New().String(),
+		NodeID:    s.nodeID,
+		ExpiresAt: time.Now().Add(s.presenceTTL),
+	}
+
+	if err := s.repo.AddPresence(ctx, session); err != nil {
+		return session, err
+	}
+
+	s.sessionsMu.Lock()
+	s.sessions[session.SessionID] = session
+	s.sessionsMu.Unlock()
+
+	return session, nil
+}
+
+// endSession drops a session of this node, it runs once the stream context is gone
+func (s *RoomService) endSession(session PresenceSession) {
+	s.sessionsMu.Lock()
+	delete(s.sessions, session.SessionID)
+	s.sessionsMu.Unlock()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := s.repo.RemovePresence(ctx, session); err != nil {
+		log.Printf("Failed to remove presence of session %s: %v", session.SessionID, err)
+	}
+}
+
+// RunPresenceHeartbeat refreshes the sessions held by this node until ctx is done,
+// sessions of a crashed node expire after the presence TTL
+func (s *RoomService) RunPresenceHeartbeat(ctx context.Context) {
+	ticker := time.NewTicker(s.presenceTTL / 3)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			expiresAt := time.Now().Add(s.presenceTTL)
+
+			s.sessionsMu.Lock()
+			sessions := make([]PresenceSession, 0, len(s.sessions))
+			for id, session := range s.sessions {
+				session.ExpiresAt = expiresAt
+				s.sessions[id] = session
+				sessions = append(sessions, session)
+			}
+			s.sessionsMu.Unlock()
+
+			if err := s.repo.RefreshPresence(ctx, sessions); err != nil {
+				log.Printf("Failed to refresh presence: %v", err)
+			}
+		}
+	}
+}
+
+// RoomPresence returns the live sessions of a room across all nodes, only members see
+// who is in a private room
+func (s *RoomService) RoomPresence(ctx context.Context, roomID, callerID string) ([]PresenceSession, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.IsPrivate {
+		isMember, err := s.repo.IsRoomMember(ctx, roomID, callerID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, ErrNotRoomMember
+		}
+	}
+
+	return s.repo.GetRoomPresence(ctx, roomID)
+}
+
+// UserPresence returns the live sessions of a user across all rooms and nodes. The sessions in
+// private rooms are left out unless the caller is the user or a member of the room.
+func (s *RoomService) UserPresence(ctx context.Context, userID, callerID string) ([]PresenceSession, error) {
+	sessions, err := s.repo.GetUserPresence(ctx, userID)
+	if err != nil || userID == callerID {
+		return sessions, err
+	}
+
+	visible := make(map[string]bool)
+	shown := make([]PresenceSession, 0, len(sessions))
+	for _, session := range sessions {
+		canSee, ok := visible[session.RoomID]
+		if !ok {
+			canSee, err = s.canSeeRoom(ctx, session.RoomID, callerID)
+			if err != nil {
+				return nil, err
+			}
+			visible[session.RoomID] = canSee
+		}
+		if canSee {
+			shown = append(shown, session)
+		}
+	}
+	return shown, nil
+}
+
+// canSeeRoom tells whether the room is public or the user owns it or is one of its members,
+// a room that no longer exists cannot be seen
+func (s *RoomService) canSeeRoom(ctx context.Context, roomID, userID string) (bool, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if errors.Is(err, ErrRoomNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return s.isVisibleRoom(ctx, room, userID)
+}
+
+// isVisibleRoom tells whether the room is public or the user owns it or is one of its members
+func (s *RoomService) isVisibleRoom(ctx context.Context, room *Room, userID string) (bool, error) {
+	if !room.IsPrivate || room.CreatedBy == userID {
+		return true, nil
+	}
+	return s.repo.IsRoomMember(ctx, room.ID, userID)
+}
+
+// onlineUsers returns the distinct users behind sessions
+func onlineUsers(sessions []PresenceSession) map[string]struct{} {
+	users := make(map[string]struct{}, len(sessions))
+	for _, session := range sessions {
+		users[session.UserID] = struct{}{}
+	}
+	return users
+}
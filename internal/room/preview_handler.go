@@ -0,0 +1,99 @@
+package room
+
+import (
+	"context"
+	"log"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// GuestMethods are the methods serving anonymous callers, the auth interceptors let them through without a token
+var GuestMethods = []string{
+	pb.RoomGrpcService_PreviewRoom_FullMethodName,
+	pb.RoomGrpcService_WatchPublicRoom_FullMethodName,
+}
+
+// callerID is the user behind the call, empty for an anonymous one
+func callerID(ctx context.Context) string {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return ""
+	}
+	return userID.String()
+}
+
+func (h *RoomHandler) PreviewRoom(ctx context.Context, req *pb.PreviewRoomRequest) (*pb.RoomPreview, error) {
+	preview, err := h.service.PreviewRoom(ctx, req.RoomId, callerID(ctx), int(req.MessageLimit))
+	if err != nil {
+		return nil, statusFromError(err, "failed to preview room")
+	}
+
+	messages := make([]*pb.ChatMessage, len(preview.Messages))
+	for i, msg := range preview.Messages {
+		messages[i] = convertToPbMessage(msg)
+	}
+	return &pb.RoomPreview{Room: convertToPbRoom(preview.Room), Messages: messages}, nil
+}
+
+func (h *RoomHandler) WatchPublicRoom(req *pb.WatchPublicRoomRequest, stream pb.RoomGrpcService_WatchPublicRoomServer) error {
+	userID := callerID(stream.Context())
+
+	events, err := h.service.WatchPublicRoom(stream.Context(), req.RoomId, userID)
+	if err != nil {
+		return statusFromError(err, "failed to watch room")
+	}
+
+	for event := range events.Events() {
+		var resp *pb.RoomPreviewEvent
+		switch event.Type {
+		case EventMessage:
+			var msg ChatMessage
+			if err := event.DecodePayload(&msg); err != nil {
+				log.Printf("Failed to decode message: %v", err)
+				continue
+			}
+			resp = &pb.RoomPreviewEvent{
+				Event: &pb.RoomPreviewEvent_Message{Message: convertToPbMessage(&msg)},
+			}
+		case EventRoomUpdated:
+			var room Room
+			if err := event.DecodePayload(&room); err != nil {
+				log.Printf("Failed to decode room update: %v", err)
+				continue
+			}
+			canPreview, err := h.service.CanPreviewRoom(stream.Context(), &room, userID)
+			if err != nil {
+				return statusFromError(err, "failed to watch room")
+			}
+			if !canPreview {
+				return status.Error(codes.PermissionDenied, ErrPrivateRoom.Error())
+			}
+			resp = &pb.RoomPreviewEvent{
+				Event: &pb.RoomPreviewEvent_RoomUpdated{
+					RoomUpdated: &pb.RoomUpdated{Room: convertToPbRoom(&room), UpdatedBy: event.UserID},
+				},
+			}
+		case EventRoomDeleted:
+			var deletion RoomDeletion
+			if err := event.DecodePayload(&deletion); err != nil {
+				log.Printf("Failed to decode room deletion: %v", err)
+			}
+			resp = &pb.RoomPreviewEvent{
+				Event: &pb.RoomPreviewEvent_RoomDeleted{
+					RoomDeleted: &pb.RoomDeleted{Reason: deletion.Reason, DeletedBy: event.UserID},
+				},
+			}
+		default:
+			continue
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+
+	return eventStreamError(events)
+}
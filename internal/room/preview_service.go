@@ -0,0 +1,78 @@
+package room
+
+import "context"
+
+const (
+	defaultPreviewMessages = 20
+	maxPreviewMessages     = 100
+)
+
+// PreviewRoom returns the room with its last limit messages to a caller who does not need
+// to be a member. userID is empty for anonymous callers, only members preview private rooms.
+func (s *RoomService) PreviewRoom(ctx context.Context, roomID, userID string, limit int) (*RoomPreview, error) {
+	switch {
+	case limit <= 0:
+		limit = defaultPreviewMessages
+	case limit > maxPreviewMessages:
+		limit = maxPreviewMessages
+	}
+
+	room, err := s.previewableRoom(ctx, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	messages, err := s.repo.GetMessages(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	messages = retainedMessages(room, messages)
+	if len(messages) > limit {
+		messages = messages[len(messages)-limit:]
+	}
+
+	return &RoomPreview{Room: room, Messages: messages}, nil
+}
+
+// WatchPublicRoom streams the messages of the room read-only along with its updates and
+// deletion, without joining it or showing up in its presence. userID is empty for anonymous callers.
+func (s *RoomService) WatchPublicRoom(ctx context.Context, roomID, userID string) (*EventStream, error) {
+	if _, err := s.previewableRoom(ctx, roomID, userID); err != nil {
+		return nil, err
+	}
+
+	return s.hub.subscribe(ctx, roomID, func(event RoomEvent) bool {
+		switch event.Type {
+		case EventMessage, EventRoomUpdated, EventRoomDeleted:
+			return true
+		}
+		return false
+	}), nil
+}
+
+// CanPreviewRoom tells whether the caller may still watch the room, a room turned private
+// is only left to its members
+func (s *RoomService) CanPreviewRoom(ctx context.Context, room *Room, userID string) (bool, error) {
+	if !room.IsPrivate {
+		return true, nil
+	}
+	if userID == "" {
+		return false, nil
+	}
+	return s.repo.IsRoomMember(ctx, room.ID, userID)
+}
+
+func (s *RoomService) previewableRoom(ctx context.Context, roomID, userID string) (*Room, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	canPreview, err := s.CanPreviewRoom(ctx, room, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !canPreview {
+		return nil, ErrPrivateRoom
+	}
+	return room, nil
+}
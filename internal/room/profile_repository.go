@@ -0,0 +1,53 @@
+package room
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// PostgresProfileRepository reads profiles from the users table of the auth service
+type PostgresProfileRepository struct {
+	db *pgxpool.Pool
+}
+
+func NewPostgresProfileRepository(db *pgxpool.Pool) *PostgresProfileRepository {
+	return &PostgresProfileRepository{db: db}
+}
+
+// GetUserProfiles loads all the profiles in one query, IDs that are not UUIDs cannot match a user
+func (r *PostgresProfileRepository) GetUserProfiles(ctx context.Context, userIDs []string) (map[string]UserProfile, error) {
+	ids := make([]uuid.UUID, 0, len(userIDs))
+	for _, userID := range userIDs {
+		if id, err := uuid.Parse(userID); err == nil {
+			ids = append(ids, id)
+		}
+	}
+
+	profiles := make(map[string]UserProfile, len(ids))
+	if len(ids) == 0 {
+		return profiles, nil
+	}
+
+	query := `
+		SELECT id::text, username, display_name
+		FROM users
+		WHERE id = ANY($1)
+	`
+
+	rows, err := r.db.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var profile UserProfile
+		if err := rows.Scan(&profile.UserID, &profile.Username, &profile.DisplayName); err != nil {
+			return nil, err
+		}
+		profiles[profile.UserID] = profile
+	}
+	return profiles, rows.Err()
+}
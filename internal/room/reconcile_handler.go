@@ -0,0 +1,37 @@
+package room
+
+import (
+	"context"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *RoomHandler) ReconcileRooms(ctx context.Context, req *pb.ReconcileRoomsRequest) (*pb.ReconcileRoomsResponse, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	report, err := h.service.ReconcileRooms(ctx, userID.String(), req.DryRun)
+	if err != nil {
+		return nil, statusFromError(err, "failed to reconcile rooms")
+	}
+
+	resp := &pb.ReconcileRoomsResponse{
+		DryRun:          report.DryRun,
+		RoomsChecked:    uint32(report.RoomsChecked),
+		Inconsistencies: make([]*pb.RoomInconsistency, len(report.Inconsistencies)),
+	}
+	for i, inconsistency := range report.Inconsistencies {
+		resp.Inconsistencies[i] = &pb.RoomInconsistency{
+			Kind:     pb.InconsistencyKind(inconsistency.Kind),
+			RoomId:   inconsistency.RoomID,
+			UserIds:  inconsistency.UserIDs,
+			Repaired: inconsistency.Repaired,
+		}
+	}
+	return resp, nil
+}
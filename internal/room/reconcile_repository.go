@@ -0,0 +1,161 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const reconcileScanCount = 500
+
+// resetMemberCountScript sets member_count of the room hash KEYS[1] and its score in the
+// member count index KEYS[3] to the size of the member set KEYS[2], ARGV[1] is the room ID
+var resetMemberCountScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+local count = redis.call('SCARD', KEYS[2])
+redis.call('HSET', KEYS[1], 'member_count', count)
+redis.call('ZADD', KEYS[3], count, ARGV[1])
+return 1
+`)
+
+// deleteRoomRemainsScript deletes what is left of the room ARGV[1] whose hash KEYS[2] is gone, when
+// it is still gone and, for ARGV[2] = 1, the room is still missing from the rooms set KEYS[1]. The
+// ARGV[3] keys after KEYS[2] are deleted and the room is removed from the sorted sets that follow.
+// It returns 0 when the room came back and was left alone.
+var deleteRoomRemainsScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[2]) == 1 then
+	return 0
+end
+if ARGV[2] == '1' and redis.call('SISMEMBER', KEYS[1], ARGV[1]) == 1 then
+	return 0
+end
+
+local deleted = tonumber(ARGV[3])
+for i = 3, deleted + 2 do
+	redis.call('DEL', KEYS[i])
+end
+for i = deleted + 3, #KEYS do
+	redis.call('ZREM', KEYS[i], ARGV[1])
+end
+redis.call('SREM', KEYS[1], ARGV[1])
+return 1
+`)
+
+// FindRoomInconsistencies scans the member sets and the rooms set for state that a crash or a
+// partial write left behind. Members without a live session are the service's business.
+func (r *RedisRepository) FindRoomInconsistencies(ctx context.Context) ([]RoomInconsistency, error) {
+	var found []RoomInconsistency
+
+	roomIDs, err := r.client.SMembers(ctx, roomsKey).Result()
+	if err != nil {
+		return nil, err
+	}
+	listed := make(map[string]struct{}, len(roomIDs))
+	for _, id := range roomIDs {
+		listed[id] = struct{}{}
+	}
+
+	iter := r.client.Scan(ctx, 0, fmt.Sprintf(roomMembersKeyFormat, "*"), reconcileScanCount).Iterator()
+	for iter.Next(ctx) {
+		roomID := strings.TrimSuffix(strings.TrimPrefix(iter.Val(), "room:"), ":members")
+		if _, ok := listed[roomID]; !ok {
+			found = append(found, RoomInconsistency{Kind: InconsistencyOrphanedMembers, RoomID: roomID})
+		}
+	}
+	if err := iter.Err(); err != nil {
+		return nil, err
+	}
+
+	for start := 0; start < len(roomIDs); start += roomListBatchSize {
+		ids := roomIDs[start:min(start+roomListBatchSize, len(roomIDs))]
+
+		pipe := r.client.Pipeline()
+		counts := make([]*redis.StringCmd, len(ids))
+		members := make([]*redis.IntCmd, len(ids))
+		exists := make([]*redis.IntCmd, len(ids))
+		for i, id := range ids {
+			key := fmt.Sprintf(roomKeyFormat, roomKey, id)
+			exists[i] = pipe.Exists(ctx, key)
+			counts[i] = pipe.HGet(ctx, key, "member_count")
+			members[i] = pipe.SCard(ctx, fmt.Sprintf(roomMembersKeyFormat, id))
+		}
+		if _, err := pipe.Exec(ctx); err != nil && !errors.Is(err, redis.Nil) {
+			return nil, err
+		}
+
+		for i, id := range ids {
+			if exists[i].Val() == 0 {
+				found = append(found, RoomInconsistency{Kind: InconsistencyMissingRoomHash, RoomID: id})
+				continue
+			}
+			count, _ := strconv.ParseInt(counts[i].Val(), 10, 64)
+			if count != members[i].Val() {
+				found = append(found, RoomInconsistency{Kind: InconsistencyMemberCountDrift, RoomID: id})
+			}
+		}
+	}
+
+	return found, nil
+}
+
+// RepairRoomInconsistency deletes what is left of orphaned and hashless rooms
+// and recounts the members of rooms whose counter drifted
+func (r *RedisRepository) RepairRoomInconsistency(ctx context.Context, inconsistency RoomInconsistency) error {
+	switch inconsistency.Kind {
+	case InconsistencyOrphanedMembers, InconsistencyMissingRoomHash:
+		return r.deleteRoomRemains(ctx, inconsistency.RoomID, inconsistency.Kind == InconsistencyOrphanedMembers)
+	case InconsistencyMemberCountDrift:
+		keys := []string{
+			fmt.Sprintf(roomKeyFormat, roomKey, inconsistency.RoomID),
+			fmt.Sprintf(roomMembersKeyFormat, inconsistency.RoomID),
+			roomsByMemberCountKey,
+		}
+		return resetMemberCountScript.Run(ctx, r.client, keys, inconsistency.RoomID).Err()
+	default:
+		return fmt.Errorf("cannot repair inconsistency of kind %d", inconsistency.Kind)
+	}
+}
+
+// deleteRoomRemains deletes the keys left by a room whose hash is gone, checking in the same
+// script that the hash is still missing and, when unlisted is set, that the room is not listed,
+// so a room created since it was found is left alone. The presence entries of its users expire
+// on their own.
+func (r *RedisRepository) deleteRoomRemains(ctx context.Context, roomID string, unlisted bool) error {
+	codes, err := r.client.SMembers(ctx, fmt.Sprintf(roomInvitesKeyFormat, roomID)).Result()
+	if err != nil {
+		return err
+	}
+
+	deleted := []string{
+		fmt.Sprintf(roomMembersKeyFormat, roomID),
+		fmt.Sprintf(roomJoinedKeyFormat, roomID),
+		fmt.Sprintf(roomWaitlistKeyFormat, roomID),
+		fmt.Sprintf(roomRolesKeyFormat, roomID),
+		fmt.Sprintf(roomMutesKeyFormat, roomID),
+		fmt.Sprintf(roomMessagesKeyFormat, roomID),
+		fmt.Sprintf(roomJoinRequestsKeyFormat, roomID),
+		fmt.Sprintf(roomMetadataKeyFormat, roomID),
+		fmt.Sprintf(roomMetadataPoliciesKeyFormat, roomID),
+		fmt.Sprintf(roomPresenceKeyFormat, roomID),
+		fmt.Sprintf(roomInvitesKeyFormat, roomID),
+		fmt.Sprintf(roomSearchGramsKeyFormat, roomID),
+	}
+	for _, code := range codes {
+		deleted = append(deleted, fmt.Sprintf(inviteKeyFormat, code))
+	}
+
+	keys := append([]string{roomsKey, fmt.Sprintf(roomKeyFormat, roomKey, roomID)}, deleted...)
+	keys = append(keys, roomsByCreatedAtKey, roomsByMemberCountKey, roomsByLastActivityKey, archivedRoomsKey)
+
+	only := "0"
+	if unlisted {
+		only = "1"
+	}
+	return deleteRoomRemainsScript.Run(ctx, r.client, keys, roomID, only, len(deleted)).Err()
+}
@@ -0,0 +1,155 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+)
+
+var ErrNotAdmin = errors.New("only server admins can perform this action")
+
+// ReconcileRooms looks for room state left behind by crashed nodes or partial writes and,
+// unless dryRun is set, repairs what can be repaired. A dry run also reports the members
+// without a live session. It is reserved to server admins.
+func (s *RoomService) ReconcileRooms(ctx context.Context, userID string, dryRun bool) (*ReconcileReport, error) {
+	if !s.IsAdmin(userID) {
+		return nil, ErrNotAdmin
+	}
+
+	report, err := s.reconcileRooms(ctx, dryRun)
+	if err != nil || !dryRun {
+		return report, err
+	}
+	if err := s.reportMembersWithoutSession(ctx, report); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// IsAdmin tells whether the user is listed as a server admin
+func (s *RoomService) IsAdmin(userID string) bool {
+	_, ok := s.adminUserIDs[userID]
+	return ok
+}
+
+func (s *RoomService) reconcileRooms(ctx context.Context, dryRun bool) (*ReconcileReport, error) {
+	found, err := s.repo.FindRoomInconsistencies(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range found {
+		if dryRun {
+			continue
+		}
+		if err := s.repo.RepairRoomInconsistency(ctx, found[i]); err != nil {
+			log.Printf("Failed to repair room %s: %v", found[i].RoomID, err)
+			continue
+		}
+		found[i].Repaired = true
+	}
+
+	roomIDs, err := s.repo.ListRoomIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ReconcileReport{DryRun: dryRun, RoomsChecked: len(roomIDs), Inconsistencies: found}, nil
+}
+
+// reportMembersWithoutSession adds the members without a live session of every sound room to the
+// report, which takes two reads per room, so it is left to the dry runs asked for by an admin
+func (s *RoomService) reportMembersWithoutSession(ctx context.Context, report *ReconcileReport) error {
+	broken := make(map[string]struct{})
+	for _, inconsistency := range report.Inconsistencies {
+		if inconsistency.Kind != InconsistencyMemberCountDrift {
+			broken[inconsistency.RoomID] = struct{}{}
+		}
+	}
+
+	roomIDs, err := s.repo.ListRoomIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, roomID := range roomIDs {
+		if _, ok := broken[roomID]; ok {
+			continue
+		}
+		offline, err := s.membersWithoutSession(ctx, roomID)
+		if err != nil {
+			return err
+		}
+		if len(offline) > 0 {
+			report.Inconsistencies = append(report.Inconsistencies, RoomInconsistency{
+				Kind:    InconsistencyMembersWithoutSession,
+				RoomID:  roomID,
+				UserIDs: offline,
+			})
+		}
+	}
+	return nil
+}
+
+// membersWithoutSession returns the members of the room no node holds a live session for
+func (s *RoomService) membersWithoutSession(ctx context.Context, roomID string) ([]string, error) {
+	members, err := s.repo.GetRoomMembers(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := s.repo.GetRoomPresence(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	online := make(map[string]struct{}, len(sessions))
+	for _, session := range sessions {
+		online[session.UserID] = struct{}{}
+	}
+
+	var offline []string
+	for _, userID := range members {
+		if _, ok := online[userID]; !ok {
+			offline = append(offline, userID)
+		}
+	}
+	return offline, nil
+}
+
+// RunReconciler repairs stale room state every reconcile interval until ctx is done,
+// running it on several nodes is harmless since every repair is idempotent
+func (s *RoomService) RunReconciler(ctx context.Context) {
+	ticker := time.NewTicker(s.reconcileInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			report, err := s.reconcileRooms(ctx, false)
+			if err != nil {
+				log.Printf("Failed to reconcile rooms: %v", err)
+				continue
+			}
+
+			found, repaired := len(report.Inconsistencies), 0
+			for _, inconsistency := range report.Inconsistencies {
+				if inconsistency.Repaired {
+					repaired++
+				}
+			}
+			if found > 0 {
+				log.Printf("Reconciled %d rooms: %d inconsistencies found, %d repaired", report.RoomsChecked, found, repaired)
+			}
+		}
+	}
+}
+
+func adminSet(userIDs []string) map[string]struct{} {
+	admins := make(map[string]struct{}, len(userIDs))
+	for _, userID := range userIDs {
+		admins[userID] = struct{}{}
+	}
+	return admins
+}
@@ -18,6 +18,49 @@ const (
 	roomKeyFormat        = "%s:%s"
 )
 
+// roomJoinedKeyFormat scores the members of a room by the time they joined in ms
+const roomJoinedKeyFormat = "room:%s:joined"
+
+// roomDirectoryChannel carries the DirectoryEvents of every room
+const roomDirectoryChannel = "rooms:directory"
+
+// userEventsChannelFormat carries the UserEvents of a single user, whatever the room
+const userEventsChannelFormat = "user:%s:events"
+
+var ErrRoomNotFound = errors.New("room not found")
+
+// updateRoomScript only writes the hash KEYS[1] when the room still exists, so a concurrent
+// DeleteRoom cannot be undone by a late update. It moves the room ARGV[1] in the public rooms KEYS[2]
+// as ARGV[2] says it is private or not and in the name index KEYS[3] to the entry ARGV[3]. The
+// grams kept in KEYS[4] are replaced in the search index, whose key format is ARGV[4], by the
+// ARGV[5] grams that follow, the rest of ARGV being the fields.
+var updateRoomScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return 0
+end
+local previous = redis.call('HGET', KEYS[1], 'name_key')
+if previous then
+	redis.call('ZREM', KEYS[3], previous)
+end
+redis.call('ZADD', KEYS[3], 0, ARGV[3])
+for _, gram in ipairs(redis.call('SMEMBERS', KEYS[4])) do
+	redis.call('SREM', string.format(ARGV[4], gram), ARGV[1])
+end
+redis.call('DEL', KEYS[4])
+local grams = tonumber(ARGV[5])
+for i = 6, grams + 5 do
+	redis.call('SADD', string.format(ARGV[4], ARGV[i]), ARGV[1])
+	redis.call('SADD', KEYS[4], ARGV[i])
+end
+redis.call('HSET', KEYS[1], 'name_key', ARGV[3], unpack(ARGV, grams + 6))
+if ARGV[2] == '1' then
+	redis.call('SREM', KEYS[2], ARGV[1])
+else
+	redis.call('SADD', KEYS[2], ARGV[1])
+end
+return 1
+`)
+
 type RedisRepository struct {
 	client *redis.Client
 }
@@ -28,16 +71,12 @@ func NewRedisRepository(client *redis.Client) *RedisRepository {
 
 func (r *RedisRepository) CreateRoom(ctx context.Context, room *Room) error {
 	roomKey := fmt.Sprintf(roomKeyFormat, roomKey, room.ID)
-	pipe := r.client.Pipeline()
+	pipe := r.client.TxPipeline()
 
-	pipe.HSet(ctx, roomKey,
-		"name", room.Name,
-		"created_at", room.CreatedAt.Format(time.RFC3339),
-		"created_by", room.CreatedBy,
-		"is_private", room.IsPrivate,
-	)
+	pipe.HSet(ctx, roomKey, roomFields(room)...)
 
 	pipe.SAdd(ctx, roomsKey, room.ID)
+	addRoomToIndexes(ctx, pipe, room)
 	_, err := pipe.Exec(ctx)
 	return err
 }
@@ -50,20 +89,125 @@ func (r *RedisRepository) GetRoom(ctx context.Context, roomID string) (*Room, er
 	}
 
 	if len(result) == 0 {
-		ErrRoomNotFound := errors.New("room not found")
 		return nil, ErrRoomNotFound
 	}
 
-	createdAt, _ := time.Parse(time.RFC3339, result["created_at"])
-	isPrivate, _ := strconv.ParseBool(result["is_private"])
+	return parseRoom(roomID, result), nil
+}
+
+// roomFields are the room:<id> hash fields of a room, the inverse of parseRoom
+func roomFields(room *Room) []interface{} {
+	lastActivity := room.LastActivity
+	if lastActivity.IsZero() {
+		lastActivity = room.CreatedAt
+	}
+
+	fields := []interface{}{
+		"name", room.Name,
+		"name_key", roomNameEntry(room.Name, room.ID),
+		"topic", room.Topic,
+		"description", room.Description,
+		"avatar_url", room.AvatarURL,
+		"created_at", room.CreatedAt.Format(time.RFC3339),
+		"created_by", room.CreatedBy,
+		"is_private", room.IsPrivate,
+		"max_members", room.MaxMembers,
+		"slow_mode_interval", room.SlowModeInterval.Milliseconds(),
+		"announcement_only", room.AnnouncementOnly,
+		"message_retention", room.MessageRetention.Milliseconds(),
+		"member_count", room.MemberCount,
+		"last_activity", lastActivity.Format(time.RFC3339),
+	}
+	if room.PinnedMessage != nil {
+		if pinned, err := json.Marshal(room.PinnedMessage); err == nil {
+			fields = append(fields, "pinned_message", pinned)
+		}
+	}
+	if room.SpaceID != "" {
+		fields = append(fields, "space_id", room.SpaceID, "category", room.Category)
+	}
+	if room.IsArchived() {
+		fields = append(fields,
+			"archived_at", room.ArchivedAt.Format(time.RFC3339),
+			"archived_by", room.ArchivedBy,
+			"purge_at", room.PurgeAt.Format(time.RFC3339),
+		)
+	}
+	return fields
+}
+
+// parseRoom builds a Room out of its room:<id> hash
+func parseRoom(roomID string, fields map[string]string) *Room {
+	createdAt, _ := time.Parse(time.RFC3339, fields["created_at"])
+	lastActivity, _ := time.Parse(time.RFC3339, fields["last_activity"])
+	archivedAt, _ := time.Parse(time.RFC3339, fields["archived_at"])
+	purgeAt, _ := time.Parse(time.RFC3339, fields["purge_at"])
+	isPrivate, _ := strconv.ParseBool(fields["is_private"])
+	memberCount, _ := strconv.Atoi(fields["member_count"])
+	maxMembers, _ := strconv.Atoi(fields["max_members"])
+	slowModeMs, _ := strconv.ParseInt(fields["slow_mode_interval"], 10, 64)
+	announcementOnly, _ := strconv.ParseBool(fields["announcement_only"])
+	retentionMs, _ := strconv.ParseInt(fields["message_retention"], 10, 64)
+
+	var pinned *ChatMessage
+	if raw, ok := fields["pinned_message"]; ok {
+		var msg ChatMessage
+		if err := json.Unmarshal([]byte(raw), &msg); err == nil {
+			pinned = &msg
+		}
+	}
 
 	return &Room{
-		ID:        roomID,
-		Name:      result["name"],
-		CreatedAt: createdAt,
-		CreatedBy: result["created_by"],
-		IsPrivate: isPrivate,
-	}, nil
+		ID:           roomID,
+		Name:         fields["name"],
+		Topic:        fields["topic"],
+		Description:  fields["description"],
+		AvatarURL:    fields["avatar_url"],
+		CreatedAt:    createdAt,
+		CreatedBy:    fields["created_by"],
+		IsPrivate:    isPrivate,
+		MaxMembers:   maxMembers,
+		MemberCount:  memberCount,
+		LastActivity: lastActivity,
+		ArchivedAt:   archivedAt,
+		ArchivedBy:   fields["archived_by"],
+		PurgeAt:      purgeAt,
+		SpaceID:      fields["space_id"],
+		Category:     fields["category"],
+
+		SlowModeInterval: time.Duration(slowModeMs) * time.Millisecond,
+		AnnouncementOnly: announcementOnly,
+		MessageRetention: time.Duration(retentionMs) * time.Millisecond,
+		PinnedMessage:    pinned,
+	}
+}
+
+func (r *RedisRepository) UpdateRoom(ctx context.Context, room *Room) error {
+	roomKey := fmt.Sprintf(roomKeyFormat, roomKey, room.ID)
+	keys := []string{roomKey, roomsPublicKey, roomsByNameKey, fmt.Sprintf(roomSearchGramsKeyFormat, room.ID)}
+	grams := searchGrams(room)
+	args := []interface{}{room.ID, room.IsPrivate, roomNameEntry(room.Name, room.ID), searchGramKeyFormat, len(grams)}
+	for _, gram := range grams {
+		args = append(args, gram)
+	}
+	args = append(args,
+		"name", room.Name,
+		"topic", room.Topic,
+		"description", room.Description,
+		"avatar_url", room.AvatarURL,
+		"is_private", room.IsPrivate,
+		"max_members", room.MaxMembers,
+		"slow_mode_interval", room.SlowModeInterval.Milliseconds(),
+		"announcement_only", room.AnnouncementOnly,
+	)
+	updated, err := updateRoomScript.Run(ctx, r.client, keys, args...).Int()
+	if err != nil {
+		return err
+	}
+	if updated == 0 {
+		return ErrRoomNotFound
+	}
+	return nil
 }
 
 func (r *RedisRepository) RoomExists(ctx context.Context, roomID string) (bool, error) {
@@ -72,14 +216,41 @@ func (r *RedisRepository) RoomExists(ctx context.Context, roomID string) (bool,
 	return exists > 0, err
 }
 
+// AddRoomMember returns ErrRoomFull when the room is at capacity or has a waitlist
 func (r *RedisRepository) AddRoomMember(ctx context.Context, roomID, userID string) error {
-	memberKey := fmt.Sprintf(roomMembersKeyFormat, roomID)
-	return r.client.SAdd(ctx, memberKey, userID).Err()
+	_, err := r.addRoomMember(ctx, roomID, userID, false)
+	return err
+}
+
+// QueueRoomMember adds the user to the room, or to the end of its waitlist when the room is full.
+// It returns the position of the user in the waitlist, 0 when the user is a member.
+func (r *RedisRepository) QueueRoomMember(ctx context.Context, roomID, userID string) (int, error) {
+	return r.addRoomMember(ctx, roomID, userID, true)
+}
+
+func (r *RedisRepository) addRoomMember(ctx context.Context, roomID, userID string, queue bool) (int, error) {
+	now := time.Now()
+	result, err := addMemberScript.Run(ctx, r.client, userMembershipKeys(roomID, userID),
+		roomID, userID, now.UnixMilli(), now.Format(time.RFC3339), queue).Int()
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case result == -1:
+		return 0, ErrRoomNotFound
+	case result == -2:
+		return 0, ErrRoomFull
+	case result <= -3:
+		return -result - 2, nil
+	}
+	return 0, nil
 }
 
 func (r *RedisRepository) RemoveRoomMember(ctx context.Context, roomID, userID string) error {
-	memberKey := fmt.Sprintf(roomMembersKeyFormat, roomID)
-	return r.client.SRem(ctx, memberKey, userID).Err()
+	now := time.Now()
+	return removeMemberScript.Run(ctx, r.client, userMembershipKeys(roomID, userID),
+		roomID, userID, now.UnixMilli(), now.Format(time.RFC3339)).Err()
 }
 
 func (r *RedisRepository) GetRoomMembers(ctx context.Context, roomID string) ([]string, error) {
@@ -92,9 +263,15 @@ func (r *RedisRepository) IsRoomMember(ctx context.Context, roomID, userID strin
 	return r.client.SIsMember(ctx, memberKey, userID).Result()
 }
 
-func (r *RedisRepository) SubscribeToRoom(ctx context.Context, roomID string) *redis.PubSub {
+// ListUserRooms reads the rooms of the user, keeping those still listed in case a room was
+// deleted without going through DeleteRoom
+func (r *RedisRepository) ListUserRooms(ctx context.Context, userID string) ([]string, error) {
+	return r.client.SInter(ctx, fmt.Sprintf(userRoomsKeyFormat, userID), roomsKey).Result()
+}
+
+func (r *RedisRepository) SubscribeToRoom(ctx context.Context, roomID string) Subscription {
 	channel := fmt.Sprintf(roomKeyFormat, roomKey, roomID)
-	return r.client.Subscribe(ctx, channel)
+	return redisSubscription{pubsub: r.client.Subscribe(ctx, channel)}
 }
 
 func (r *RedisRepository) PublishRoomEvent(ctx context.Context, roomID string, event interface{}) error {
@@ -106,27 +283,105 @@ func (r *RedisRepository) PublishRoomEvent(ctx context.Context, roomID string, e
 	return r.client.Publish(ctx, channel, payload).Err()
 }
 
+func (r *RedisRepository) SubscribeToDirectory(ctx context.Context) Subscription {
+	return redisSubscription{pubsub: r.client.Subscribe(ctx, roomDirectoryChannel)}
+}
+
+func (r *RedisRepository) PublishDirectoryEvent(ctx context.Context, event interface{}) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return r.client.Publish(ctx, roomDirectoryChannel, payload).Err()
+}
+
+func (r *RedisRepository) SubscribeToUser(ctx context.Context, userID string) Subscription {
+	return redisSubscription{pubsub: r.client.Subscribe(ctx, fmt.Sprintf(userEventsChannelFormat, userID))}
+}
+
+func (r *RedisRepository) PublishUserEvent(ctx context.Context, userID string, event interface{}) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+	return r.client.Publish(ctx, fmt.Sprintf(userEventsChannelFormat, userID), payload).Err()
+}
+
 func (r *RedisRepository) ListRoomIDs(ctx context.Context) ([]string, error) {
 	return r.client.SMembers(ctx, roomsKey).Result()
 }
 
 func (r *RedisRepository) DeleteRoom(ctx context.Context, roomID string) error {
-	pipe := r.client.Pipeline()
+	pipe := r.client.TxPipeline()
+	if err := r.queueRoomDeletion(ctx, pipe, roomID); err != nil {
+		return err
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// queueRoomDeletion queues the removal of every key of the room on pipe
+func (r *RedisRepository) queueRoomDeletion(ctx context.Context, pipe redis.Pipeliner, roomID string) error {
+	// Deletes the invite links pointing to the room
+	if err := r.deleteRoomInvites(ctx, pipe, roomID); err != nil {
+		return err
+	}
+
+	// Takes the room out of its space
+	if err := r.removeRoomFromSpace(ctx, pipe, roomID); err != nil {
+		return err
+	}
+
+	// Takes the room out of the listing indexes, those of its creator and members included
+	if err := r.removeRoomFromIndexes(ctx, pipe, roomID); err != nil {
+		return err
+	}
+
+	// Drops the sessions still registered in the room
+	if err := r.deleteRoomPresence(ctx, pipe, roomID); err != nil {
+		return err
+	}
 
 	// Deletes room's metadata
 	pipe.Del(ctx, fmt.Sprintf(roomKeyFormat, roomKey, roomID))
 
-	// Deletes the list of members
+	// Deletes the list of members and the waitlist
 	pipe.Del(ctx, fmt.Sprintf(roomMembersKeyFormat, roomID))
+	pipe.Del(ctx, fmt.Sprintf(roomJoinedKeyFormat, roomID))
+	pipe.Del(ctx, fmt.Sprintf(roomWaitlistKeyFormat, roomID))
+	pipe.Del(ctx, fmt.Sprintf(roomRolesKeyFormat, roomID))
+	pipe.Del(ctx, fmt.Sprintf(roomMutesKeyFormat, roomID))
+	pipe.Del(ctx, fmt.Sprintf(roomMessagesKeyFormat, roomID))
+	pipe.Del(ctx, fmt.Sprintf(roomJoinRequestsKeyFormat, roomID))
+	pipe.Del(ctx, fmt.Sprintf(roomMetadataKeyFormat, roomID))
+	pipe.Del(ctx, fmt.Sprintf(roomMetadataPoliciesKeyFormat, roomID))
 
 	// removes from the global list
 	pipe.SRem(ctx, "rooms", roomID)
-
-	_, err := pipe.Exec(ctx)
-	return err
+	pipe.ZRem(ctx, archivedRoomsKey, roomID)
+	return nil
 }
 
 func (r *RedisRepository) RemoveAllMembers(ctx context.Context, roomID string) error {
-	key := fmt.Sprintf(roomMembersKeyFormat, roomID)
-	return r.client.Del(ctx, key).Err()
+	return removeAllMembersScript.Run(ctx, r.client, membershipKeys(roomID), roomID, userRoomsKeyFormat).Err()
+}
+
+// redisSubscription adapts a Redis pub/sub channel to Subscription
+type redisSubscription struct {
+	pubsub *redis.PubSub
+}
+
+func (s redisSubscription) Receive(ctx context.Context) ([]byte, error) {
+	msg, err := s.pubsub.ReceiveMessage(ctx)
+	if errors.Is(err, redis.ErrClosed) {
+		return nil, ErrSubscriptionClosed
+	}
+	if err != nil {
+		return nil, err
+	}
+	return []byte(msg.Payload), nil
+}
+
+func (s redisSubscription) Close() error {
+	return s.pubsub.Close()
 }
@@ -0,0 +1,229 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Roles above RoleMember live in the room:<id>:roles hash keyed by user ID,
+// members without an entry are plain members. Slow mode keeps one expiring
+// key per user and room while the user has to wait before posting again.
+const (
+	roomRolesKeyFormat = "room:%s:roles"
+	slowModeKeyFormat  = "room:%s:slowmode:%s"
+)
+
+// takePostSlotScript lets a post through and starts the wait of ARGV[1] ms when the key
+// KEYS[1] is free, it returns the time left to wait in ms otherwise
+var takePostSlotScript = redis.NewScript(`
+local ttl = redis.call('PTTL', KEYS[1])
+if ttl > 0 then
+	return ttl
+end
+redis.call('SET', KEYS[1], '1', 'PX', ARGV[1])
+return 0
+`)
+
+// transferOwnershipScript hands the room KEYS[1] from ARGV[1] to the member ARGV[2] of
+// KEYS[2], the new owner loses their role entry in KEYS[3] and the previous one, if still
+// a member, gets the role ARGV[3]. The room ARGV[4] moves from the rooms created by ARGV[1],
+// KEYS[4], to those of ARGV[2], KEYS[5]. It returns -1 for an unknown room, -2 when ARGV[1] is
+// not the owner and -3 when ARGV[2] is not a member.
+var transferOwnershipScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+if redis.call('HGET', KEYS[1], 'created_by') ~= ARGV[1] then
+	return -2
+end
+if redis.call('SISMEMBER', KEYS[2], ARGV[2]) == 0 then
+	return -3
+end
+redis.call('HSET', KEYS[1], 'created_by', ARGV[2])
+redis.call('SREM', KEYS[4], ARGV[4])
+redis.call('SADD', KEYS[5], ARGV[4])
+redis.call('HDEL', KEYS[3], ARGV[2])
+if redis.call('SISMEMBER', KEYS[2], ARGV[1]) == 1 then
+	redis.call('HSET', KEYS[3], ARGV[1], ARGV[3])
+end
+return 1
+`)
+
+func (r *RedisRepository) SetMemberRole(ctx context.Context, roomID, userID string, role MemberRole) error {
+	key := fmt.Sprintf(roomRolesKeyFormat, roomID)
+	if role == RoleMember {
+		return r.client.HDel(ctx, key, userID).Err()
+	}
+	return r.client.HSet(ctx, key, userID, int(role)).Err()
+}
+
+func (r *RedisRepository) GetMemberRole(ctx context.Context, roomID, userID string) (MemberRole, error) {
+	value, err := r.client.HGet(ctx, fmt.Sprintf(roomRolesKeyFormat, roomID), userID).Result()
+	if errors.Is(err, redis.Nil) {
+		return RoleMember, nil
+	}
+	if err != nil {
+		return RoleMember, err
+	}
+
+	role, err := strconv.Atoi(value)
+	if err != nil {
+		return RoleMember, fmt.Errorf("invalid role %q: %w", value, err)
+	}
+	return MemberRole(role), nil
+}
+
+// GetMemberRoles returns the members holding a role above RoleMember
+func (r *RedisRepository) GetMemberRoles(ctx context.Context, roomID string) (map[string]MemberRole, error) {
+	values, err := r.client.HGetAll(ctx, fmt.Sprintf(roomRolesKeyFormat, roomID)).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	roles := make(map[string]MemberRole, len(values))
+	for userID, value := range values {
+		role, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid role %q: %w", value, err)
+		}
+		roles[userID] = MemberRole(role)
+	}
+	return roles, nil
+}
+
+// TakePostSlot claims the right to post for the slow mode interval, shared by every node.
+// It returns how long the user still has to wait, 0 when the post is allowed.
+func (r *RedisRepository) TakePostSlot(ctx context.Context, roomID, userID string, interval time.Duration) (time.Duration, error) {
+	key := fmt.Sprintf(slowModeKeyFormat, roomID, userID)
+	wait, err := takePostSlotScript.Run(ctx, r.client, []string{key}, interval.Milliseconds()).Int64()
+	if err != nil {
+		return 0, err
+	}
+	return time.Duration(wait) * time.Millisecond, nil
+}
+
+// ReleasePostSlot frees the slot taken for a post that failed, the user can post again right away
+func (r *RedisRepository) ReleasePostSlot(ctx context.Context, roomID, userID string) error {
+	return r.client.Del(ctx, fmt.Sprintf(slowModeKeyFormat, roomID, userID)).Err()
+}
+
+// TransferOwnership makes the member newOwnerID the owner of the room in place of ownerID,
+// who stays on as an admin when they are still a member
+func (r *RedisRepository) TransferOwnership(ctx context.Context, roomID, ownerID, newOwnerID string) error {
+	keys := []string{
+		fmt.Sprintf(roomKeyFormat, roomKey, roomID),
+		fmt.Sprintf(roomMembersKeyFormat, roomID),
+		fmt.Sprintf(roomRolesKeyFormat, roomID),
+		fmt.Sprintf(roomsByCreatorKeyFormat, ownerID),
+		fmt.Sprintf(roomsByCreatorKeyFormat, newOwnerID),
+	}
+	result, err := transferOwnershipScript.Run(ctx, r.client, keys, ownerID, newOwnerID, int(RoleAdmin), roomID).Int()
+	if err != nil {
+		return err
+	}
+
+	switch result {
+	case -1:
+		return ErrRoomNotFound
+	case -2:
+		return ErrNotRoomOwner
+	case -3:
+		return ErrNotRoomMember
+	}
+	return nil
+}
+
+// GetMemberJoinTimes returns when each member joined the room, members who joined
+// before join times were recorded have a zero time
+func (r *RedisRepository) GetMemberJoinTimes(ctx context.Context, roomID string) (map[string]time.Time, error) {
+	entries, err := r.client.ZRangeWithScores(ctx, fmt.Sprintf(roomJoinedKeyFormat, roomID), 0, -1).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	joined := make(map[string]time.Time, len(entries))
+	for _, z := range entries {
+		joined[z.Member.(string)] = joinTimeFromScore(z.Score)
+	}
+	return joined, nil
+}
+
+// ListRoomMembersPage returns up to limit members in join order after the cursor, ties broken by
+// user ID as the sorted set does, with their role. Owners are listed with their stored role.
+func (r *RedisRepository) ListRoomMembersPage(ctx context.Context, roomID string, after *memberCursor, limit int) ([]MemberInfo, error) {
+	joinedKey := fmt.Sprintf(roomJoinedKeyFormat, roomID)
+
+	var entries []redis.Z
+	var err error
+	if after == nil {
+		entries, err = r.client.ZRangeWithScores(ctx, joinedKey, 0, int64(limit-1)).Result()
+	} else {
+		score := time.Unix(0, after.JoinedAt).UnixMilli()
+		var raw []interface{}
+		raw, err = memberPageScript.Run(ctx, r.client, []string{joinedKey}, score, after.ID, limit).Slice()
+		entries = make([]redis.Z, 0, len(raw)/2)
+		for i := 0; i+1 < len(raw); i += 2 {
+			member, _ := raw[i].(string)
+			score, _ := strconv.ParseFloat(fmt.Sprint(raw[i+1]), 64)
+			entries = append(entries, redis.Z{Member: member, Score: score})
+		}
+	}
+	if err != nil || len(entries) == 0 {
+		return nil, err
+	}
+
+	userIDs := make([]string, len(entries))
+	for i, z := range entries {
+		userIDs[i] = z.Member.(string)
+	}
+	roles, err := r.client.HMGet(ctx, fmt.Sprintf(roomRolesKeyFormat, roomID), userIDs...).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	members := make([]MemberInfo, len(entries))
+	for i, z := range entries {
+		members[i] = MemberInfo{UserID: userIDs[i], JoinedAt: joinTimeFromScore(z.Score)}
+		if value, ok := roles[i].(string); ok {
+			role, err := strconv.Atoi(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid role %q: %w", value, err)
+			}
+			members[i].Role = MemberRole(role)
+		}
+	}
+	return members, nil
+}
+
+// memberPageScript returns up to ARGV[3] members of the sorted set KEYS[1] with their score,
+// after the member ARGV[2] joined at ARGV[1]. The page starts right after that member when it
+// is still there with that score, otherwise after every member it would come after.
+var memberPageScript = redis.NewScript(`
+local limit = tonumber(ARGV[3])
+local rank = redis.call('ZRANK', KEYS[1], ARGV[2])
+if rank and tonumber(redis.call('ZSCORE', KEYS[1], ARGV[2])) == tonumber(ARGV[1]) then
+	return redis.call('ZRANGE', KEYS[1], rank + 1, rank + limit, 'WITHSCORES')
+end
+
+local start = redis.call('ZCOUNT', KEYS[1], '-inf', '(' .. ARGV[1])
+for _, member in ipairs(redis.call('ZRANGEBYSCORE', KEYS[1], ARGV[1], ARGV[1])) do
+	if member < ARGV[2] then
+		start = start + 1
+	end
+end
+return redis.call('ZRANGE', KEYS[1], start, start + limit - 1, 'WITHSCORES')
+`)
+
+// joinTimeFromScore reads a score of the joined set, 0 marks members who joined before
+// join times were recorded
+func joinTimeFromScore(score float64) time.Time {
+	if score == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(int64(score))
+}
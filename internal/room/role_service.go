@@ -0,0 +1,60 @@
+package room
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	ErrInvalidRole      = errors.New("invalid member role")
+	ErrInsufficientRole = errors.New("your role in the room does not allow this action")
+)
+
+// SetMemberRole changes the role of a member. The owner can hand out any role but owner,
+// admins can only promote members to moderator and demote moderators.
+func (s *RoomService) SetMemberRole(ctx context.Context, roomID, memberID string, role MemberRole, userID string) error {
+	if role < RoleMember || role >= RoleOwner {
+		return ErrInvalidRole
+	}
+
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	isMember, err := s.repo.IsRoomMember(ctx, roomID, memberID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrNotRoomMember
+	}
+
+	callerRole, err := s.memberRole(ctx, room, userID)
+	if err != nil {
+		return err
+	}
+	currentRole, err := s.memberRole(ctx, room, memberID)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case currentRole == RoleOwner:
+		return ErrInsufficientRole
+	case callerRole == RoleOwner:
+	case callerRole == RoleAdmin && currentRole < RoleAdmin && role < RoleAdmin:
+	default:
+		return ErrInsufficientRole
+	}
+
+	return s.repo.SetMemberRole(ctx, roomID, memberID, role)
+}
+
+// memberRole resolves the role of the user in the room, Room.CreatedBy being its owner
+func (s *RoomService) memberRole(ctx context.Context, room *Room, userID string) (MemberRole, error) {
+	if room.CreatedBy == userID {
+		return RoleOwner, nil
+	}
+	return s.repo.GetMemberRole(ctx, room.ID, userID)
+}
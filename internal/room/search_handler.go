@@ -0,0 +1,31 @@
+package room
+
+import (
+	"context"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *RoomHandler) SearchRooms(ctx context.Context, req *pb.SearchRoomsRequest) (*pb.SearchRoomsResponse, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	matches, nextCursor, err := h.service.SearchRooms(ctx, userID.String(), req.Query, int(req.Limit), req.Cursor)
+	if err != nil {
+		return nil, statusFromError(err, "failed to search rooms")
+	}
+
+	resp := &pb.SearchRoomsResponse{
+		Results:    make([]*pb.RoomSearchResult, len(matches)),
+		NextCursor: nextCursor,
+	}
+	for i, match := range matches {
+		resp.Results[i] = &pb.RoomSearchResult{Room: convertToPbRoom(match.Room), Relevance: match.Relevance}
+	}
+	return resp, nil
+}
@@ -0,0 +1,125 @@
+package room
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// The search index holds, for every gram of the normalized name and topic of the rooms, the rooms
+// having it: the padded trigrams the similarity is computed on, and the letters and pairs of letters
+// of each word so that queries too short for a trigram still find the rooms containing them.
+// room:<id>:search_grams keeps the grams of a room to take it out of the index again.
+const (
+	searchGramKeyFormat      = "rooms:search:%s"
+	roomSearchGramsKeyFormat = "room:%s:search_grams"
+)
+
+// SearchRoomsPage gathers from the search index the rooms that can match the query, keeps those not
+// archived the user can see, and ranks them all to return the page past the cursor
+func (r *RedisRepository) SearchRoomsPage(ctx context.Context, query, userID string, after *searchCursor, limit int) ([]RoomMatch, error) {
+	if query == "" {
+		return nil, nil
+	}
+
+	prefix := "rooms:search_results:" + uuid.NewString()
+	found, visible := prefix+":found", prefix+":visible"
+
+	pipe := r.client.TxPipeline()
+	pipe.SUnionStore(ctx, found, searchCandidateKeys(query)...)
+	pipe.SUnionStore(ctx, visible, roomsPublicKey,
+		fmt.Sprintf(roomsByCreatorKeyFormat, userID),
+		fmt.Sprintf(userRoomsKeyFormat, userID))
+	candidates := pipe.SInter(ctx, found, visible, roomsUnarchivedKey)
+	pipe.Del(ctx, found, visible)
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, err
+	}
+
+	ids := candidates.Val()
+	var matching []*Room
+	for start := 0; start < len(ids); start += roomListBatchSize {
+		batch := ids[start:min(start+roomListBatchSize, len(ids))]
+		entries := make([]redis.Z, len(batch))
+		for i, id := range batch {
+			entries[i] = redis.Z{Member: id}
+		}
+
+		rooms, err := r.fetchRooms(ctx, entries)
+		if err != nil {
+			return nil, err
+		}
+		for _, room := range rooms {
+			if room != nil {
+				matching = append(matching, room)
+			}
+		}
+	}
+
+	return pageRoomMatches(query, matching, after, limit), nil
+}
+
+// searchCandidateKeys are the index keys of the rooms that can match the normalized query. A text
+// containing the query contains each of its words, so the rooms with the first trigram of its longest
+// word, or the word itself when shorter, hold every substring match. Similar texts share at least
+// one trigram with the query.
+func searchCandidateKeys(query string) []string {
+	longest := ""
+	for _, word := range strings.Fields(query) {
+		if utf8.RuneCountInString(word) > utf8.RuneCountInString(longest) {
+			longest = word
+		}
+	}
+	if runes := []rune(longest); len(runes) > 3 {
+		longest = string(runes[:3])
+	}
+
+	keys := []string{fmt.Sprintf(searchGramKeyFormat, longest)}
+	for trigram := range trigrams(query) {
+		keys = append(keys, fmt.Sprintf(searchGramKeyFormat, trigram))
+	}
+	return keys
+}
+
+// searchGrams returns the grams the room is indexed under
+func searchGrams(room *Room) []string {
+	set := make(map[string]struct{})
+	for _, text := range []string{normalizeSearchText(room.Name), normalizeSearchText(room.Topic)} {
+		for trigram := range trigrams(text) {
+			set[trigram] = struct{}{}
+		}
+		for _, word := range strings.Fields(text) {
+			runes := []rune(word)
+			for i := range runes {
+				set[string(runes[i])] = struct{}{}
+				if i+2 <= len(runes) {
+					set[string(runes[i:i+2])] = struct{}{}
+				}
+			}
+		}
+	}
+
+	grams := make([]string, 0, len(set))
+	for gram := range set {
+		grams = append(grams, gram)
+	}
+	return grams
+}
+
+// addRoomToSearchIndex queues the indexing of a room that has no grams yet on pipe
+func addRoomToSearchIndex(ctx context.Context, pipe redis.Pipeliner, room *Room) {
+	grams := searchGrams(room)
+	if len(grams) == 0 {
+		return
+	}
+	members := make([]interface{}, len(grams))
+	for i, gram := range grams {
+		pipe.SAdd(ctx, fmt.Sprintf(searchGramKeyFormat, gram), room.ID)
+		members[i] = gram
+	}
+	pipe.SAdd(ctx, fmt.Sprintf(roomSearchGramsKeyFormat, room.ID), members...)
+}
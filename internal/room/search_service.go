@@ -0,0 +1,212 @@
+package room
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"math"
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	defaultSearchLimit   = 20
+	maxSearchLimit       = 100
+	maxSearchQueryLength = 100
+	// minSearchSimilarity is the trigram similarity a misspelt query needs to match
+	minSearchSimilarity = 0.3
+	// topicWeight scales down the matches found in the topic rather than the name
+	topicWeight = 0.5
+)
+
+var ErrInvalidSearch = errors.New("search query must be between 1 and 100 characters")
+
+// searchCursor is the last result of a SearchRooms page, results are ranked by relevance,
+// then by last activity, then by room ID
+type searchCursor struct {
+	Query        string  `json:"q"`
+	Relevance    float64 `json:"r"`
+	LastActivity int64   `json:"a"`
+	ID           string  `json:"id"`
+}
+
+// SearchRooms finds the rooms whose name or topic matches the query by prefix, by substring or
+// despite a typo. Archived rooms are left out and private ones only show up for their members.
+func (s *RoomService) SearchRooms(ctx context.Context, userID, query string, limit int, cursor string) ([]RoomMatch, string, error) {
+	query = normalizeSearchText(query)
+	if query == "" || utf8.RuneCountInString(query) > maxSearchQueryLength {
+		return nil, "", ErrInvalidSearch
+	}
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	limit = min(limit, maxSearchLimit)
+
+	var after *searchCursor
+	if cursor != "" {
+		decoded, err := decodeSearchCursor(cursor)
+		if err != nil || decoded.Query != query {
+			return nil, "", ErrInvalidPageToken
+		}
+		after = decoded
+	}
+
+	// one more match than asked tells whether there is a next page
+	matches, err := s.repo.SearchRoomsPage(ctx, query, userID, after, limit+1)
+	if err != nil {
+		return nil, "", err
+	}
+	if len(matches) <= limit {
+		return matches, "", nil
+	}
+
+	page := matches[:limit]
+	last := page[len(page)-1]
+	nextCursor := encodeSearchCursor(&searchCursor{
+		Query:        query,
+		Relevance:    last.Relevance,
+		LastActivity: last.Room.LastActivity.UnixMilli(),
+		ID:           last.Room.ID,
+	})
+	return page, nextCursor, nil
+}
+
+// searchRelevance rates from 0 to 1 how well the room matches the normalized query, 0 being no match.
+// It is rounded so rooms matching about as well are ranked by activity.
+func searchRelevance(query string, room *Room) float64 {
+	relevance := max(
+		textRelevance(query, normalizeSearchText(room.Name)),
+		textRelevance(query, normalizeSearchText(room.Topic))*topicWeight,
+	)
+	return math.Round(relevance*100) / 100
+}
+
+func textRelevance(query, text string) float64 {
+	switch {
+	case text == "":
+		return 0
+	case text == query:
+		return 1
+	case strings.HasPrefix(text, query):
+		return 0.9
+	case strings.Contains(text, " "+query):
+		// a word of the text starts with the query
+		return 0.8
+	case strings.Contains(text, query):
+		return 0.7
+	}
+
+	if similarity := trigramSimilarity(query, text); similarity >= minSearchSimilarity {
+		return 0.6 * similarity
+	}
+	return 0
+}
+
+// trigramSimilarity compares the trigrams of the query with those of the whole text and of each
+// of its words, like pg_trgm does, and returns the best similarity found
+func trigramSimilarity(query, text string) float64 {
+	queryTrigrams := trigrams(query)
+	best := jaccard(queryTrigrams, trigrams(text))
+	for _, word := range strings.Fields(text) {
+		best = max(best, jaccard(queryTrigrams, trigrams(word)))
+	}
+	return best
+}
+
+// trigrams returns the trigrams of every word of s, padded with two spaces in front and one behind
+func trigrams(s string) map[string]struct{} {
+	set := make(map[string]struct{})
+	for _, word := range strings.Fields(s) {
+		runes := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			set[string(runes[i:i+3])] = struct{}{}
+		}
+	}
+	return set
+}
+
+func jaccard(a, b map[string]struct{}) float64 {
+	if len(a) == 0 || len(b) == 0 {
+		return 0
+	}
+	shared := 0
+	for trigram := range a {
+		if _, ok := b[trigram]; ok {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(a)+len(b)-shared)
+}
+
+// normalizeSearchText lowercases s and reduces it to its letters and digits separated by single spaces
+func normalizeSearchText(s string) string {
+	words := strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+func sortRoomMatches(matches []RoomMatch) {
+	sort.Slice(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if a.Relevance != b.Relevance {
+			return a.Relevance > b.Relevance
+		}
+		if activityA, activityB := a.Room.LastActivity.UnixMilli(), b.Room.LastActivity.UnixMilli(); activityA != activityB {
+			return activityA > activityB
+		}
+		return a.Room.ID < b.Room.ID
+	})
+}
+
+// pageRoomMatches ranks the rooms matching the query and returns up to limit of them past the cursor,
+// for the stores that cannot rank in the query. The rooms are expected to be visible to the searching user.
+func pageRoomMatches(query string, rooms []*Room, after *searchCursor, limit int) []RoomMatch {
+	matches := make([]RoomMatch, 0, len(rooms))
+	for _, room := range rooms {
+		if room.IsArchived() {
+			continue
+		}
+		relevance := searchRelevance(query, room)
+		if relevance == 0 {
+			continue
+		}
+		match := RoomMatch{Room: room, Relevance: relevance}
+		if after == nil || isAfterMatch(match, after) {
+			matches = append(matches, match)
+		}
+	}
+	sortRoomMatches(matches)
+	return matches[:min(limit, len(matches))]
+}
+
+func isAfterMatch(match RoomMatch, cursor *searchCursor) bool {
+	if match.Relevance != cursor.Relevance {
+		return match.Relevance < cursor.Relevance
+	}
+	if activity := match.Room.LastActivity.UnixMilli(); activity != cursor.LastActivity {
+		return activity < cursor.LastActivity
+	}
+	return match.Room.ID > cursor.ID
+}
+
+func encodeSearchCursor(cursor *searchCursor) string {
+	raw, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(raw)
+}
+
+func decodeSearchCursor(token string) (*searchCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, err
+	}
+
+	var cursor searchCursor
+	if err := json.Unmarshal(raw, &cursor); err != nil {
+		return nil, err
+	}
+	return &cursor, nil
+}
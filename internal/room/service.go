@@ -2,10 +2,9 @@ package room
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
-	"github.com/redis/go-redis/v9"
+	"github.com/assu-2000/StreamRPC/config"
 	"log"
 	"sync"
 	"time"
@@ -13,70 +12,147 @@ import (
 	"github.com/google/uuid"
 )
 
-type RoomService struct {
-	repo          RoomRepository
-	activeRooms   map[string]*RoomContext
-	activeRoomsMu sync.RWMutex
-}
+const (
+	defaultRoomPageSize = 50
+	maxRoomPageSize     = 200
+)
+
+var (
+	ErrNotRoomOwner    = errors.New("only the room owner can perform this action")
+	ErrNotRoomMember   = errors.New("user is not a member of the room")
+	ErrPrivateRoom     = errors.New("room is private, an invite or an approved join request is required to join it")
+	ErrInvalidSlowMode = errors.New("slow mode interval cannot be negative")
+	ErrInvalidCreator  = errors.New("room creator filter must be a user ID")
+)
 
-type RoomContext struct {
-	Members    map[string]struct{}
-	CancelFunc context.CancelFunc
+type RoomService struct {
+	repo        RoomRepository
+	profiles    ProfileDirectory
+	hub         *roomHub
+	nodeID      string
+	presenceTTL time.Duration
+
+	archiveGracePeriod   time.Duration
+	archivePurgeInterval time.Duration
+	messageHistorySize   int
+
+	adminUserIDs      map[string]struct{}
+	reconcileInterval time.Duration
+
+	// sessions held by this node, keyed by session ID, kept alive by RunPresenceHeartbeat
+	sessions   map[string]PresenceSession
+	sessionsMu sync.Mutex
 }
 
-func NewRoomService(repo RoomRepository) *RoomService {
+// NewRoomService builds the service, profiles may be nil in which case members are listed without profile
+func NewRoomService(repo RoomRepository, profiles ProfileDirectory, cfg config.RoomConfig) *RoomService {
 	return &RoomService{
 		repo:        repo,
-		activeRooms: make(map[string]*RoomContext),
+		profiles:    profiles,
+		hub:         newRoomHub(repo, cfg),
+		nodeID:      cfg.NodeID,
+		presenceTTL: cfg.PresenceTTL,
+		sessions:    make(map[string]PresenceSession),
+
+		archiveGracePeriod:   cfg.ArchiveGracePeriod,
+		archivePurgeInterval: cfg.ArchivePurgeInterval,
+		messageHistorySize:   cfg.MessageHistorySize,
+
+		adminUserIDs:      adminSet(cfg.AdminUserIDs),
+		reconcileInterval: cfg.ReconcileInterval,
 	}
 }
 
-func (s *RoomService) CreateRoom(ctx context.Context, name string, creatorID string, isPrivate bool) (*Room, error) {
+// CreateRoom creates a room, maxMembers 0 leaves it uncapped
+func (s *RoomService) CreateRoom(ctx context.Context, name string, creatorID string, isPrivate bool, maxMembers int) (*Room, error) {
+	if maxMembers < 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	now := time.Now()
 	room := &Room{
-		ID:        uuid.New().String(),
-		Name:      name,
-		CreatedAt: time.Now(),
-		CreatedBy: creatorID,
-		IsPrivate: isPrivate,
+		ID:           uuid.New().String(),
+		Name:         name,
+		CreatedAt:    now,
+		CreatedBy:    creatorID,
+		IsPrivate:    isPrivate,
+		MaxMembers:   maxMembers,
+		LastActivity: now,
 	}
 
 	if err := s.repo.CreateRoom(ctx, room); err != nil {
 		return nil, err
 	}
+	s.publishRoomChange(DirectoryRoomCreated, room, creatorID)
 
 	return room, nil
 }
 
-func (s *RoomService) JoinRoom(ctx context.Context, roomID, userID string) (<-chan RoomEvent, error) {
+// JoinRoom adds the user to the room and opens a presence session for this stream,
+// the session ends and the stream is closed once ctx is done.
+// Archived rooms can still be followed by their members but take no new ones,
+// private rooms only take new members through an invite, their space or a join request.
+// A full room rejects the join with ErrRoomFull unless waitIfFull is set, the user then
+// waits in the waitlist and the returned position is their place in it, 0 for members.
+func (s *RoomService) JoinRoom(ctx context.Context, roomID, userID string, waitIfFull bool) (*EventStream, int, error) {
 	// checks if room does exist
-	exists, err := s.repo.RoomExists(ctx, roomID)
-	if err != nil || !exists {
-		return nil, errors.New("room does not exist")
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	// Adds the user into the room
-	if err := s.repo.AddRoomMember(ctx, roomID, userID); err != nil {
-		return nil, err
+	isMember, err := s.repo.IsRoomMember(ctx, roomID, userID)
+	if err != nil {
+		return nil, 0, err
+	}
+	canJoin, err := s.canJoinRoom(ctx, room, userID)
+	if err != nil {
+		return nil, 0, err
 	}
 
-	// creates channel for events
-	events := make(chan RoomEvent, 10)
+	position := 0
+	switch {
+	case isMember:
+	case room.IsArchived():
+		return nil, 0, ErrRoomArchived
+	case !canJoin:
+		return nil, 0, ErrPrivateRoom
+	case waitIfFull:
+		// Adds the user into the room, or into its waitlist when it is full
+		position, err = s.repo.QueueRoomMember(ctx, roomID, userID)
+		if err != nil {
+			return nil, 0, err
+		}
+		if position == 0 {
+			s.publishMemberDelta(room, userID, 1)
+			s.notifyUser(userID, UserEvent{Type: UserRoomAdded, RoomID: roomID})
+		}
+	default:
+		// Adds the user into the room
+		if err := s.repo.AddRoomMember(ctx, roomID, userID); err != nil {
+			return nil, 0, err
+		}
+		s.publishMemberDelta(room, userID, 1)
+		s.notifyUser(userID, UserEvent{Type: UserRoomAdded, RoomID: roomID})
+	}
 
-	// handles Redis PubSub connection
-	roomCtx, cancel := context.WithCancel(ctx)
-	pubsub := s.repo.SubscribeToRoom(roomCtx, roomID)
+	if position > 0 {
+		return s.waitForSlot(ctx, roomID, userID), position, nil
+	}
 
-	// Stocke le room actif
-	s.activeRoomsMu.Lock()
-	s.activeRooms[roomID] = &RoomContext{
-		Members:    make(map[string]struct{}),
-		CancelFunc: cancel,
+	// every stream is its own session, so a user can be joined from several devices
+	session, err := s.startSession(ctx, roomID, userID)
+	if err != nil {
+		return nil, 0, err
 	}
-	s.activeRooms[roomID].Members[userID] = struct{}{}
-	s.activeRoomsMu.Unlock()
 
-	// Goroutine to handle messages
-	go s.handlePubSubMessages(roomID, pubsub, events)
+	// attaches the stream to the room feed shared by this node
+	stream := s.hub.subscribe(ctx, roomID, nil)
+	go func() {
+		<-ctx.Done()
+		s.endSession(session)
+	}()
+
 	// notifies other users
 	s.broadcastRoomEvent(roomID, RoomEvent{
 		Type:   EventUserJoined,
@@ -84,35 +160,84 @@ func (s *RoomService) JoinRoom(ctx context.Context, roomID, userID string) (<-ch
 		RoomID: roomID,
 	})
 
-	return events, nil
+	return stream, 0, nil
 }
 
+// LeaveRoom removes the user from the room and ends all of their sessions in it, on every node.
+// An owner leaving hands the room over to its longest-standing admin.
 func (s *RoomService) LeaveRoom(ctx context.Context, roomID, userID string) error {
-	s.activeRoomsMu.Lock()
-	defer s.activeRoomsMu.Unlock()
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil && !errors.Is(err, ErrRoomNotFound) {
+		return err
+	}
+	if room != nil && room.CreatedBy == userID {
+		if err := s.handOverOwnership(ctx, room); err != nil {
+			return err
+		}
+	}
 
+	wasMember, err := s.repo.IsRoomMember(ctx, roomID, userID)
+	if err != nil {
+		return err
+	}
 	if err := s.repo.RemoveRoomMember(ctx, roomID, userID); err != nil {
 		return err
 	}
+	if room != nil && wasMember {
+		s.publishMemberDelta(room, userID, -1)
+	}
+	if wasMember {
+		s.notifyUser(userID, UserEvent{Type: UserRoomRemoved, RoomID: roomID})
+	}
 
-	if roomCtx, exists := s.activeRooms[roomID]; exists {
-		delete(roomCtx.Members, userID)
-		if len(roomCtx.Members) == 0 {
-			roomCtx.CancelFunc()
-			delete(s.activeRooms, roomID)
-		}
+	if err := s.repo.RemoveUserPresence(ctx, roomID, userID); err != nil {
+		return err
 	}
 
-	// notifies other users
+	// notifies other users, streams of the leaving user close on this event
 	s.broadcastRoomEvent(roomID, RoomEvent{
 		Type:   EventUserLeft,
 		UserID: userID,
 		RoomID: roomID,
 	})
 
+	// the freed slot goes to the head of the waitlist
+	s.promoteWaitlist(roomID)
+
 	return nil
 }
 
+// RemoveMember makes another member leave the room, admins and the owner can remove
+// the members ranked below them
+func (s *RoomService) RemoveMember(ctx context.Context, roomID, memberID, userID string) error {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+
+	isMember, err := s.repo.IsRoomMember(ctx, roomID, memberID)
+	if err != nil {
+		return err
+	}
+	if !isMember {
+		return ErrNotRoomMember
+	}
+
+	callerRole, err := s.memberRole(ctx, room, userID)
+	if err != nil {
+		return err
+	}
+	memberRole, err := s.memberRole(ctx, room, memberID)
+	if err != nil {
+		return err
+	}
+	if callerRole < RoleAdmin || memberRole >= callerRole {
+		return ErrInsufficientRole
+	}
+
+	return s.LeaveRoom(ctx, roomID, memberID)
+}
+
 func (s *RoomService) broadcastRoomEvent(roomID string, event RoomEvent) {
 	err := s.repo.PublishRoomEvent(context.Background(), roomID, event)
 	if err != nil {
@@ -126,35 +251,143 @@ func (s *RoomService) GetRoom(ctx context.Context, roomID string) (*Room, error)
 	return s.repo.GetRoom(ctx, roomID)
 }
 
-// ListRooms returns all available rooms
-func (s *RoomService) ListRooms(ctx context.Context) ([]*Room, error) {
-	roomIDs, err := s.repo.ListRoomIDs(ctx)
+// UpdateRoom applies the fields set in update and notifies joined members with the new state
+func (s *RoomService) UpdateRoom(ctx context.Context, roomID, userID string, update RoomUpdate) (*Room, error) {
+	room, err := s.repo.GetRoom(ctx, roomID)
 	if err != nil {
 		return nil, err
 	}
 
-	var rooms []*Room
-	for _, id := range roomIDs {
-		room, err := s.repo.GetRoom(ctx, id)
-		if err != nil {
-			continue // ou retourner l'erreur selon le cas
+	if room.CreatedBy != userID {
+		return nil, ErrNotRoomOwner
+	}
+	if room.IsArchived() {
+		return nil, ErrRoomArchived
+	}
+
+	if update.Name != nil {
+		room.Name = *update.Name
+	}
+	if update.Topic != nil {
+		room.Topic = *update.Topic
+	}
+	if update.Description != nil {
+		room.Description = *update.Description
+	}
+	if update.AvatarURL != nil {
+		room.AvatarURL = *update.AvatarURL
+	}
+	if update.IsPrivate != nil {
+		room.IsPrivate = *update.IsPrivate
+	}
+	if update.MaxMembers != nil {
+		if *update.MaxMembers < 0 {
+			return nil, ErrInvalidCapacity
 		}
-		rooms = append(rooms, room)
+		room.MaxMembers = *update.MaxMembers
+	}
+	if update.SlowModeInterval != nil {
+		if *update.SlowModeInterval < 0 {
+			return nil, ErrInvalidSlowMode
+		}
+		room.SlowModeInterval = *update.SlowModeInterval
+	}
+	if update.AnnouncementOnly != nil {
+		room.AnnouncementOnly = *update.AnnouncementOnly
+	}
+
+	if err := s.repo.UpdateRoom(ctx, room); err != nil {
+		return nil, err
 	}
 
-	return rooms, nil
+	s.broadcastRoomUpdate(room, userID)
+
+	// a raised capacity lets waitlisted users in
+	if update.MaxMembers != nil {
+		s.promoteWaitlist(roomID)
+	}
+	return room, nil
 }
 
-// DeleteRoom deletes room and its members
-func (s *RoomService) DeleteRoom(ctx context.Context, roomID string) error {
-	// 1. notifies other users
-	s.broadcastRoomEvent(roomID, RoomEvent{
-		Type:   EventRoomDeleted,
-		RoomID: roomID,
-	})
+// broadcastRoomUpdate sends the new state of the room to its joined members
+func (s *RoomService) broadcastRoomUpdate(room *Room, userID string) {
+	event, err := NewRoomEvent(EventRoomUpdated, room.ID, userID, room)
+	if err != nil {
+		log.Printf("Failed to build room update event: %v", err)
+		return
+	}
+	s.broadcastRoomEvent(room.ID, event)
+	s.publishRoomChange(DirectoryRoomUpdated, room, userID)
+}
+
+// ListRooms returns a page of rooms matching opts and the token of the next page
+func (s *RoomService) ListRooms(ctx context.Context, opts RoomListOptions) ([]*Room, string, error) {
+	if opts.Filter.CreatedBy != "" {
+		if _, err := uuid.Parse(opts.Filter.CreatedBy); err != nil {
+			return nil, "", ErrInvalidCreator
+		}
+	}
+	if opts.PageSize <= 0 {
+		opts.PageSize = defaultRoomPageSize
+	}
+	if opts.PageSize > maxRoomPageSize {
+		opts.PageSize = maxRoomPageSize
+	}
+
+	return s.repo.ListRooms(ctx, opts)
+}
 
-	// 2. delets room
-	return s.repo.DeleteRoom(ctx, roomID)
+// RoomDeletion is the payload of EventRoomDeleted, the event UserID being who deleted the room
+type RoomDeletion struct {
+	Reason string
+}
+
+// DeleteRoom deletes the room for good, only its owner can do it
+func (s *RoomService) DeleteRoom(ctx context.Context, roomID, userID, reason string) error {
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return err
+	}
+	if room.CreatedBy != userID {
+		return ErrNotRoomOwner
+	}
+
+	return s.deleteRoom(ctx, roomID, userID, reason)
+}
+
+// deleteRoom removes the room with its members, presence and history, then tells every node.
+// Streams following the room get EventRoomDeleted and are closed with ErrRoomDeleted.
+func (s *RoomService) deleteRoom(ctx context.Context, roomID, actorID, reason string) error {
+	// a room that cannot be read is treated as private, so only the watchers who saw it hear of it
+	isPrivate := true
+	if room, err := s.repo.GetRoom(ctx, roomID); err == nil {
+		isPrivate = room.IsPrivate
+	}
+
+	if err := s.repo.DeleteRoom(ctx, roomID); err != nil {
+		return err
+	}
+	return s.roomDeleted(roomID, isPrivate, actorID, reason)
+}
+
+// roomDeleted closes the streams of a deleted room and tells its members and the directory watchers
+func (s *RoomService) roomDeleted(roomID string, isPrivate bool, actorID, reason string) error {
+	s.hub.forget(roomID)
+
+	deletion := RoomDeletion{Reason: reason}
+	event, err := NewRoomEvent(EventRoomDeleted, roomID, actorID, deletion)
+	if err != nil {
+		return err
+	}
+	s.broadcastRoomEvent(roomID, event)
+	s.publishDirectoryEvent(DirectoryEvent{
+		Type:      DirectoryRoomDeleted,
+		RoomID:    roomID,
+		IsPrivate: isPrivate,
+		UserID:    actorID,
+		Deletion:  &deletion,
+	})
+	return nil
 }
 
 // GetRoomMembers returns members of a given room
@@ -164,51 +397,20 @@ func (s *RoomService) GetRoomMembers(ctx context.Context, roomID string) ([]stri
 
 // RoomStats returns a room's stats
 func (s *RoomService) RoomStats(ctx context.Context, roomID string) (*RoomStats, error) {
-	members, err := s.repo.GetRoomMembers(ctx, roomID)
+	room, err := s.repo.GetRoom(ctx, roomID)
 	if err != nil {
 		return nil, err
 	}
 
-	room, err := s.repo.GetRoom(ctx, roomID)
+	sessions, err := s.repo.GetRoomPresence(ctx, roomID)
 	if err != nil {
 		return nil, err
 	}
 
-	s.activeRoomsMu.RLock()
-	activeCount := len(s.activeRooms[roomID].Members)
-	s.activeRoomsMu.RUnlock()
-
 	return &RoomStats{
 		Room:          room,
-		TotalMembers:  len(members),
-		ActiveMembers: activeCount,
+		TotalMembers:  room.MemberCount,
+		ActiveMembers: len(onlineUsers(sessions)),
+		Delivery:      s.hub.stats(roomID),
 	}, nil
 }
-
-func (s *RoomService) handlePubSubMessages(roomID string, pubsub *redis.PubSub, events chan<- RoomEvent) {
-	defer close(events)
-	defer pubsub.Close()
-
-	for {
-		msg, err := pubsub.ReceiveMessage(context.Background())
-		if err != nil {
-			if errors.Is(err, redis.ErrClosed) {
-				return
-			}
-			log.Printf("PubSub error: %v", err)
-			continue
-		}
-
-		var event RoomEvent
-		if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
-			log.Printf("Failed to unmarshal event: %v", err)
-			continue
-		}
-
-		select {
-		case events <- event:
-		default:
-			log.Println("Event channel full, dropping event")
-		}
-	}
-}
@@ -0,0 +1,339 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/assu-2000/StreamRPC/config"
+	"github.com/google/uuid"
+)
+
+func newTestService() *RoomService {
+	return NewRoomService(NewMemoryRepository(), nil, config.RoomConfig{
+		NodeID:             "test",
+		PresenceTTL:        30 * time.Second,
+		EventBufferSize:    16,
+		SlowConsumerPolicy: config.DropOldest,
+		MessageHistorySize: 100,
+	})
+}
+
+// nextEvent returns the first event of type want on the stream, skipping the others
+func nextEvent(t *testing.T, stream *EventStream, want EventType) RoomEvent {
+	t.Helper()
+	timeout := time.After(time.Second)
+	for {
+		select {
+		case event, ok := <-stream.Events():
+			if !ok {
+				t.Fatalf("stream closed before event %v: %v", want, stream.Err())
+			}
+			if event.Type == want {
+				return event
+			}
+		case <-timeout:
+			t.Fatalf("no event %v", want)
+		}
+	}
+}
+
+func TestJoinRoom(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := newTestService()
+
+	room, err := svc.CreateRoom(ctx, "general", "owner", false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := svc.JoinRoom(ctx, room.ID, "alice", false); err != nil {
+		t.Fatal(err)
+	}
+
+	isMember, err := svc.repo.IsRoomMember(ctx, room.ID, "alice")
+	if err != nil || !isMember {
+		t.Fatalf("alice is not a member: %v", err)
+	}
+	joined, err := svc.GetRoom(ctx, room.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if joined.MemberCount != 1 {
+		t.Fatalf("member count = %d, want 1", joined.MemberCount)
+	}
+
+	if _, _, err := svc.JoinRoom(ctx, "missing", "alice", false); !errors.Is(err, ErrRoomNotFound) {
+		t.Fatalf("join of a missing room: %v", err)
+	}
+
+	private, err := svc.CreateRoom(ctx, "private", "owner", true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := svc.JoinRoom(ctx, private.ID, "alice", false); !errors.Is(err, ErrPrivateRoom) {
+		t.Fatalf("join of a private room: %v", err)
+	}
+
+	full, err := svc.CreateRoom(ctx, "full", "owner", false, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := svc.JoinRoom(ctx, full.ID, "alice", false); err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := svc.JoinRoom(ctx, full.ID, "bob", false); !errors.Is(err, ErrRoomFull) {
+		t.Fatalf("join of a full room: %v", err)
+	}
+}
+
+func TestLeaveRoom(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := newTestService()
+
+	room, err := svc.CreateRoom(ctx, "general", "owner", false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher, _, err := svc.JoinRoom(ctx, room.ID, "alice", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := svc.JoinRoom(ctx, room.ID, "bob", false); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.LeaveRoom(ctx, room.ID, "bob"); err != nil {
+		t.Fatal(err)
+	}
+	if event := nextEvent(t, watcher, EventUserLeft); event.UserID != "bob" {
+		t.Fatalf("left event for %s, want bob", event.UserID)
+	}
+
+	isMember, err := svc.repo.IsRoomMember(ctx, room.ID, "bob")
+	if err != nil || isMember {
+		t.Fatalf("bob is still a member: %v", err)
+	}
+	left, err := svc.GetRoom(ctx, room.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if left.MemberCount != 1 {
+		t.Fatalf("member count = %d, want 1", left.MemberCount)
+	}
+}
+
+func TestSendMessage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := newTestService()
+
+	room, err := svc.CreateRoom(ctx, "general", "owner", false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher, _, err := svc.JoinRoom(ctx, room.ID, "alice", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := svc.JoinRoom(ctx, room.ID, "bob", false); err != nil {
+		t.Fatal(err)
+	}
+
+	sent, err := svc.SendMessage(ctx, room.ID, "bob", "hello")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got ChatMessage
+	if err := nextEvent(t, watcher, EventMessage).DecodePayload(&got); err != nil {
+		t.Fatal(err)
+	}
+	if got.ID != sent.ID || got.Content != "hello" || got.UserID != "bob" {
+		t.Fatalf("got message %+v, want %+v", got, sent)
+	}
+
+	history, err := svc.repo.GetMessages(ctx, room.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(history) != 1 || history[0].ID != sent.ID {
+		t.Fatalf("history = %v, want the sent message", history)
+	}
+
+	if _, err := svc.SendMessage(ctx, room.ID, "carol", "hello"); !errors.Is(err, ErrNotRoomMember) {
+		t.Fatalf("send from a non-member: %v", err)
+	}
+	if _, err := svc.SendMessage(ctx, room.ID, "bob", ""); !errors.Is(err, ErrInvalidMessage) {
+		t.Fatalf("send of an empty message: %v", err)
+	}
+}
+
+// failingHistory is a MemoryRepository whose message history refuses writes while fail is set
+type failingHistory struct {
+	*MemoryRepository
+	fail bool
+}
+
+func (r *failingHistory) AppendMessages(ctx context.Context, roomID string, messages []*ChatMessage, limit int) error {
+	if r.fail {
+		return errors.New("history unavailable")
+	}
+	return r.MemoryRepository.AppendMessages(ctx, roomID, messages, limit)
+}
+
+func TestFailedSendReleasesSlowModeSlot(t *testing.T) {
+	ctx := context.Background()
+	repo := &failingHistory{MemoryRepository: NewMemoryRepository()}
+	svc := NewRoomService(repo, nil, config.RoomConfig{NodeID: "test", MessageHistorySize: 100})
+
+	room, err := svc.CreateRoom(ctx, "general", "owner", false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	interval := time.Hour
+	if _, err := svc.UpdateRoom(ctx, room.ID, "owner", RoomUpdate{SlowModeInterval: &interval}); err != nil {
+		t.Fatal(err)
+	}
+	if err := repo.AddRoomMember(ctx, room.ID, "bob"); err != nil {
+		t.Fatal(err)
+	}
+
+	repo.fail = true
+	if _, err := svc.SendMessage(ctx, room.ID, "bob", "hello"); err == nil {
+		t.Fatal("send went through a failing history")
+	}
+	repo.fail = false
+	if _, err := svc.SendMessage(ctx, room.ID, "bob", "hello"); err != nil {
+		t.Fatalf("send after a failed one: %v", err)
+	}
+	var slowMode *SlowModeError
+	if _, err := svc.SendMessage(ctx, room.ID, "bob", "again"); !errors.As(err, &slowMode) {
+		t.Fatalf("second send within the interval: %v", err)
+	}
+}
+
+func TestDeleteRoom(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := newTestService()
+
+	room, err := svc.CreateRoom(ctx, "general", "owner", false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	watcher, _, err := svc.JoinRoom(ctx, room.ID, "alice", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.DeleteRoom(ctx, room.ID, "alice", ""); !errors.Is(err, ErrNotRoomOwner) {
+		t.Fatalf("delete by a member: %v", err)
+	}
+	if err := svc.DeleteRoom(ctx, room.ID, "owner", "done"); err != nil {
+		t.Fatal(err)
+	}
+
+	var deletion RoomDeletion
+	if err := nextEvent(t, watcher, EventRoomDeleted).DecodePayload(&deletion); err != nil {
+		t.Fatal(err)
+	}
+	if deletion.Reason != "done" {
+		t.Fatalf("deletion reason = %q, want done", deletion.Reason)
+	}
+	for range watcher.Events() {
+	}
+	if !errors.Is(watcher.Err(), ErrRoomDeleted) {
+		t.Fatalf("stream ended with %v, want ErrRoomDeleted", watcher.Err())
+	}
+
+	if _, err := svc.GetRoom(ctx, room.ID); !errors.Is(err, ErrRoomNotFound) {
+		t.Fatalf("get of the deleted room: %v", err)
+	}
+	if _, err := svc.SendMessage(ctx, room.ID, "alice", "hello"); !errors.Is(err, ErrRoomNotFound) {
+		t.Fatalf("send to the deleted room: %v", err)
+	}
+}
+
+func TestRemoveMember(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	svc := newTestService()
+
+	room, err := svc.CreateRoom(ctx, "general", "owner", false, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, userID := range []string{"owner", "admin", "alice"} {
+		if _, _, err := svc.JoinRoom(ctx, room.ID, userID, false); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := svc.SetMemberRole(ctx, room.ID, "admin", RoleAdmin, "owner"); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := svc.RemoveMember(ctx, room.ID, "admin", "alice"); !errors.Is(err, ErrInsufficientRole) {
+		t.Fatalf("removal by a member: %v", err)
+	}
+	if err := svc.RemoveMember(ctx, room.ID, "owner", "admin"); !errors.Is(err, ErrInsufficientRole) {
+		t.Fatalf("removal of the owner: %v", err)
+	}
+	if err := svc.RemoveMember(ctx, room.ID, "alice", "admin"); err != nil {
+		t.Fatal(err)
+	}
+
+	isMember, err := svc.repo.IsRoomMember(ctx, room.ID, "alice")
+	if err != nil || isMember {
+		t.Fatalf("alice is still a member: %v", err)
+	}
+	kept, err := svc.GetRoom(ctx, room.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if kept.CreatedBy != "owner" {
+		t.Fatalf("room owned by %s, want owner", kept.CreatedBy)
+	}
+}
+
+func TestListRoomsVisibility(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService()
+
+	if _, err := svc.CreateRoom(ctx, "lobby", "owner", false, 0); err != nil {
+		t.Fatal(err)
+	}
+	secret, err := svc.CreateRoom(ctx, "secret", "owner", true, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob := uuid.NewString()
+	if _, err := svc.CreateRoom(ctx, "hideout", bob, true, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.repo.AddRoomMember(ctx, secret.ID, "alice"); err != nil {
+		t.Fatal(err)
+	}
+
+	for userID, want := range map[string]int{"owner": 2, "alice": 2, bob: 2, "eve": 1} {
+		opts := RoomListOptions{Filter: RoomFilter{VisibleTo: userID}}
+		rooms, _, err := svc.ListRooms(ctx, opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(rooms) != want {
+			t.Errorf("%s sees %d rooms, want %d", userID, len(rooms), want)
+		}
+	}
+
+	opts := RoomListOptions{Filter: RoomFilter{CreatedBy: bob, VisibleTo: "eve"}}
+	if rooms, _, err := svc.ListRooms(ctx, opts); err != nil || len(rooms) != 0 {
+		t.Fatalf("eve lists %d private rooms of bob: %v", len(rooms), err)
+	}
+
+	opts = RoomListOptions{Filter: RoomFilter{CreatedBy: "bob"}}
+	if _, _, err := svc.ListRooms(ctx, opts); !errors.Is(err, ErrInvalidCreator) {
+		t.Fatalf("expected ErrInvalidCreator, got %v", err)
+	}
+}
@@ -0,0 +1,126 @@
+package room
+
+import (
+	"context"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type SpaceHandler struct {
+	pb.UnimplementedSpaceGrpcServiceServer
+	service *RoomService
+}
+
+func NewSpaceGRPCHandler(service *RoomService) *SpaceHandler {
+	return &SpaceHandler{service: service}
+}
+
+func (h *SpaceHandler) CreateSpace(ctx context.Context, req *pb.CreateSpaceRequest) (*pb.Space, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	space, err := h.service.CreateSpace(ctx, req.Name, userID.String(), req.IsPrivate, req.Categories)
+	if err != nil {
+		return nil, statusFromError(err, "failed to create space")
+	}
+
+	return convertToPbSpace(space), nil
+}
+
+func (h *SpaceHandler) JoinSpace(ctx context.Context, req *pb.JoinSpaceRequest) (*pb.Space, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	space, err := h.service.JoinSpace(ctx, req.SpaceId, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to join space")
+	}
+
+	return convertToPbSpace(space), nil
+}
+
+func (h *SpaceHandler) AddSpaceMember(ctx context.Context, req *pb.AddSpaceMemberRequest) (*emptypb.Empty, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	if err := h.service.AddSpaceMember(ctx, req.SpaceId, req.UserId, userID.String()); err != nil {
+		return nil, statusFromError(err, "failed to add space member")
+	}
+
+	return &emptypb.Empty{}, nil
+}
+
+func (h *SpaceHandler) AddRoomToSpace(ctx context.Context, req *pb.AddRoomToSpaceRequest) (*pb.Room, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	room, err := h.service.AddRoomToSpace(ctx, req.SpaceId, req.RoomId, req.Category, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to add room to space")
+	}
+
+	return convertToPbRoom(room), nil
+}
+
+func (h *SpaceHandler) MoveRoom(ctx context.Context, req *pb.MoveRoomRequest) (*pb.Room, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	room, err := h.service.MoveRoom(ctx, req.RoomId, req.Category, int(req.Position), userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to move room")
+	}
+
+	return convertToPbRoom(room), nil
+}
+
+func (h *SpaceHandler) ListSpaceRooms(ctx context.Context, req *pb.ListSpaceRoomsRequest) (*pb.ListSpaceRoomsResponse, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	space, categories, err := h.service.ListSpaceRooms(ctx, req.SpaceId, userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to list space rooms")
+	}
+
+	resp := &pb.ListSpaceRoomsResponse{
+		Space:      convertToPbSpace(space),
+		Categories: make([]*pb.SpaceCategory, len(categories)),
+	}
+	for i, category := range categories {
+		rooms := make([]*pb.Room, len(category.Rooms))
+		for j, room := range category.Rooms {
+			rooms[j] = convertToPbRoom(room)
+		}
+		resp.Categories[i] = &pb.SpaceCategory{Name: category.Name, Rooms: rooms}
+	}
+
+	return resp, nil
+}
+
+func convertToPbSpace(space *Space) *pb.Space {
+	return &pb.Space{
+		Id:        space.ID,
+		Name:      space.Name,
+		IsPrivate: space.IsPrivate,
+		CreatedBy: space.CreatedBy,
+		CreatedAt: timestamppb.New(space.CreatedAt),
+	}
+}
@@ -0,0 +1,208 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// A space is a space:<id> hash with its member set, the set of its rooms, the list of its
+// category names in display order and one list of room IDs per category. Rooms record their
+// space and category in their own hash.
+const (
+	spacesKey                = "spaces"
+	spaceKeyFormat           = "space:%s"
+	spaceMembersKeyFormat    = "space:%s:members"
+	spaceCategoriesKeyFormat = "space:%s:categories"
+	spaceCategoryKeyFormat   = "space:%s:category:%s"
+	spaceRoomsKeyFormat      = "space:%s:rooms"
+)
+
+var (
+	ErrSpaceNotFound    = errors.New("space not found")
+	ErrRoomInOtherSpace = errors.New("room already belongs to another space")
+)
+
+// placeRoomScript puts the room KEYS[1] in the category ARGV[2] of the space KEYS[2] at
+// index ARGV[3] (-1 for the end), taking it out of its previous category first. The
+// category lists are derived from the space key since the previous one is only known
+// from the room hash. ARGV[1] is the space ID and ARGV[4] the room ID.
+var placeRoomScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return -1
+end
+if redis.call('EXISTS', KEYS[2]) == 0 then
+	return -2
+end
+local current = redis.call('HGET', KEYS[1], 'space_id')
+if current and current ~= '' and current ~= ARGV[1] then
+	return -3
+end
+local previous = redis.call('HGET', KEYS[1], 'category')
+if current == ARGV[1] and previous then
+	redis.call('LREM', KEYS[2] .. ':category:' .. previous, 0, ARGV[4])
+end
+local categories = KEYS[2] .. ':categories'
+if not redis.call('LPOS', categories, ARGV[2]) then
+	redis.call('RPUSH', categories, ARGV[2])
+end
+local target = KEYS[2] .. ':category:' .. ARGV[2]
+local pivot = false
+if tonumber(ARGV[3]) >= 0 then
+	pivot = redis.call('LINDEX', target, ARGV[3])
+end
+if pivot then
+	redis.call('LINSERT', target, 'BEFORE', pivot, ARGV[4])
+else
+	redis.call('RPUSH', target, ARGV[4])
+end
+redis.call('HSET', KEYS[1], 'space_id', ARGV[1], 'category', ARGV[2])
+redis.call('SADD', KEYS[2] .. ':rooms', ARGV[4])
+return 1
+`)
+
+func (r *RedisRepository) CreateSpace(ctx context.Context, space *Space, categories []string) error {
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, fmt.Sprintf(spaceKeyFormat, space.ID),
+		"name", space.Name,
+		"is_private", space.IsPrivate,
+		"created_at", space.CreatedAt.Format(time.RFC3339),
+		"created_by", space.CreatedBy,
+	)
+	pipe.SAdd(ctx, spacesKey, space.ID)
+	pipe.SAdd(ctx, fmt.Sprintf(spaceMembersKeyFormat, space.ID), space.CreatedBy)
+	if len(categories) > 0 {
+		names := make([]interface{}, len(categories))
+		for i, name := range categories {
+			names[i] = name
+		}
+		pipe.RPush(ctx, fmt.Sprintf(spaceCategoriesKeyFormat, space.ID), names...)
+	}
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+func (r *RedisRepository) GetSpace(ctx context.Context, spaceID string) (*Space, error) {
+	fields, err := r.client.HGetAll(ctx, fmt.Sprintf(spaceKeyFormat, spaceID)).Result()
+	if err != nil {
+		return nil, err
+	}
+	if len(fields) == 0 {
+		return nil, ErrSpaceNotFound
+	}
+
+	createdAt, _ := time.Parse(time.RFC3339, fields["created_at"])
+	isPrivate, _ := strconv.ParseBool(fields["is_private"])
+	return &Space{
+		ID:        spaceID,
+		Name:      fields["name"],
+		IsPrivate: isPrivate,
+		CreatedAt: createdAt,
+		CreatedBy: fields["created_by"],
+	}, nil
+}
+
+func (r *RedisRepository) AddSpaceMember(ctx context.Context, spaceID, userID string) error {
+	return r.client.SAdd(ctx, fmt.Sprintf(spaceMembersKeyFormat, spaceID), userID).Err()
+}
+
+func (r *RedisRepository) IsSpaceMember(ctx context.Context, spaceID, userID string) (bool, error) {
+	return r.client.SIsMember(ctx, fmt.Sprintf(spaceMembersKeyFormat, spaceID), userID).Result()
+}
+
+// PlaceRoomInSpace adds the room to a category of the space or moves it within the space,
+// the category is created at the end of the space when it does not exist yet
+func (r *RedisRepository) PlaceRoomInSpace(ctx context.Context, spaceID, roomID, category string, position int) error {
+	keys := []string{
+		fmt.Sprintf(roomKeyFormat, roomKey, roomID),
+		fmt.Sprintf(spaceKeyFormat, spaceID),
+	}
+	result, err := placeRoomScript.Run(ctx, r.client, keys, spaceID, category, position, roomID).Int()
+	if err != nil {
+		return err
+	}
+
+	switch result {
+	case -1:
+		return ErrRoomNotFound
+	case -2:
+		return ErrSpaceNotFound
+	case -3:
+		return ErrRoomInOtherSpace
+	}
+	return nil
+}
+
+// ListSpaceRooms returns the categories of the space in order with their rooms,
+// categories without rooms are kept
+func (r *RedisRepository) ListSpaceRooms(ctx context.Context, spaceID string) ([]SpaceCategory, error) {
+	names, roomIDs, err := r.spaceLayout(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	categories := make([]SpaceCategory, len(names))
+	for i, name := range names {
+		entries := make([]redis.Z, len(roomIDs[i]))
+		for j, id := range roomIDs[i] {
+			entries[j] = redis.Z{Member: id}
+		}
+
+		fetched, err := r.fetchRooms(ctx, entries)
+		if err != nil {
+			return nil, err
+		}
+
+		categories[i] = SpaceCategory{Name: name, Rooms: make([]*Room, 0, len(fetched))}
+		for _, room := range fetched {
+			if room != nil {
+				categories[i].Rooms = append(categories[i].Rooms, room)
+			}
+		}
+	}
+	return categories, nil
+}
+
+// spaceLayout returns the category names of the space in order and the room IDs of each one
+func (r *RedisRepository) spaceLayout(ctx context.Context, spaceID string) ([]string, [][]string, error) {
+	names, err := r.client.LRange(ctx, fmt.Sprintf(spaceCategoriesKeyFormat, spaceID), 0, -1).Result()
+	if err != nil || len(names) == 0 {
+		return nil, nil, err
+	}
+
+	pipe := r.client.Pipeline()
+	cmds := make([]*redis.StringSliceCmd, len(names))
+	for i, name := range names {
+		cmds[i] = pipe.LRange(ctx, fmt.Sprintf(spaceCategoryKeyFormat, spaceID, name), 0, -1)
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	roomIDs := make([][]string, len(names))
+	for i, cmd := range cmds {
+		roomIDs[i] = cmd.Val()
+	}
+	return names, roomIDs, nil
+}
+
+// removeRoomFromSpace queues the removal of the room from its space category on pipe
+func (r *RedisRepository) removeRoomFromSpace(ctx context.Context, pipe redis.Pipeliner, roomID string) error {
+	fields, err := r.client.HMGet(ctx, fmt.Sprintf(roomKeyFormat, roomKey, roomID), "space_id", "category").Result()
+	if err != nil {
+		return err
+	}
+
+	spaceID, _ := fields[0].(string)
+	category, _ := fields[1].(string)
+	if spaceID == "" {
+		return nil
+	}
+	pipe.LRem(ctx, fmt.Sprintf(spaceCategoryKeyFormat, spaceID, category), 0, roomID)
+	pipe.SRem(ctx, fmt.Sprintf(spaceRoomsKeyFormat, spaceID), roomID)
+	return nil
+}
@@ -0,0 +1,209 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrInvalidSpace   = errors.New("space and category names cannot be empty or repeated")
+	ErrNotSpaceOwner  = errors.New("only the space owner can perform this action")
+	ErrNotSpaceMember = errors.New("user is not a member of the space")
+	ErrPrivateSpace   = errors.New("space is private, its owner has to add you")
+	ErrRoomNotInSpace = errors.New("room does not belong to a space")
+)
+
+// CreateSpace creates a space owned and joined by its creator with the given categories
+func (s *RoomService) CreateSpace(ctx context.Context, name, userID string, isPrivate bool, categories []string) (*Space, error) {
+	if strings.TrimSpace(name) == "" {
+		return nil, ErrInvalidSpace
+	}
+	seen := make(map[string]struct{}, len(categories))
+	for _, category := range categories {
+		if _, ok := seen[category]; ok || strings.TrimSpace(category) == "" {
+			return nil, ErrInvalidSpace
+		}
+		seen[category] = struct{}{}
+	}
+
+	space := &Space{
+		ID:        uuid.New().String(),
+		Name:      name,
+		IsPrivate: isPrivate,
+		CreatedAt: time.Now(),
+		CreatedBy: userID,
+	}
+	if err := s.repo.CreateSpace(ctx, space, categories); err != nil {
+		return nil, err
+	}
+	return space, nil
+}
+
+// JoinSpace makes the user a member of a public space
+func (s *RoomService) JoinSpace(ctx context.Context, spaceID, userID string) (*Space, error) {
+	space, err := s.repo.GetSpace(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if space.IsPrivate {
+		isMember, err := s.repo.IsSpaceMember(ctx, spaceID, userID)
+		if err != nil {
+			return nil, err
+		}
+		if !isMember {
+			return nil, ErrPrivateSpace
+		}
+		return space, nil
+	}
+
+	if err := s.repo.AddSpaceMember(ctx, spaceID, userID); err != nil {
+		return nil, err
+	}
+	return space, nil
+}
+
+// AddSpaceMember lets the space owner add members, the only way into a private space
+func (s *RoomService) AddSpaceMember(ctx context.Context, spaceID, memberID, userID string) error {
+	space, err := s.repo.GetSpace(ctx, spaceID)
+	if err != nil {
+		return err
+	}
+
+	if space.CreatedBy != userID {
+		return ErrNotSpaceOwner
+	}
+	return s.repo.AddSpaceMember(ctx, spaceID, memberID)
+}
+
+// AddRoomToSpace appends the room to a category of the space, both have to be owned by the caller
+func (s *RoomService) AddRoomToSpace(ctx context.Context, spaceID, roomID, category, userID string) (*Room, error) {
+	if strings.TrimSpace(category) == "" {
+		return nil, ErrInvalidSpace
+	}
+
+	space, err := s.repo.GetSpace(ctx, spaceID)
+	if err != nil {
+		return nil, err
+	}
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	if space.CreatedBy != userID {
+		return nil, ErrNotSpaceOwner
+	}
+	if room.CreatedBy != userID {
+		return nil, ErrNotRoomOwner
+	}
+
+	return s.placeRoom(ctx, room, spaceID, category, -1, userID)
+}
+
+// MoveRoom moves a room to position in a category of its space, which is created when missing
+func (s *RoomService) MoveRoom(ctx context.Context, roomID, category string, position int, userID string) (*Room, error) {
+	if strings.TrimSpace(category) == "" {
+		return nil, ErrInvalidSpace
+	}
+
+	room, err := s.repo.GetRoom(ctx, roomID)
+	if err != nil {
+		return nil, err
+	}
+	if room.SpaceID == "" {
+		return nil, ErrRoomNotInSpace
+	}
+
+	space, err := s.repo.GetSpace(ctx, room.SpaceID)
+	if err != nil {
+		return nil, err
+	}
+	if space.CreatedBy != userID {
+		return nil, ErrNotSpaceOwner
+	}
+
+	return s.placeRoom(ctx, room, room.SpaceID, category, position, userID)
+}
+
+func (s *RoomService) placeRoom(ctx context.Context, room *Room, spaceID, category string, position int, userID string) (*Room, error) {
+	if err := s.repo.PlaceRoomInSpace(ctx, spaceID, room.ID, category, position); err != nil {
+		return nil, err
+	}
+	room.SpaceID = spaceID
+	room.Category = category
+
+	s.broadcastRoomUpdate(room, userID)
+	return room, nil
+}
+
+// ListSpaceRooms returns the categories of the space with their rooms, private spaces
+// are only listed to their members. The private rooms of a public space are only listed
+// to their members, since the space does not let anyone else in.
+func (s *RoomService) ListSpaceRooms(ctx context.Context, spaceID, userID string) (*Space, []SpaceCategory, error) {
+	space, err := s.repo.GetSpace(ctx, spaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if space.IsPrivate {
+		isMember, err := s.repo.IsSpaceMember(ctx, spaceID, userID)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !isMember {
+			return nil, nil, ErrNotSpaceMember
+		}
+	}
+
+	categories, err := s.repo.ListSpaceRooms(ctx, spaceID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if space.IsPrivate {
+		return space, categories, nil
+	}
+
+	for i := range categories {
+		rooms := categories[i].Rooms[:0]
+		for _, room := range categories[i].Rooms {
+			visible, err := s.isVisibleRoom(ctx, room, userID)
+			if err != nil {
+				return nil, nil, err
+			}
+			if visible {
+				rooms = append(rooms, room)
+			}
+		}
+		categories[i].Rooms = rooms
+	}
+	return space, categories, nil
+}
+
+// canJoinRoom tells whether the user may become a member of the room without an invite:
+// public rooms are open, private ones to their owner and to the members of their space
+// when that space is private too, since anyone can join a public space
+func (s *RoomService) canJoinRoom(ctx context.Context, room *Room, userID string) (bool, error) {
+	if !room.IsPrivate || room.CreatedBy == userID {
+		return true, nil
+	}
+	if room.SpaceID == "" {
+		return false, nil
+	}
+
+	space, err := s.repo.GetSpace(ctx, room.SpaceID)
+	if errors.Is(err, ErrSpaceNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if !space.IsPrivate {
+		return false, nil
+	}
+	return s.repo.IsSpaceMember(ctx, room.SpaceID, userID)
+}
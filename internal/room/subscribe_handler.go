@@ -0,0 +1,116 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func (h *RoomHandler) Subscribe(stream pb.RoomGrpcService_SubscribeServer) error {
+	userID, ok := stream.Context().Value("user_id").(uuid.UUID)
+	if !ok {
+		return status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	sub, err := h.service.Subscribe(stream.Context(), userID.String())
+	if err != nil {
+		return statusFromError(err, "failed to subscribe")
+	}
+
+	// filters keep coming while events flow, a client done sending still gets its events
+	recvErr := make(chan error, 1)
+	go func() {
+		for {
+			req, err := stream.Recv()
+			if err != nil {
+				if !errors.Is(err, io.EOF) {
+					recvErr <- err
+				}
+				return
+			}
+			for _, filter := range req.Filters {
+				sub.SetFilter(filter.RoomId, roomEventFilterFromPb(filter))
+			}
+		}
+	}()
+
+	for {
+		select {
+		case err := <-recvErr:
+			return err
+		case event, ok := <-sub.Events():
+			if !ok {
+				return eventStreamError(sub)
+			}
+			resp := h.subscribeEventFor(stream.Context(), userID.String(), event)
+			if resp == nil {
+				continue
+			}
+			if err := stream.Send(resp); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// subscribeEventFor converts an event of the subscription of the user, nil when it is not for them
+func (h *RoomHandler) subscribeEventFor(ctx context.Context, userID string, event UserEvent) *pb.SubscribeEvent {
+	resp := &pb.SubscribeEvent{RoomId: event.RoomID}
+	switch event.Type {
+	case UserRoomAdded:
+		resp.Event = &pb.SubscribeEvent_RoomAdded{RoomAdded: convertToPbRoom(event.Room)}
+	case UserRoomRemoved:
+		removed := &pb.RoomRemoved{}
+		if event.Event != nil {
+			var deletion RoomDeletion
+			if err := event.Event.DecodePayload(&deletion); err != nil {
+				log.Printf("Failed to decode room deletion: %v", err)
+			}
+			removed.Deleted = &pb.RoomDeleted{Reason: deletion.Reason, DeletedBy: event.Event.UserID}
+		}
+		resp.Event = &pb.SubscribeEvent_RoomRemoved{RoomRemoved: removed}
+	case UserRoomEvent:
+		if event.Event.Type == EventMessage {
+			var msg ChatMessage
+			if err := event.Event.DecodePayload(&msg); err != nil {
+				log.Printf("Failed to decode message: %v", err)
+				return nil
+			}
+			resp.Event = &pb.SubscribeEvent_Message{Message: convertToPbMessage(&msg)}
+			break
+		}
+		roomEvent := h.roomEventFor(ctx, event.RoomID, userID, *event.Event)
+		if roomEvent == nil {
+			return nil
+		}
+		resp.Event = &pb.SubscribeEvent_RoomEvent{RoomEvent: roomEvent}
+	case UserJoinRequestResolved:
+		if event.Decision == nil {
+			return nil
+		}
+		resp.Event = &pb.SubscribeEvent_JoinRequestResolved{
+			JoinRequestResolved: &pb.JoinRequestResolved{
+				UserId:     userID,
+				Approved:   event.Decision.Approved,
+				ReviewedBy: event.Decision.ReviewedBy,
+			},
+		}
+	default:
+		return nil
+	}
+	return resp
+}
+
+func roomEventFilterFromPb(filter *pb.RoomEventFilter) RoomEventFilter {
+	types := make([]EventType, len(filter.Types))
+	for i, t := range filter.Types {
+		types[i] = EventType(t)
+	}
+	return RoomEventFilter{Types: types, Muted: filter.Muted}
+}
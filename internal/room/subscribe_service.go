@@ -0,0 +1,273 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+)
+
+// UserSubscription multiplexes the events of every room a user belongs to over a single stream.
+// Rooms are followed and dropped as the memberships of the user change. Each room goes through
+// the feed this node shares for it, so the slow consumer policy still applies per room.
+type UserSubscription struct {
+	service *RoomService
+	userID  string
+	ctx     context.Context
+	cancel  context.CancelFunc
+	events  chan UserEvent
+	// wg tracks every goroutine sending on events, which is closed once they are all gone
+	wg sync.WaitGroup
+
+	mu      sync.Mutex
+	rooms   map[string]*followedRoom
+	filters map[string]RoomEventFilter
+	err     error
+}
+
+// followedRoom is a room of the subscription, done is closed once its events stopped flowing
+type followedRoom struct {
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// Events is closed once the subscription ends
+func (s *UserSubscription) Events() <-chan UserEvent {
+	return s.events
+}
+
+// Err tells why Events was closed, it is nil when the context of the subscription was done
+func (s *UserSubscription) Err() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// SetFilter replaces the filter of the room, followed or not yet. Events already queued for
+// the stream are not filtered again.
+func (s *UserSubscription) SetFilter(roomID string, filter RoomEventFilter) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.filters[roomID] = filter
+}
+
+func (s *UserSubscription) accepts(roomID string, eventType EventType) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.filters[roomID].Accepts(eventType)
+}
+
+// Subscribe streams the events of every room the user is a member of, along with the rooms
+// joined and left and the answers to the join requests of the user, until ctx is done.
+// The rooms the user already belongs to are announced first with UserRoomAdded.
+func (s *RoomService) Subscribe(ctx context.Context, userID string) (*UserSubscription, error) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	// subscribes before listing the rooms so no membership change falls in between
+	updates := s.repo.SubscribeToUser(ctx, userID)
+	roomIDs, err := s.repo.ListUserRooms(ctx, userID)
+	if err != nil {
+		cancel()
+		updates.Close()
+		return nil, err
+	}
+
+	sub := &UserSubscription{
+		service: s,
+		userID:  userID,
+		ctx:     ctx,
+		cancel:  cancel,
+		events:  make(chan UserEvent),
+		rooms:   make(map[string]*followedRoom),
+		filters: make(map[string]RoomEventFilter),
+	}
+
+	// the Redis subscription does not watch ctx, closing it unblocks Receive
+	go func() {
+		<-ctx.Done()
+		updates.Close()
+	}()
+
+	sub.wg.Add(1)
+	go sub.run(updates, roomIDs)
+	go func() {
+		sub.wg.Wait()
+		close(sub.events)
+	}()
+
+	return sub, nil
+}
+
+// run follows the initial rooms then applies the membership changes published for the user,
+// it is the only goroutine adding and removing rooms so they are applied in order
+func (s *UserSubscription) run(updates Subscription, roomIDs []string) {
+	defer s.wg.Done()
+
+	for _, roomID := range roomIDs {
+		s.follow(roomID)
+	}
+
+	for {
+		payload, err := updates.Receive(s.ctx)
+		if err != nil {
+			if errors.Is(err, ErrSubscriptionClosed) || s.ctx.Err() != nil {
+				return
+			}
+			log.Printf("PubSub error: %v", err)
+			continue
+		}
+
+		var event UserEvent
+		if err := json.Unmarshal(payload, &event); err != nil {
+			log.Printf("Failed to unmarshal user event: %v", err)
+			continue
+		}
+
+		switch event.Type {
+		case UserRoomAdded:
+			s.follow(event.RoomID)
+		case UserRoomRemoved:
+			s.unfollow(event.RoomID)
+		case UserJoinRequestResolved:
+			if !s.send(s.ctx, event) {
+				return
+			}
+		}
+	}
+}
+
+// follow starts delivering the events of the room, it does nothing for a room already followed
+// or one the user is no longer a member of
+func (s *UserSubscription) follow(roomID string) {
+	s.mu.Lock()
+	_, exists := s.rooms[roomID]
+	s.mu.Unlock()
+	if exists {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(s.ctx)
+	room, err := s.service.repo.GetRoom(ctx, roomID)
+	if err == nil {
+		var isMember bool
+		isMember, err = s.service.repo.IsRoomMember(ctx, roomID, s.userID)
+		if err == nil && !isMember {
+			err = ErrNotRoomMember
+		}
+	}
+	if err != nil {
+		if !errors.Is(err, ErrRoomNotFound) && !errors.Is(err, ErrNotRoomMember) {
+			log.Printf("Failed to follow room %s for user %s: %v", roomID, s.userID, err)
+		}
+		cancel()
+		return
+	}
+
+	session, err := s.service.startSession(ctx, roomID, s.userID)
+	if err != nil {
+		log.Printf("Failed to start presence session: %v", err)
+		cancel()
+		return
+	}
+
+	// the deletion always gets through, it ends the room for the subscription whatever the filter
+	stream := s.service.hub.subscribe(ctx, roomID, func(event RoomEvent) bool {
+		return event.Type == EventRoomDeleted || s.accepts(roomID, event.Type)
+	})
+
+	followed := &followedRoom{cancel: cancel, done: make(chan struct{})}
+	s.mu.Lock()
+	s.rooms[roomID] = followed
+	s.mu.Unlock()
+
+	// announced before the forwarder starts so the room comes before its events
+	if !s.send(ctx, UserEvent{Type: UserRoomAdded, RoomID: roomID, Room: room}) {
+		cancel()
+		s.service.endSession(session)
+		close(followed.done)
+		return
+	}
+
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		defer close(followed.done)
+		defer s.service.endSession(session)
+		s.forward(ctx, roomID, followed, stream)
+	}()
+}
+
+// unfollow stops the events of the room and announces it once the last of them went out
+func (s *UserSubscription) unfollow(roomID string) {
+	s.mu.Lock()
+	followed, ok := s.rooms[roomID]
+	if ok {
+		delete(s.rooms, roomID)
+	}
+	s.mu.Unlock()
+	if !ok {
+		return
+	}
+
+	followed.cancel()
+	<-followed.done
+	s.send(s.ctx, UserEvent{Type: UserRoomRemoved, RoomID: roomID})
+}
+
+// forward hands the events of a room stream to the subscription until the room is unfollowed or deleted
+func (s *UserSubscription) forward(ctx context.Context, roomID string, followed *followedRoom, stream *EventStream) {
+	var deletion *RoomEvent
+	for event := range stream.Events() {
+		if event.Type == EventRoomDeleted {
+			deletion = &event
+			continue
+		}
+		if !s.send(ctx, UserEvent{Type: UserRoomEvent, RoomID: roomID, Event: &event}) {
+			return
+		}
+	}
+
+	switch err := stream.Err(); {
+	case errors.Is(err, ErrRoomDeleted):
+		s.mu.Lock()
+		owned := s.rooms[roomID] == followed
+		if owned {
+			delete(s.rooms, roomID)
+		}
+		s.mu.Unlock()
+		followed.cancel()
+		// otherwise unfollow got to the room first and announces it
+		if owned {
+			s.send(s.ctx, UserEvent{Type: UserRoomRemoved, RoomID: roomID, Event: deletion})
+		}
+	case err != nil:
+		// a room that cannot keep up ends the whole subscription, the client has to resync anyway
+		s.fail(err)
+	}
+}
+
+func (s *UserSubscription) send(ctx context.Context, event UserEvent) bool {
+	select {
+	case s.events <- event:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (s *UserSubscription) fail(err error) {
+	s.mu.Lock()
+	if s.err == nil {
+		s.err = err
+	}
+	s.mu.Unlock()
+	s.cancel()
+}
+
+// notifyUser publishes the event on the channel of the user, for their subscriptions on every node
+func (s *RoomService) notifyUser(userID string, event UserEvent) {
+	if err := s.repo.PublishUserEvent(context.Background(), userID, event); err != nil {
+		log.Printf("Failed to publish event to user %s: %v", userID, err)
+	}
+}
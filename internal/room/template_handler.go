@@ -0,0 +1,100 @@
+package room
+
+import (
+	"context"
+	"sort"
+
+	"github.com/assu-2000/StreamRPC/internal/pb"
+	"github.com/google/uuid"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func (h *RoomHandler) CreateRoomTemplate(ctx context.Context, req *pb.CreateRoomTemplateRequest) (*pb.RoomTemplate, error) {
+	userID, ok := ctx.Value("user_id").(uuid.UUID)
+	if !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+	if req.Template == nil {
+		return nil, status.Error(codes.InvalidArgument, "template is required")
+	}
+
+	template, err := h.service.CreateRoomTemplate(ctx, roomTemplateFromPb(req.Template), userID.String())
+	if err != nil {
+		return nil, statusFromError(err, "failed to create room template")
+	}
+	return convertToPbRoomTemplate(template), nil
+}
+
+func (h *RoomHandler) ListRoomTemplates(ctx context.Context, req *pb.ListRoomTemplatesRequest) (*pb.ListRoomTemplatesResponse, error) {
+	if _, ok := ctx.Value("user_id").(uuid.UUID); !ok {
+		return nil, status.Error(codes.Unauthenticated, "invalid user")
+	}
+
+	templates, err := h.service.ListRoomTemplates(ctx)
+	if err != nil {
+		return nil, statusFromError(err, "failed to list room templates")
+	}
+
+	resp := &pb.ListRoomTemplatesResponse{Templates: make([]*pb.RoomTemplate, len(templates))}
+	for i, template := range templates {
+		resp.Templates[i] = convertToPbRoomTemplate(template)
+	}
+	return resp, nil
+}
+
+func roomTemplateFromPb(template *pb.RoomTemplate) RoomTemplate {
+	converted := RoomTemplate{
+		Name:             template.Name,
+		NamePattern:      template.NamePattern,
+		IsPrivate:        template.IsPrivate,
+		DefaultRoles:     make(map[string]MemberRole, len(template.DefaultRoles)),
+		WelcomeMessage:   template.WelcomeMessage,
+		MessageRetention: template.MessageRetention.AsDuration(),
+		Metadata:         make(map[string]map[string]string, len(template.Metadata)),
+	}
+	for userID, role := range template.DefaultRoles {
+		converted.DefaultRoles[userID] = MemberRole(role)
+	}
+	for _, metadata := range template.Metadata {
+		values := converted.Metadata[metadata.Namespace]
+		if values == nil {
+			values = make(map[string]string, len(metadata.Values))
+			converted.Metadata[metadata.Namespace] = values
+		}
+		for key, value := range metadata.Values {
+			values[key] = value
+		}
+	}
+	return converted
+}
+
+func convertToPbRoomTemplate(template *RoomTemplate) *pb.RoomTemplate {
+	pbTemplate := &pb.RoomTemplate{
+		Id:             template.ID,
+		Name:           template.Name,
+		NamePattern:    template.NamePattern,
+		IsPrivate:      template.IsPrivate,
+		DefaultRoles:   make(map[string]pb.MemberRole, len(template.DefaultRoles)),
+		WelcomeMessage: template.WelcomeMessage,
+		CreatedBy:      template.CreatedBy,
+		CreatedAt:      timestamppb.New(template.CreatedAt),
+	}
+	for userID, role := range template.DefaultRoles {
+		pbTemplate.DefaultRoles[userID] = pb.MemberRole(role)
+	}
+	if template.MessageRetention > 0 {
+		pbTemplate.MessageRetention = durationpb.New(template.MessageRetention)
+	}
+	namespaces := make([]string, 0, len(template.Metadata))
+	for namespace := range template.Metadata {
+		namespaces = append(namespaces, namespace)
+	}
+	sort.Strings(namespaces)
+	for _, namespace := range namespaces {
+		pbTemplate.Metadata = append(pbTemplate.Metadata, &pb.TemplateMetadata{Namespace: namespace, Values: template.Metadata[namespace]})
+	}
+	return pbTemplate
+}
@@ -0,0 +1,110 @@
+package room
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// roomTemplatesKey holds every room template JSON-encoded under its ID
+const roomTemplatesKey = "room_templates"
+
+var ErrTemplateNotFound = errors.New("room template not found")
+
+func (r *RedisRepository) CreateRoomTemplate(ctx context.Context, template *RoomTemplate) error {
+	raw, err := json.Marshal(template)
+	if err != nil {
+		return fmt.Errorf("failed to marshal room template: %w", err)
+	}
+	return r.client.HSet(ctx, roomTemplatesKey, template.ID, raw).Err()
+}
+
+func (r *RedisRepository) GetRoomTemplate(ctx context.Context, templateID string) (*RoomTemplate, error) {
+	raw, err := r.client.HGet(ctx, roomTemplatesKey, templateID).Result()
+	if errors.Is(err, redis.Nil) {
+		return nil, ErrTemplateNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return parseRoomTemplate(templateID, raw)
+}
+
+func (r *RedisRepository) ListRoomTemplates(ctx context.Context) ([]*RoomTemplate, error) {
+	values, err := r.client.HGetAll(ctx, roomTemplatesKey).Result()
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*RoomTemplate, 0, len(values))
+	for templateID, raw := range values {
+		template, err := parseRoomTemplate(templateID, raw)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	return templates, nil
+}
+
+func parseRoomTemplate(templateID, raw string) (*RoomTemplate, error) {
+	var template RoomTemplate
+	if err := json.Unmarshal([]byte(raw), &template); err != nil {
+		return nil, fmt.Errorf("invalid room template %s: %w", templateID, err)
+	}
+	return &template, nil
+}
+
+// CreateRoomWithSetup writes the room, its indexes, members, metadata and messages in a single MULTI
+func (r *RedisRepository) CreateRoomWithSetup(ctx context.Context, room *Room, setup RoomSetup) error {
+	created := *room
+	created.MemberCount = len(setup.Members)
+
+	pipe := r.client.TxPipeline()
+	pipe.HSet(ctx, fmt.Sprintf(roomKeyFormat, roomKey, room.ID), roomFields(&created)...)
+	pipe.SAdd(ctx, roomsKey, room.ID)
+	addRoomToIndexes(ctx, pipe, &created)
+	pipe.ZAdd(ctx, roomsByMemberCountKey, redis.Z{Score: float64(created.MemberCount), Member: room.ID})
+
+	joinedAt := float64(room.CreatedAt.UnixMilli())
+	for userID, role := range setup.Members {
+		pipe.SAdd(ctx, fmt.Sprintf(roomMembersKeyFormat, room.ID), userID)
+		pipe.ZAdd(ctx, fmt.Sprintf(roomJoinedKeyFormat, room.ID), redis.Z{Score: joinedAt, Member: userID})
+		pipe.SAdd(ctx, fmt.Sprintf(userRoomsKeyFormat, userID), room.ID)
+		if role > RoleMember {
+			pipe.HSet(ctx, fmt.Sprintf(roomRolesKeyFormat, room.ID), userID, int(role))
+		}
+	}
+	if err := queueRoomContent(ctx, pipe, room.ID, setup); err != nil {
+		return err
+	}
+
+	_, err := pipe.Exec(ctx)
+	return err
+}
+
+// queueRoomContent adds the metadata and messages of the setup to the pipeline
+func queueRoomContent(ctx context.Context, pipe redis.Pipeliner, roomID string, setup RoomSetup) error {
+	for namespace, values := range setup.Metadata {
+		for key, value := range values {
+			pipe.HSet(ctx, fmt.Sprintf(roomMetadataKeyFormat, roomID), metadataField(namespace, key), value)
+		}
+	}
+
+	if len(setup.Messages) == 0 {
+		return nil
+	}
+	values := make([]interface{}, len(setup.Messages))
+	for i, msg := range setup.Messages {
+		raw, err := json.Marshal(msg)
+		if err != nil {
+			return fmt.Errorf("failed to marshal message: %w", err)
+		}
+		values[i] = raw
+	}
+	pipe.RPush(ctx, fmt.Sprintf(roomMessagesKeyFormat, roomID), values...)
+	return nil
+}
@@ -0,0 +1,175 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	maxTemplateNameLength   = 100
+	maxTemplateDefaultRoles = 100
+	maxRoomNameLength       = 100
+)
+
+var (
+	ErrInvalidTemplate = errors.New("template needs a name and a pattern of up to 100 characters, roles from member to admin for at most 100 users, a welcome message up to 4000 characters, a non-negative retention and valid metadata")
+	ErrInvalidRoomName = errors.New("room name must be between 1 and 100 characters")
+)
+
+// CreateRoomTemplate stores a template every user can then create rooms from. Templates make
+// their default roles members of each room, so only admins define them.
+func (s *RoomService) CreateRoomTemplate(ctx context.Context, template RoomTemplate, userID string) (*RoomTemplate, error) {
+	if !s.IsAdmin(userID) {
+		return nil, ErrNotAdmin
+	}
+	if template.NamePattern == "" {
+		template.NamePattern = "{name}"
+	}
+	if err := validateRoomTemplate(&template); err != nil {
+		return nil, err
+	}
+
+	template.ID = uuid.New().String()
+	template.CreatedBy = userID
+	template.CreatedAt = time.Now()
+	if err := s.repo.CreateRoomTemplate(ctx, &template); err != nil {
+		return nil, err
+	}
+	return &template, nil
+}
+
+// ListRoomTemplates returns every template sorted by name
+func (s *RoomService) ListRoomTemplates(ctx context.Context) ([]*RoomTemplate, error) {
+	templates, err := s.repo.ListRoomTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(templates, func(i, j int) bool {
+		if templates[i].Name != templates[j].Name {
+			return templates[i].Name < templates[j].Name
+		}
+		return templates[i].ID < templates[j].ID
+	})
+	return templates, nil
+}
+
+// CreateRoomFromTemplate creates a room set up by the template, name fills the {name} of its pattern.
+// The room is stored together with its members, roles, metadata and welcome message in one go,
+// so it never shows up with only part of them.
+func (s *RoomService) CreateRoomFromTemplate(ctx context.Context, templateID, name, creatorID string, maxMembers int) (*Room, error) {
+	if maxMembers < 0 {
+		return nil, ErrInvalidCapacity
+	}
+
+	template, err := s.repo.GetRoomTemplate(ctx, templateID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	roomName := strings.TrimSpace(expandNamePattern(template.NamePattern, name, now))
+	if roomName == "" || utf8.RuneCountInString(roomName) > maxRoomNameLength {
+		return nil, ErrInvalidRoomName
+	}
+
+	room := &Room{
+		ID:               uuid.New().String(),
+		Name:             roomName,
+		CreatedAt:        now,
+		CreatedBy:        creatorID,
+		IsPrivate:        template.IsPrivate,
+		MaxMembers:       maxMembers,
+		MessageRetention: template.MessageRetention,
+		LastActivity:     now,
+	}
+	var welcome *ChatMessage
+	if template.WelcomeMessage != "" {
+		welcome = &ChatMessage{
+			ID:        uuid.New().String(),
+			RoomID:    room.ID,
+			UserID:    creatorID,
+			Content:   template.WelcomeMessage,
+			Timestamp: now,
+		}
+		room.PinnedMessage = welcome
+	}
+
+	setup := RoomSetup{
+		Members:  make(map[string]MemberRole, len(template.DefaultRoles)),
+		Metadata: template.Metadata,
+	}
+	for memberID, role := range template.DefaultRoles {
+		// the creator already owns the room
+		if memberID != creatorID {
+			setup.Members[memberID] = role
+		}
+	}
+	if maxMembers > 0 && len(setup.Members) > maxMembers {
+		return nil, ErrRoomFull
+	}
+	if welcome != nil {
+		setup.Messages = []*ChatMessage{welcome}
+	}
+
+	if err := s.repo.CreateRoomWithSetup(ctx, room, setup); err != nil {
+		return nil, err
+	}
+
+	created, err := s.repo.GetRoom(ctx, room.ID)
+	if err != nil {
+		return nil, err
+	}
+	s.publishRoomChange(DirectoryRoomCreated, created, creatorID)
+	for memberID := range setup.Members {
+		s.notifyUser(memberID, UserEvent{Type: UserRoomAdded, RoomID: created.ID})
+	}
+	return created, nil
+}
+
+// expandNamePattern replaces {name} by name and {date} by the UTC date of now
+func expandNamePattern(pattern, name string, now time.Time) string {
+	return strings.NewReplacer(
+		"{name}", name,
+		"{date}", now.UTC().Format(time.DateOnly),
+	).Replace(pattern)
+}
+
+func validateRoomTemplate(template *RoomTemplate) error {
+	if template.Name == "" || utf8.RuneCountInString(template.Name) > maxTemplateNameLength ||
+		utf8.RuneCountInString(template.NamePattern) > maxRoomNameLength {
+		return ErrInvalidTemplate
+	}
+	if utf8.RuneCountInString(template.WelcomeMessage) > maxMessageLength || template.MessageRetention < 0 {
+		return ErrInvalidTemplate
+	}
+
+	if len(template.DefaultRoles) > maxTemplateDefaultRoles {
+		return ErrInvalidTemplate
+	}
+	for userID, role := range template.DefaultRoles {
+		if _, err := uuid.Parse(userID); err != nil || role < RoleMember || role >= RoleOwner {
+			return ErrInvalidTemplate
+		}
+	}
+
+	keys := 0
+	for namespace, values := range template.Metadata {
+		for key, value := range values {
+			if validateMetadataKey(namespace, key) != nil || len(value) > maxMetadataValueSize {
+				return ErrInvalidTemplate
+			}
+			keys++
+		}
+	}
+	if keys > maxMetadataKeysPerRoom {
+		return ErrInvalidTemplate
+	}
+	return nil
+}
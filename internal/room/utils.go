@@ -2,28 +2,182 @@ package room
 
 import (
 	"context"
-	"github.com/redis/go-redis/v9"
+	"errors"
+	"time"
 )
 
+var ErrSubscriptionClosed = errors.New("subscription closed")
+
+// Subscription receives the events published to a room, whatever broker carries them
+type Subscription interface {
+	// Receive blocks until the next JSON-encoded event and returns ErrSubscriptionClosed once closed
+	Receive(ctx context.Context) ([]byte, error)
+	Close() error
+}
+
+// ProfileDirectory resolves the accounts behind user IDs
+type ProfileDirectory interface {
+	// GetUserProfiles returns the profiles found, unknown IDs are left out
+	GetUserProfiles(ctx context.Context, userIDs []string) (map[string]UserProfile, error)
+}
+
 type RoomRepository interface {
 	// Room Management
 	CreateRoom(ctx context.Context, room *Room) error
 	GetRoom(ctx context.Context, roomID string) (*Room, error)
+	UpdateRoom(ctx context.Context, room *Room) error
 	DeleteRoom(ctx context.Context, roomID string) error
 	RoomExists(ctx context.Context, roomID string) (bool, error)
 	ListRoomIDs(ctx context.Context) ([]string, error)
+	ListRooms(ctx context.Context, opts RoomListOptions) ([]*Room, string, error)
+	// SearchRoomsPage returns up to limit rooms past the cursor whose name or topic matches the normalized
+	// query, best first. Archived rooms are left out and private ones only match for their owner and members.
+	SearchRoomsPage(ctx context.Context, query, userID string, after *searchCursor, limit int) ([]RoomMatch, error)
+
+	// Templates
+	CreateRoomTemplate(ctx context.Context, template *RoomTemplate) error
+	GetRoomTemplate(ctx context.Context, templateID string) (*RoomTemplate, error)
+	ListRoomTemplates(ctx context.Context) ([]*RoomTemplate, error)
+	// CreateRoomWithSetup creates the room together with its members, metadata and messages. The room
+	// and its members are stored or not at once, metadata and messages kept apart from the rooms
+	// are only written once the room is stored.
+	CreateRoomWithSetup(ctx context.Context, room *Room, setup RoomSetup) error
+
+	// Archiving
+	ArchiveRoom(ctx context.Context, roomID, userID string, archivedAt, purgeAt time.Time) error
+	UnarchiveRoom(ctx context.Context, roomID string) error
+	ClaimRoomsToPurge(ctx context.Context, now, until time.Time, limit int) ([]string, error)
+	// PurgeArchivedRoom deletes the room only if it is still archived and due by now, it tells whether it did
+	PurgeArchivedRoom(ctx context.Context, roomID string, now time.Time) (bool, error)
 
 	// Membership Management
 	AddRoomMember(ctx context.Context, roomID, userID string) error
 	RemoveRoomMember(ctx context.Context, roomID, userID string) error
+	QueueRoomMember(ctx context.Context, roomID, userID string) (int, error)
+	PromoteWaitlist(ctx context.Context, roomID string) ([]string, error)
+	RemoveFromWaitlist(ctx context.Context, roomID, userID string) error
 	GetRoomMembers(ctx context.Context, roomID string) ([]string, error)
 	IsRoomMember(ctx context.Context, roomID, userID string) (bool, error)
+	ListUserRooms(ctx context.Context, userID string) ([]string, error)
 	RemoveAllMembers(ctx context.Context, roomID string) error
+	TouchRoomActivity(ctx context.Context, roomID string, at time.Time) error
+
+	// Roles and rate limits
+	SetMemberRole(ctx context.Context, roomID, userID string, role MemberRole) error
+	GetMemberRole(ctx context.Context, roomID, userID string) (MemberRole, error)
+	GetMemberRoles(ctx context.Context, roomID string) (map[string]MemberRole, error)
+	GetMemberJoinTimes(ctx context.Context, roomID string) (map[string]time.Time, error)
+	ListRoomMembersPage(ctx context.Context, roomID string, after *memberCursor, limit int) ([]MemberInfo, error)
+	TransferOwnership(ctx context.Context, roomID, ownerID, newOwnerID string) error
+	MuteMember(ctx context.Context, roomID, userID string, until time.Time) error
+	UnmuteMember(ctx context.Context, roomID, userID string) error
+	IsMuted(ctx context.Context, roomID, userID string) (bool, error)
+	GetRoomMutes(ctx context.Context, roomID string) (map[string]time.Time, error)
+	GetMemberMutes(ctx context.Context, roomID string, userIDs []string) (map[string]time.Time, error)
+	TakePostSlot(ctx context.Context, roomID, userID string, interval time.Duration) (time.Duration, error)
+	ReleasePostSlot(ctx context.Context, roomID, userID string) error
+
+	// Message history
+	AppendMessages(ctx context.Context, roomID string, messages []*ChatMessage, limit int) error
+	GetMessages(ctx context.Context, roomID string) ([]*ChatMessage, error)
+	DropMessagesBefore(ctx context.Context, roomID string, before time.Time) error
+
+	// Invites
+	CreateInvite(ctx context.Context, invite *InviteLink) error
+	GetInvite(ctx context.Context, code string) (*InviteLink, error)
+	ListInvites(ctx context.Context, roomID string) ([]*InviteLink, error)
+	DeleteInvite(ctx context.Context, roomID, code string) error
+	// JoinByInvite makes userID a member of the room of the invite and counts one use of it in one step.
+	// It returns false, leaving the invite untouched, when the user already is a member.
+	JoinByInvite(ctx context.Context, code, userID string) (bool, error)
+
+	// Join requests
+	AddJoinRequest(ctx context.Context, request *JoinRequest) (*JoinRequest, bool, error)
+	ListJoinRequests(ctx context.Context, roomID string) ([]*JoinRequest, error)
+	TakeJoinRequest(ctx context.Context, roomID, userID string) (*JoinRequest, error)
+
+	// Metadata
+	SetRoomMetadata(ctx context.Context, roomID, namespace, key, value string, maxKeys int) error
+	GetRoomMetadata(ctx context.Context, roomID, namespace string) (map[string]string, error)
+	DeleteRoomMetadata(ctx context.Context, roomID, namespace, key string) (bool, error)
+	SetMetadataPolicy(ctx context.Context, roomID, namespace string, policy MetadataPolicy) error
+	GetMetadataPolicy(ctx context.Context, roomID, namespace string) (*MetadataPolicy, error)
+
+	// Reconciliation
+	FindRoomInconsistencies(ctx context.Context) ([]RoomInconsistency, error)
+	RepairRoomInconsistency(ctx context.Context, inconsistency RoomInconsistency) error
+
+	// Spaces
+	CreateSpace(ctx context.Context, space *Space, categories []string) error
+	GetSpace(ctx context.Context, spaceID string) (*Space, error)
+	AddSpaceMember(ctx context.Context, spaceID, userID string) error
+	IsSpaceMember(ctx context.Context, spaceID, userID string) (bool, error)
+	PlaceRoomInSpace(ctx context.Context, spaceID, roomID, category string, position int) error
+	ListSpaceRooms(ctx context.Context, spaceID string) ([]SpaceCategory, error)
+
+	// Presence
+	AddPresence(ctx context.Context, session PresenceSession) error
+	RefreshPresence(ctx context.Context, sessions []PresenceSession) error
+	RemovePresence(ctx context.Context, session PresenceSession) error
+	RemoveUserPresence(ctx context.Context, roomID, userID string) error
+	GetRoomPresence(ctx context.Context, roomID string) ([]PresenceSession, error)
+	GetMembersPresence(ctx context.Context, roomID string, userIDs []string) ([]PresenceSession, error)
+	GetUserPresence(ctx context.Context, userID string) ([]PresenceSession, error)
 
 	// PubSub
-	SubscribeToRoom(ctx context.Context, roomID string) *redis.PubSub
+	SubscribeToRoom(ctx context.Context, roomID string) Subscription
 	PublishRoomEvent(ctx context.Context, roomID string, event interface{}) error
+	SubscribeToDirectory(ctx context.Context) Subscription
+	PublishDirectoryEvent(ctx context.Context, event interface{}) error
+	SubscribeToUser(ctx context.Context, userID string) Subscription
+	PublishUserEvent(ctx context.Context, userID string, event interface{}) error
 
 	// Cleanup
 	//RemoveAllMembers(ctx context.Context, roomID string) error
 }
+
+// RoomStore is the durable part of RoomRepository, rooms, their memberships and the room templates,
+// kept in a database behind CachedRepository
+type RoomStore interface {
+	CreateRoom(ctx context.Context, room *Room) error
+	GetRoom(ctx context.Context, roomID string) (*Room, error)
+	UpdateRoom(ctx context.Context, room *Room) error
+	DeleteRoom(ctx context.Context, roomID string) error
+	ListRoomIDs(ctx context.Context) ([]string, error)
+	ListRooms(ctx context.Context, opts RoomListOptions) ([]*Room, string, error)
+	SearchRoomsPage(ctx context.Context, query, userID string, after *searchCursor, limit int) ([]RoomMatch, error)
+
+	CreateRoomTemplate(ctx context.Context, template *RoomTemplate) error
+	GetRoomTemplate(ctx context.Context, templateID string) (*RoomTemplate, error)
+	ListRoomTemplates(ctx context.Context) ([]*RoomTemplate, error)
+	// CreateRoomWithMembers creates the room and makes the users members with their role in one transaction
+	CreateRoomWithMembers(ctx context.Context, room *Room, members map[string]MemberRole) error
+
+	ArchiveRoom(ctx context.Context, roomID, userID string, archivedAt, purgeAt time.Time) error
+	UnarchiveRoom(ctx context.Context, roomID string) error
+	ClaimRoomsToPurge(ctx context.Context, now, until time.Time, limit int) ([]string, error)
+	PurgeArchivedRoom(ctx context.Context, roomID string, now time.Time) (bool, error)
+
+	CreateInvite(ctx context.Context, invite *InviteLink) error
+	GetInvite(ctx context.Context, code string) (*InviteLink, error)
+	ListInvites(ctx context.Context, roomID string) ([]*InviteLink, error)
+	DeleteInvite(ctx context.Context, roomID, code string) error
+	JoinByInvite(ctx context.Context, code, userID string) (bool, error)
+
+	AddRoomMember(ctx context.Context, roomID, userID string) error
+	RemoveRoomMember(ctx context.Context, roomID, userID string) error
+	QueueRoomMember(ctx context.Context, roomID, userID string) (int, error)
+	PromoteWaitlist(ctx context.Context, roomID string) ([]string, error)
+	RemoveFromWaitlist(ctx context.Context, roomID, userID string) error
+	GetRoomMembers(ctx context.Context, roomID string) ([]string, error)
+	ListUserRooms(ctx context.Context, userID string) ([]string, error)
+	RemoveAllMembers(ctx context.Context, roomID string) error
+	TouchRoomActivity(ctx context.Context, roomID string, at time.Time) error
+
+	SetMemberRole(ctx context.Context, roomID, userID string, role MemberRole) error
+	GetMemberRoles(ctx context.Context, roomID string) (map[string]MemberRole, error)
+	GetMemberJoinTimes(ctx context.Context, roomID string) (map[string]time.Time, error)
+	ListRoomMembersPage(ctx context.Context, roomID string, after *memberCursor, limit int) ([]MemberInfo, error)
+	TransferOwnership(ctx context.Context, roomID, ownerID, newOwnerID string) error
+	SetRoomSpace(ctx context.Context, roomID, spaceID, category string) error
+}
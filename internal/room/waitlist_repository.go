@@ -0,0 +1,59 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// The waitlist of a room is a sorted set of user IDs scored by the time they queued
+const roomWaitlistKeyFormat = "room:%s:waitlist"
+
+var ErrRoomFull = errors.New("room is full")
+
+// promoteWaitlistScript moves users from the head of the waitlist into the room while it
+// has free slots and returns them, it takes the membership KEYS and the room ID, the activity
+// time and the format of the rooms of a user as ARGV, the promoted users being only known to the script
+var promoteWaitlistScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 0 then
+	return {}
+end
+local maxMembers = tonumber(redis.call('HGET', KEYS[1], 'max_members')) or 0
+local promoted = {}
+while maxMembers == 0 or redis.call('SCARD', KEYS[2]) < maxMembers do
+	local head = redis.call('ZPOPMIN', KEYS[5])
+	if #head == 0 then
+		break
+	end
+	if redis.call('SADD', KEYS[2], head[1]) == 1 then
+		redis.call('ZADD', KEYS[7], ARGV[2], head[1])
+		redis.call('SADD', string.format(ARGV[4], head[1]), ARGV[1])
+		redis.call('HINCRBY', KEYS[1], 'member_count', 1)
+		redis.call('ZINCRBY', KEYS[3], 1, ARGV[1])
+		table.insert(promoted, head[1])
+	end
+end
+if #promoted > 0 then
+	redis.call('HSET', KEYS[1], 'last_activity', ARGV[3])
+	redis.call('ZADD', KEYS[4], ARGV[2], ARGV[1])
+end
+return promoted
+`)
+
+// PromoteWaitlist fills the free slots of the room from its waitlist, in queue order
+func (r *RedisRepository) PromoteWaitlist(ctx context.Context, roomID string) ([]string, error) {
+	now := time.Now()
+	promoted, err := promoteWaitlistScript.Run(ctx, r.client, membershipKeys(roomID),
+		roomID, now.UnixMilli(), now.Format(time.RFC3339), userRoomsKeyFormat).StringSlice()
+	if err != nil && !errors.Is(err, redis.Nil) {
+		return nil, err
+	}
+	return promoted, nil
+}
+
+func (r *RedisRepository) RemoveFromWaitlist(ctx context.Context, roomID, userID string) error {
+	return r.client.ZRem(ctx, fmt.Sprintf(roomWaitlistKeyFormat, roomID), userID).Err()
+}
@@ -0,0 +1,106 @@
+package room
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sync"
+	"sync/atomic"
+)
+
+var ErrInvalidCapacity = errors.New("max members cannot be negative")
+
+// waitForSlot follows the room for a waitlisted user. Until the user is promoted the stream
+// only gets the promotion itself, its own leave and the room deletion, it then turns into a regular member
+// stream with its own presence session. Leaving before that gives the place in the queue back.
+func (s *RoomService) waitForSlot(ctx context.Context, roomID, userID string) *EventStream {
+	var member atomic.Bool
+	promoted := make(chan struct{})
+	var promote sync.Once
+
+	accept := func(event RoomEvent) bool {
+		if member.Load() {
+			return true
+		}
+		switch event.Type {
+		case EventWaitlistPromoted:
+			if event.UserID != userID {
+				return false
+			}
+			promote.Do(func() {
+				member.Store(true)
+				close(promoted)
+			})
+			return true
+		case EventUserLeft:
+			return event.UserID == userID
+		case EventRoomDeleted:
+			return true
+		}
+		return false
+	}
+	stream := s.hub.subscribe(ctx, roomID, accept)
+
+	// the promotion may have been published before the stream was subscribed
+	if isMember, err := s.repo.IsRoomMember(ctx, roomID, userID); err == nil && isMember {
+		promote.Do(func() {
+			member.Store(true)
+			close(promoted)
+		})
+	}
+
+	go func() {
+		select {
+		case <-ctx.Done():
+			if err := s.repo.RemoveFromWaitlist(context.Background(), roomID, userID); err != nil {
+				log.Printf("Failed to remove user from waitlist: %v", err)
+			}
+			return
+		case <-promoted:
+		}
+
+		session, err := s.startSession(ctx, roomID, userID)
+		if err != nil {
+			log.Printf("Failed to start presence session: %v", err)
+			return
+		}
+		<-ctx.Done()
+		s.endSession(session)
+	}()
+
+	return stream
+}
+
+// promoteWaitlist hands the free slots of the room to the waitlist and announces the new members
+func (s *RoomService) promoteWaitlist(roomID string) {
+	promoted, err := s.repo.PromoteWaitlist(context.Background(), roomID)
+	if err != nil {
+		log.Printf("Failed to promote waitlist: %v", err)
+		return
+	}
+	if len(promoted) == 0 {
+		return
+	}
+
+	room, err := s.repo.GetRoom(context.Background(), roomID)
+	if err != nil {
+		log.Printf("Failed to load room %s after promoting its waitlist: %v", roomID, err)
+	}
+
+	for _, userID := range promoted {
+		s.broadcastRoomEvent(roomID, RoomEvent{
+			Type:   EventWaitlistPromoted,
+			UserID: userID,
+			RoomID: roomID,
+		})
+		s.broadcastRoomEvent(roomID, RoomEvent{
+			Type:   EventUserJoined,
+			UserID: userID,
+			RoomID: roomID,
+		})
+		if room != nil {
+			s.publishMemberDelta(room, userID, 1)
+		}
+		s.notifyUser(userID, UserEvent{Type: UserRoomAdded, RoomID: roomID})
+	}
+}
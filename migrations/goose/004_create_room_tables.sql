@@ -0,0 +1,46 @@
+-- +goose Up
+CREATE TABLE rooms (
+                       id UUID PRIMARY KEY,
+                       name VARCHAR(100) NOT NULL,
+                       topic TEXT NOT NULL DEFAULT '',
+                       description TEXT NOT NULL DEFAULT '',
+                       avatar_url TEXT NOT NULL DEFAULT '',
+                       created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                       created_by UUID NOT NULL REFERENCES users(id),
+                       is_private BOOLEAN NOT NULL DEFAULT FALSE,
+                       max_members INTEGER NOT NULL DEFAULT 0,
+                       slow_mode_interval_ms BIGINT NOT NULL DEFAULT 0,
+                       announcement_only BOOLEAN NOT NULL DEFAULT FALSE,
+                       space_id TEXT NOT NULL DEFAULT '',
+                       category TEXT NOT NULL DEFAULT '',
+                       member_count INTEGER NOT NULL DEFAULT 0,
+                       last_activity TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                       archived_at TIMESTAMP WITH TIME ZONE,
+                       archived_by UUID REFERENCES users(id),
+                       purge_at TIMESTAMP WITH TIME ZONE
+);
+CREATE INDEX idx_rooms_created_at ON rooms(created_at, id);
+CREATE INDEX idx_rooms_member_count ON rooms(member_count, id);
+CREATE INDEX idx_rooms_last_activity ON rooms(last_activity, id);
+CREATE INDEX idx_rooms_purge_at ON rooms(purge_at) WHERE purge_at IS NOT NULL;
+
+CREATE TABLE room_members (
+                              room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+                              user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              role SMALLINT NOT NULL DEFAULT 0,
+                              joined_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                              PRIMARY KEY (room_id, user_id)
+);
+CREATE INDEX idx_room_members_user_id ON room_members(user_id);
+
+CREATE TABLE room_waitlist (
+                               room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+                               user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                               queued_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                               PRIMARY KEY (room_id, user_id)
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS room_waitlist;
+DROP TABLE IF EXISTS room_members;
+DROP TABLE IF EXISTS rooms;
@@ -0,0 +1,8 @@
+-- +goose Up
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+CREATE INDEX idx_rooms_name_trgm ON rooms USING GIN (lower(name) gin_trgm_ops);
+CREATE INDEX idx_rooms_topic_trgm ON rooms USING GIN (lower(topic) gin_trgm_ops);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_rooms_topic_trgm;
+DROP INDEX IF EXISTS idx_rooms_name_trgm;
@@ -0,0 +1,21 @@
+-- +goose Up
+ALTER TABLE rooms ADD COLUMN message_retention_ms BIGINT NOT NULL DEFAULT 0;
+ALTER TABLE rooms ADD COLUMN pinned_message JSONB;
+
+CREATE TABLE room_templates (
+                                id UUID PRIMARY KEY,
+                                name VARCHAR(100) NOT NULL,
+                                name_pattern VARCHAR(100) NOT NULL,
+                                is_private BOOLEAN NOT NULL DEFAULT FALSE,
+                                default_roles JSONB NOT NULL DEFAULT '{}',
+                                welcome_message TEXT NOT NULL DEFAULT '',
+                                message_retention_ms BIGINT NOT NULL DEFAULT 0,
+                                metadata JSONB NOT NULL DEFAULT '{}',
+                                created_by UUID NOT NULL REFERENCES users(id),
+                                created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- +goose Down
+DROP TABLE IF EXISTS room_templates;
+ALTER TABLE rooms DROP COLUMN IF EXISTS pinned_message;
+ALTER TABLE rooms DROP COLUMN IF EXISTS message_retention_ms;
@@ -0,0 +1,5 @@
+-- +goose Up
+ALTER TABLE rooms ADD COLUMN purge_claimed_until TIMESTAMP WITH TIME ZONE;
+
+-- +goose Down
+ALTER TABLE rooms DROP COLUMN IF EXISTS purge_claimed_until;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- room_members rows of a deleted user go with the ON DELETE CASCADE, which the repository
+-- never sees, so the member count of their rooms is moved here before they are deleted
+-- +goose StatementBegin
+CREATE FUNCTION leave_rooms_of_deleted_user() RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE rooms SET member_count = member_count - 1
+    WHERE id IN (SELECT room_id FROM room_members WHERE user_id = OLD.id);
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_users_leave_rooms
+    BEFORE DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION leave_rooms_of_deleted_user();
+
+-- counts drifted by users deleted before the trigger existed
+UPDATE rooms SET member_count = (SELECT COUNT(*) FROM room_members WHERE room_id = rooms.id);
+
+-- +goose Down
+DROP TRIGGER IF EXISTS trg_users_leave_rooms ON users;
+DROP FUNCTION IF EXISTS leave_rooms_of_deleted_user();
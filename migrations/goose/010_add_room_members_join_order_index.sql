@@ -0,0 +1,5 @@
+-- +goose Up
+CREATE INDEX idx_room_members_joined_at ON room_members(room_id, joined_at, user_id);
+
+-- +goose Down
+DROP INDEX IF EXISTS idx_room_members_joined_at;
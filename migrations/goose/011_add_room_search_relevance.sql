@@ -0,0 +1,25 @@
+-- +goose Up
+-- room_text_relevance rates a name or topic against a normalized query the way searchRelevance does
+-- in the service, so search results can be ranked and paged in the database
+-- +goose StatementBegin
+CREATE FUNCTION room_text_relevance(query TEXT, raw TEXT, min_similarity DOUBLE PRECISION)
+RETURNS DOUBLE PRECISION AS $$
+    SELECT CASE
+        WHEN text = '' THEN 0
+        WHEN text = query THEN 1
+        WHEN starts_with(text, query) THEN 0.9
+        WHEN strpos(text, ' ' || query) > 0 THEN 0.8
+        WHEN strpos(text, query) > 0 THEN 0.7
+        WHEN best >= min_similarity THEN 0.6 * best
+        ELSE 0
+    END
+    FROM (SELECT trim(regexp_replace(lower(raw), '[^[:alnum:]]+', ' ', 'g')) AS text) normalized,
+        LATERAL (
+            SELECT GREATEST(similarity(query, text), MAX(similarity(query, word))) AS best
+            FROM unnest(string_to_array(text, ' ')) AS word
+        ) trigrams;
+$$ LANGUAGE SQL IMMUTABLE;
+-- +goose StatementEnd
+
+-- +goose Down
+DROP FUNCTION IF EXISTS room_text_relevance(TEXT, TEXT, DOUBLE PRECISION);
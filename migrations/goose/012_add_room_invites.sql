@@ -0,0 +1,15 @@
+-- +goose Up
+-- invites move next to the memberships, so a join by invite counts its use in the same transaction
+CREATE TABLE room_invites (
+                              code TEXT PRIMARY KEY,
+                              room_id UUID NOT NULL REFERENCES rooms(id) ON DELETE CASCADE,
+                              created_by UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+                              created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+                              expires_at TIMESTAMP WITH TIME ZONE,
+                              max_uses INTEGER NOT NULL DEFAULT 0,
+                              uses INTEGER NOT NULL DEFAULT 0
+);
+CREATE INDEX idx_room_invites_room_id ON room_invites(room_id);
+
+-- +goose Down
+DROP TABLE IF EXISTS room_invites;
@@ -0,0 +1,56 @@
+-- +goose Up
+-- a deleted user's rooms go to the member ranked highest, the one who joined first among equals,
+-- the way handOverOwnership picks the admin taking over; rooms left without any member are deleted.
+-- The new owner drops its member role like with TransferOwnership.
+-- +goose StatementBegin
+CREATE FUNCTION hand_over_rooms_of_deleted_user() RETURNS TRIGGER AS $$
+BEGIN
+    WITH successors AS (
+        SELECT DISTINCT ON (m.room_id) m.room_id, m.user_id
+        FROM room_members m
+        JOIN rooms r ON r.id = m.room_id
+        WHERE r.created_by = OLD.id AND m.user_id <> OLD.id
+        ORDER BY m.room_id, m.role DESC, m.joined_at, m.user_id
+    ), handed AS (
+        UPDATE rooms SET created_by = s.user_id
+        FROM successors s
+        WHERE rooms.id = s.room_id
+        RETURNING rooms.id, rooms.created_by
+    )
+    UPDATE room_members m SET role = 0
+    FROM handed h
+    WHERE m.room_id = h.id AND m.user_id = h.created_by;
+
+    DELETE FROM rooms WHERE created_by = OLD.id;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+-- +goose StatementEnd
+
+CREATE TRIGGER trg_users_hand_over_rooms
+    BEFORE DELETE ON users
+    FOR EACH ROW EXECUTE FUNCTION hand_over_rooms_of_deleted_user();
+
+-- the archiver and the template author are kept for the record only, they must not block the delete
+ALTER TABLE rooms DROP CONSTRAINT rooms_archived_by_fkey;
+ALTER TABLE rooms ADD CONSTRAINT rooms_archived_by_fkey
+    FOREIGN KEY (archived_by) REFERENCES users(id) ON DELETE SET NULL;
+
+ALTER TABLE room_templates ALTER COLUMN created_by DROP NOT NULL;
+ALTER TABLE room_templates DROP CONSTRAINT room_templates_created_by_fkey;
+ALTER TABLE room_templates ADD CONSTRAINT room_templates_created_by_fkey
+    FOREIGN KEY (created_by) REFERENCES users(id) ON DELETE SET NULL;
+
+-- +goose Down
+DELETE FROM room_templates WHERE created_by IS NULL;
+ALTER TABLE room_templates DROP CONSTRAINT room_templates_created_by_fkey;
+ALTER TABLE room_templates ADD CONSTRAINT room_templates_created_by_fkey
+    FOREIGN KEY (created_by) REFERENCES users(id);
+ALTER TABLE room_templates ALTER COLUMN created_by SET NOT NULL;
+
+ALTER TABLE rooms DROP CONSTRAINT rooms_archived_by_fkey;
+ALTER TABLE rooms ADD CONSTRAINT rooms_archived_by_fkey
+    FOREIGN KEY (archived_by) REFERENCES users(id);
+
+DROP TRIGGER IF EXISTS trg_users_hand_over_rooms ON users;
+DROP FUNCTION IF EXISTS hand_over_rooms_of_deleted_user();